@@ -17,6 +17,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
@@ -159,7 +160,7 @@ func GoWSK_balance_multistandardfetcher_FetchBalances(ethClientHandle C.uintptr_
 	}
 
 	// Call fetcher and collect results
-	ch := multistandardfetcher.FetchBalances(ctx, multicallAddress, multicallCaller, fetchConfig, int(batchSizeC))
+	ch := multistandardfetcher.FetchBalances(ctx, multicallAddress, multicallCaller, fetchConfig, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), int(batchSizeC))
 	results := make([]fetchResultJSON, 0)
 
 	// Collect results, checking for context cancellation
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"math/big"
 	"net/http"
 	"strconv"
 	"strings"
@@ -14,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
+	"github.com/status-im/go-wallet-sdk/pkg/amount"
 	"github.com/status-im/go-wallet-sdk/pkg/balance/fetcher"
 )
 
@@ -102,19 +102,17 @@ func handleFetchBalances(w http.ResponseWriter, r *http.Request) {
 		Errors:  []string{},
 	}
 
-	// Parse block number
-	var atBlock gethrpc.BlockNumber
-	if req.BlockNum == "" {
-		atBlock = gethrpc.LatestBlockNumber
-	} else {
-		blockNum, err := strconv.ParseInt(req.BlockNum, 10, 64)
-		if err != nil {
+	// Parse block: decimal number, tag (latest, safe, finalized, pending) or block hash
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
+	if req.BlockNum != "" {
+		if blockNum, err := strconv.ParseInt(req.BlockNum, 10, 64); err == nil {
+			atBlock = gethrpc.BlockNumberOrHashWithNumber(gethrpc.BlockNumber(blockNum))
+		} else if err := json.Unmarshal([]byte(strconv.Quote(req.BlockNum)), &atBlock); err != nil {
 			response.Errors = append(response.Errors, fmt.Sprintf("Invalid block number: %s", req.BlockNum))
 			w.Header().Set("Content-Type", "application/json")
 			json.NewEncoder(w).Encode(response)
 			return
 		}
-		atBlock = gethrpc.BlockNumber(blockNum)
 	}
 
 	// Process each chain
@@ -231,7 +229,7 @@ func handleFetchBalances(w http.ResponseWriter, r *http.Request) {
 			} else {
 				accountBalances.NativeBalance = BalanceResult{
 					Address: addrStr,
-					Balance: weiToEther(nativeBalance),
+					Balance: amount.FormatEther(nativeBalance),
 					Wei:     nativeBalance.String(),
 				}
 			}
@@ -249,7 +247,7 @@ func handleFetchBalances(w http.ResponseWriter, r *http.Request) {
 							TokenAddress: tokenAddrStr,
 							TokenSymbol:  tokenInfo.Symbol,
 							TokenName:    tokenInfo.Name,
-							Balance:      formatTokenBalance(tokenBalance, tokenInfo.Decimals),
+							Balance:      amount.New(tokenBalance, uint(tokenInfo.Decimals)).String(),
 							Wei:          tokenBalance.String(),
 							Decimals:     tokenInfo.Decimals,
 						}
@@ -297,26 +295,3 @@ func getTokenInfo(chainID int, tokenAddress string) TokenInfo {
 		ChainID:  chainID,
 	}
 }
-
-// formatTokenBalance formats a token balance based on its decimals
-func formatTokenBalance(balance *big.Int, decimals int) string {
-	if balance == nil {
-		return "0"
-	}
-
-	// Convert to string with proper decimal places
-	balanceStr := balance.String()
-
-	if decimals == 0 {
-		return balanceStr
-	}
-
-	if len(balanceStr) <= decimals {
-		// Pad with leading zeros
-		padded := strings.Repeat("0", decimals-len(balanceStr)+1) + balanceStr
-		return "0." + padded[1:]
-	}
-
-	// Insert decimal point
-	return balanceStr[:len(balanceStr)-decimals] + "." + balanceStr[len(balanceStr)-decimals:]
-}
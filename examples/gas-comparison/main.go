@@ -2,11 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"math/big"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/status-im/go-wallet-sdk/pkg/amount"
 	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
 	"github.com/status-im/go-wallet-sdk/pkg/gas"
 	"github.com/status-im/go-wallet-sdk/pkg/gas/infura"
@@ -294,13 +295,26 @@ func displayComparison(current *gas.FeeSuggestions, oldFees *old.SuggestedFees,
 	infuraHighMaxFee := big.NewInt(0)
 	infuraBaseFee := big.NewInt(0)
 	if infuraFeesValid {
-		infuraLowPriority = stringToWei(infura.Low.SuggestedMaxPriorityFeePerGas)
-		infuraLowMaxFee = stringToWei(infura.Low.SuggestedMaxFeePerGas)
-		infuraMediumPriority = stringToWei(infura.Medium.SuggestedMaxPriorityFeePerGas)
-		infuraMediumMaxFee = stringToWei(infura.Medium.SuggestedMaxFeePerGas)
-		infuraHighPriority = stringToWei(infura.High.SuggestedMaxPriorityFeePerGas)
-		infuraHighMaxFee = stringToWei(infura.High.SuggestedMaxFeePerGas)
-		infuraBaseFee = stringToWei(infura.EstimatedBaseFee)
+		var parseErrs []error
+		parse := func(str string) *big.Int {
+			wei, err := stringToWei(str)
+			if err != nil {
+				parseErrs = append(parseErrs, err)
+				return big.NewInt(0)
+			}
+			return wei
+		}
+		infuraLowPriority = parse(infura.Low.SuggestedMaxPriorityFeePerGas)
+		infuraLowMaxFee = parse(infura.Low.SuggestedMaxFeePerGas)
+		infuraMediumPriority = parse(infura.Medium.SuggestedMaxPriorityFeePerGas)
+		infuraMediumMaxFee = parse(infura.Medium.SuggestedMaxFeePerGas)
+		infuraHighPriority = parse(infura.High.SuggestedMaxPriorityFeePerGas)
+		infuraHighMaxFee = parse(infura.High.SuggestedMaxFeePerGas)
+		infuraBaseFee = parse(infura.EstimatedBaseFee)
+		if err := errors.Join(parseErrs...); err != nil {
+			fmt.Printf("⚠️  Invalid Infura fees, not compared: %v\n", err)
+			infuraFeesValid = false
+		}
 	}
 
 	fmt.Printf("\n")
@@ -468,35 +482,29 @@ func displayComparison(current *gas.FeeSuggestions, oldFees *old.SuggestedFees,
 	fmt.Printf("\n")
 }
 
-func hexToWei(hexStr string) *big.Int {
-	if hexStr == "" || hexStr == "0x0" {
-		return big.NewInt(0)
-	}
+// Decimals parsed from fee strings before rounding them to the wei
+const feeParseDecimals = 36
 
-	wei, err := hexutil.DecodeBig(hexStr)
-	if err != nil {
-		return big.NewInt(0)
-	}
-
-	return wei
-}
-
-func stringToWei(str string) *big.Int {
+// stringToWei parses a fee given in wei as a hex string, or in gwei as a
+// decimal string (Infura format). Gwei decimals beyond the wei are rounded.
+func stringToWei(str string) (*big.Int, error) {
 	if str == "" {
-		return big.NewInt(0)
+		return big.NewInt(0), nil
 	}
 
-	// Try parsing as decimal first (Infura format)
-	if val, err := strconv.ParseFloat(str, 64); err == nil {
-		// Convert from gwei to wei if it's a small number (likely gwei)
-		if val < 1000 {
-			val = val * 1e9 // Convert gwei to wei
+	if strings.HasPrefix(str, "0x") {
+		wei, err := hexutil.DecodeBig(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee %q: %w", str, err)
 		}
-		return big.NewInt(int64(val))
+		return wei, nil
 	}
 
-	// Fall back to hex parsing
-	return hexToWei(str)
+	gwei, err := amount.Parse(str, feeParseDecimals)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fee %q: %w", str, err)
+	}
+	return gwei.Rescale(amount.GweiDecimals, amount.RoundHalfUp).BaseUnits(), nil
 }
 
 func percentDiffWei(a, b *big.Int) float64 {
@@ -588,8 +596,8 @@ func displayOurSuggestions(suggestions *gas.FeeSuggestions, gasPrice *big.Int, g
 
 	fmt.Printf("📋 NODE SUGGESTIONS\n")
 	fmt.Printf("═══════════════════════════════════════════════════════════════════════════\n")
-	fmt.Printf("Gas Price: %20s wei\n", gasPrice.String())
-	fmt.Printf("Gas Tip Cap: %20s wei\n", gasTipCap.String())
+	fmt.Printf("Gas Price: %20s wei (%s)\n", gasPrice.String(), formatGwei(gasPrice))
+	fmt.Printf("Gas Tip Cap: %20s wei (%s)\n", gasTipCap.String(), formatGwei(gasTipCap))
 	fmt.Printf("═══════════════════════════════════════════════════════════════════════════\n")
 
 	fmt.Printf("📋 OUR IMPLEMENTATION RESULTS\n")
@@ -636,45 +644,69 @@ func displayOurSuggestions(suggestions *gas.FeeSuggestions, gasPrice *big.Int, g
 		getTimeCategory(int(suggestions.HighInclusion.MinTimeUntilInclusion), int(suggestions.HighInclusion.MaxTimeUntilInclusion)))
 	fmt.Printf("═══════════════════════════════════════════════════════════════════════════\n")
 
-	fmt.Printf("\n🔸 Base Fee: %20s wei\n", ourBaseFee.String())
+	fmt.Printf("\n🔸 Base Fee: %20s wei (%s)\n", ourBaseFee.String(), formatGwei(ourBaseFee))
 	fmt.Printf("🔸 Network Congestion: %.1f%%\n", suggestions.NetworkCongestion*100)
 }
 
+// feeLevel is the lowest fee in gwei of a level, with its labels
+type feeLevel struct {
+	minGwei         string
+	competitiveness string
+	category        string
+}
+
+// Fee levels, from the highest
+var feeLevels = []feeLevel{
+	{"5", "Very High", "🚀 Premium"},
+	{"3", "High", "⚡ Fast"},
+	{"1.5", "Medium", "🟢 Standard"},
+	{"0.5", "Low", "🟡 Economy"},
+	{"0", "Very Low", "🟠 Slow"},
+}
+
 // Update helper functions to work with wei values
 func getFeeCompetitivenessWei(feeWei *big.Int) string {
-	// Convert wei to gwei for comparison (1 gwei = 1e9 wei)
-	feeGwei := new(big.Float).Quo(new(big.Float).SetInt(feeWei), big.NewFloat(1e9))
-	feeFloat, _ := feeGwei.Float64()
-
-	if feeFloat >= 5.0 {
-		return "Very High"
-	} else if feeFloat >= 3.0 {
-		return "High"
-	} else if feeFloat >= 1.5 {
-		return "Medium"
-	} else if feeFloat >= 0.5 {
-		return "Low"
-	} else {
-		return "Very Low"
+	level, err := getFeeLevelWei(feeWei)
+	if err != nil {
+		return "Unknown"
 	}
+	return level.competitiveness
 }
 
 func getFeeCategoryEmojiWei(feeWei *big.Int) string {
-	// Convert wei to gwei for comparison (1 gwei = 1e9 wei)
-	feeGwei := new(big.Float).Quo(new(big.Float).SetInt(feeWei), big.NewFloat(1e9))
-	feeFloat, _ := feeGwei.Float64()
-
-	if feeFloat >= 5.0 {
-		return "🚀 Premium"
-	} else if feeFloat >= 3.0 {
-		return "⚡ Fast"
-	} else if feeFloat >= 1.5 {
-		return "🟢 Standard"
-	} else if feeFloat >= 0.5 {
-		return "🟡 Economy"
-	} else {
-		return "🟠 Slow"
+	level, err := getFeeLevelWei(feeWei)
+	if err != nil {
+		return "❓ Unknown"
 	}
+	return level.category
+}
+
+// getFeeLevelWei returns the highest level whose minimum the fee reaches
+func getFeeLevelWei(feeWei *big.Int) (feeLevel, error) {
+	for _, level := range feeLevels {
+		ok, err := feeAtLeastGwei(feeWei, level.minGwei)
+		if err != nil {
+			return feeLevel{}, err
+		}
+		if ok {
+			return level, nil
+		}
+	}
+	return feeLevels[len(feeLevels)-1], nil
+}
+
+// feeAtLeastGwei compares a fee in wei with a threshold in gwei, exactly
+func feeAtLeastGwei(feeWei *big.Int, thresholdGwei string) (bool, error) {
+	threshold, err := amount.Parse(thresholdGwei, amount.GweiDecimals)
+	if err != nil {
+		return false, fmt.Errorf("invalid threshold %q: %w", thresholdGwei, err)
+	}
+	return amount.Gwei(feeWei).Cmp(threshold) >= 0, nil
+}
+
+// formatGwei formats a wei value in gwei for display
+func formatGwei(wei *big.Int) string {
+	return amount.Gwei(wei).Format(amount.FormatOptions{MaxDecimals: 4}) + " gwei"
 }
 
 // getNetworkEmoji returns an emoji for the network
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
 	"github.com/status-im/go-wallet-sdk/pkg/multicall"
@@ -102,7 +103,7 @@ func main() {
 	log.Printf("Prepared %d jobs for multicall", len(jobs))
 
 	// Execute multicall using the new RunSync function
-	results := multicall.RunSync(ctx, jobs, nil, multicallContract, 100)
+	results := multicall.RunSync(ctx, jobs, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), multicallContract, 100)
 
 	// Process results
 	blockNumber := results[0].BlockNumber
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
@@ -111,7 +112,7 @@ func main() {
 
 	// Execute fetch
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicallAddr, multicallContract, config, 100)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicallAddr, multicallContract, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 100)
 
 	// Process results
 	var totalResults int
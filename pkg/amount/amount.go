@@ -0,0 +1,154 @@
+package amount
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrTooManyDecimals  = errors.New("amount has more decimals than the unit")
+	ErrDecimalsMismatch = errors.New("amounts have different decimals")
+	ErrTokenMismatch    = errors.New("amounts are of different tokens")
+)
+
+// Amount is a number of base units with the decimals of its unit, e.g.
+// 1500000 with 6 decimals is 1.5 USDC. The zero value is 0 with no decimals.
+// Amounts are immutable: operations return new values.
+type Amount struct {
+	value    *big.Int
+	decimals uint
+	token    *types.Token
+}
+
+// New returns the amount of value base units of a unit with the given decimals.
+// A nil value is zero.
+func New(value *big.Int, decimals uint) Amount {
+	return Amount{value: copyInt(value), decimals: decimals}
+}
+
+// FromToken returns the amount of value base units of token.
+func FromToken(value *big.Int, token *types.Token) Amount {
+	return Amount{value: copyInt(value), decimals: token.Decimals, token: token}
+}
+
+// BaseUnits returns the amount in base units.
+func (a Amount) BaseUnits() *big.Int {
+	return copyInt(a.value)
+}
+
+// Decimals returns the decimals of the unit.
+func (a Amount) Decimals() uint {
+	return a.decimals
+}
+
+// Token returns the token of the amount, nil if it isn't tied to a token.
+func (a Amount) Token() *types.Token {
+	return a.token
+}
+
+func (a Amount) Sign() int {
+	if a.value == nil {
+		return 0
+	}
+	return a.value.Sign()
+}
+
+func (a Amount) IsZero() bool {
+	return a.Sign() == 0
+}
+
+func (a Amount) Neg() Amount {
+	a.value = new(big.Int).Neg(a.int())
+	return a
+}
+
+func (a Amount) Abs() Amount {
+	a.value = new(big.Int).Abs(a.int())
+	return a
+}
+
+// Cmp compares the values of a and b, which may have different decimals.
+// Tokens are ignored.
+func (a Amount) Cmp(b Amount) int {
+	x, y := a.int(), b.int()
+	switch {
+	case a.decimals < b.decimals:
+		x = new(big.Int).Mul(x, pow10(b.decimals-a.decimals))
+	case a.decimals > b.decimals:
+		y = new(big.Int).Mul(y, pow10(a.decimals-b.decimals))
+	}
+	return x.Cmp(y)
+}
+
+// Add returns a+b. Both amounts must have the same decimals, and the same
+// token if both are tied to one.
+func (a Amount) Add(b Amount) (Amount, error) {
+	ret, err := a.merge(b)
+	if err != nil {
+		return Amount{}, err
+	}
+	ret.value = new(big.Int).Add(a.int(), b.int())
+	return ret, nil
+}
+
+// Sub returns a-b, with the same restrictions as Add.
+func (a Amount) Sub(b Amount) (Amount, error) {
+	ret, err := a.merge(b)
+	if err != nil {
+		return Amount{}, err
+	}
+	ret.value = new(big.Int).Sub(a.int(), b.int())
+	return ret, nil
+}
+
+// Rescale returns the amount in a unit with the given decimals, e.g. gwei to wei.
+// Dropped decimals are rounded with the given mode. The result isn't tied to a token.
+func (a Amount) Rescale(decimals uint, rounding Rounding) Amount {
+	ret := Amount{decimals: decimals}
+	if decimals >= a.decimals {
+		ret.value = new(big.Int).Mul(a.int(), pow10(decimals-a.decimals))
+	} else {
+		ret.value = roundInt(a.int(), a.decimals-decimals, rounding)
+	}
+	return ret
+}
+
+// String returns the exact decimal value, without trailing zeros, e.g. "1.5".
+func (a Amount) String() string {
+	return trimZeros(formatFixed(a.int(), a.decimals))
+}
+
+func (a Amount) merge(b Amount) (Amount, error) {
+	if a.decimals != b.decimals {
+		return Amount{}, ErrDecimalsMismatch
+	}
+	ret := Amount{decimals: a.decimals, token: a.token}
+	if b.token != nil {
+		if a.token != nil && a.token.Key() != b.token.Key() {
+			return Amount{}, ErrTokenMismatch
+		}
+		ret.token = b.token
+	}
+	return ret, nil
+}
+
+func (a Amount) int() *big.Int {
+	if a.value == nil {
+		return new(big.Int)
+	}
+	return a.value
+}
+
+func copyInt(value *big.Int) *big.Int {
+	if value == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(value)
+}
+
+func pow10(n uint) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), new(big.Int).SetUint64(uint64(n)), nil)
+}
@@ -0,0 +1,222 @@
+package amount_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/amount"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+func mustParse(t *testing.T, s string, decimals uint) amount.Amount {
+	t.Helper()
+	a, err := amount.Parse(s, decimals)
+	require.NoError(t, err)
+	return a
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input    string
+		decimals uint
+		expected string
+		err      error
+	}{
+		{input: "1", decimals: 18, expected: "1000000000000000000"},
+		{input: "1.5", decimals: 6, expected: "1500000"},
+		{input: "-0.5", decimals: 6, expected: "-500000"},
+		{input: "+2", decimals: 0, expected: "2"},
+		{input: ".5", decimals: 1, expected: "5"},
+		{input: "1.", decimals: 2, expected: "100"},
+		{input: " 0.000001 ", decimals: 6, expected: "1"},
+		{input: "1.5e3", decimals: 0, expected: "1500"},
+		{input: "15E-1", decimals: 1, expected: "15"},
+		{input: "1.2500", decimals: 2, expected: "125"},
+		{input: "0e-50", decimals: 18, expected: "0"},
+		{input: "123456789012345678901234567890.123456789012345678", decimals: 18, expected: "123456789012345678901234567890123456789012345678"},
+		{input: "1.234", decimals: 2, err: amount.ErrTooManyDecimals},
+		{input: "1e-19", decimals: 18, err: amount.ErrTooManyDecimals},
+		{input: "", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: ".", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "-", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "1,5", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "1 000", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "0x10", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "1e", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "1e100000", decimals: 18, err: amount.ErrInvalidAmount},
+		{input: "--1", decimals: 18, err: amount.ErrInvalidAmount},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			a, err := amount.Parse(tt.input, tt.decimals)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, a.BaseUnits().String())
+			assert.Equal(t, tt.decimals, a.Decimals())
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	assert.Equal(t, "0", amount.Amount{}.String())
+	assert.Equal(t, "0", amount.New(nil, 18).String())
+	assert.Equal(t, "1.5", amount.New(big.NewInt(1500000), 6).String())
+	assert.Equal(t, "0.000001", amount.New(big.NewInt(1), 6).String())
+	assert.Equal(t, "-0.1", amount.New(big.NewInt(-100000), 6).String())
+	assert.Equal(t, "1000", amount.New(big.NewInt(1000), 0).String())
+	assert.Equal(t, "10", amount.New(big.NewInt(10000000), 6).String())
+
+	// Round trip
+	for _, s := range []string{"0.000000000000000001", "123.456", "-7", "99999999999999999999.5"} {
+		assert.Equal(t, s, mustParse(t, s, 18).String())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		decimals uint
+		opts     amount.FormatOptions
+		expected string
+	}{
+		{name: "all decimals", value: "1.23456789", decimals: 18, opts: amount.FormatOptions{MaxDecimals: amount.AllDecimals}, expected: "1.23456789"},
+		{name: "integer", value: "1.5", decimals: 18, opts: amount.FormatOptions{}, expected: "2"},
+		{name: "integer round down", value: "1.5", decimals: 18, opts: amount.FormatOptions{Rounding: amount.RoundDown}, expected: "1"},
+		{name: "max decimals", value: "1.23456789", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "1.2346"},
+		{name: "max decimals round down", value: "1.23456789", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4, Rounding: amount.RoundDown}, expected: "1.2345"},
+		{name: "trailing zeros trimmed", value: "1.50001", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "1.5"},
+		{name: "round up carries", value: "9.99999", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "10"},
+		{name: "below threshold", value: "0.00001", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "<0.0001"},
+		{name: "below threshold rounds up", value: "0.00005", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "0.0001"},
+		{name: "below threshold negative", value: "-0.00001", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "-<0.0001"},
+		{name: "below one", value: "0.4", decimals: 18, opts: amount.FormatOptions{}, expected: "<1"},
+		{name: "zero", value: "0", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4}, expected: "0"},
+		{name: "negative", value: "-1.23456", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 2}, expected: "-1.23"},
+		{name: "significant digits fraction", value: "0.000123456", decimals: 18, opts: amount.FormatOptions{MaxDecimals: amount.AllDecimals, SignificantDigits: 3}, expected: "0.000123"},
+		{name: "significant digits mixed", value: "12.3456", decimals: 18, opts: amount.FormatOptions{MaxDecimals: amount.AllDecimals, SignificantDigits: 3}, expected: "12.3"},
+		{name: "significant digits keep integer", value: "123456.789", decimals: 18, opts: amount.FormatOptions{MaxDecimals: amount.AllDecimals, SignificantDigits: 3}, expected: "123457"},
+		{name: "significant digits and max decimals", value: "0.000123456", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 4, SignificantDigits: 3}, expected: "0.0001"},
+		{name: "default", value: "1234.56789", decimals: 18, opts: amount.DefaultFormatOptions, expected: "1234.57"},
+		{name: "default small", value: "0.000012", decimals: 18, opts: amount.DefaultFormatOptions, expected: "<0.0001"},
+		{name: "compact below thousand", value: "999.5", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 2, Compact: true}, expected: "999.5"},
+		{name: "compact thousands", value: "1500", decimals: 18, opts: amount.FormatOptions{MaxDecimals: 2, Compact: true}, expected: "1.5K"},
+		{name: "compact millions", value: "2345678", decimals: 6, opts: amount.FormatOptions{MaxDecimals: 2, Compact: true}, expected: "2.35M"},
+		{name: "compact billions", value: "3250000000", decimals: 0, opts: amount.FormatOptions{MaxDecimals: 2, Compact: true}, expected: "3.25B"},
+		{name: "compact trillions", value: "1000000000000000", decimals: 0, opts: amount.FormatOptions{MaxDecimals: 2, Compact: true}, expected: "1000T"},
+		{name: "compact carries to next suffix", value: "999999", decimals: 0, opts: amount.FormatOptions{MaxDecimals: 1, Compact: true}, expected: "1M"},
+		{name: "compact rounding reaches thousand", value: "999.99", decimals: 2, opts: amount.FormatOptions{MaxDecimals: 1, Compact: true}, expected: "1K"},
+		{name: "compact significant digits", value: "1234567", decimals: 0, opts: amount.FormatOptions{MaxDecimals: amount.AllDecimals, SignificantDigits: 2, Compact: true}, expected: "1.2M"},
+		{name: "compact negative", value: "-1500", decimals: 0, opts: amount.FormatOptions{MaxDecimals: 1, Compact: true}, expected: "-1.5K"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mustParse(t, tt.value, tt.decimals).Format(tt.opts))
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	usdc := &types.Token{
+		ChainID:  1,
+		Address:  common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		Decimals: 6,
+		Symbol:   "USDC",
+	}
+	dai := &types.Token{
+		ChainID:  1,
+		Address:  common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"),
+		Decimals: 18,
+		Symbol:   "DAI",
+	}
+
+	a := amount.FromToken(big.NewInt(1234567), usdc)
+	assert.Equal(t, uint(6), a.Decimals())
+	assert.Same(t, usdc, a.Token())
+	assert.Equal(t, "1.23 USDC", a.Format(amount.FormatOptions{MaxDecimals: 2, Symbol: true}))
+	assert.Equal(t, "1.23", a.Format(amount.FormatOptions{MaxDecimals: 2}))
+
+	b, err := amount.ParseToken("0.5", usdc)
+	require.NoError(t, err)
+	assert.Same(t, usdc, b.Token())
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, "1.734567", sum.String())
+	assert.Same(t, usdc, sum.Token())
+
+	// Amounts without a token take the token of the other operand
+	sum, err = amount.New(big.NewInt(1), 6).Add(a)
+	require.NoError(t, err)
+	assert.Same(t, usdc, sum.Token())
+
+	_, err = amount.ParseToken("0.0000001", usdc)
+	assert.ErrorIs(t, err, amount.ErrTooManyDecimals)
+
+	_, err = a.Add(amount.FromToken(big.NewInt(1), dai))
+	assert.ErrorIs(t, err, amount.ErrDecimalsMismatch)
+
+	other := *usdc
+	other.Address = common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7")
+	_, err = a.Sub(amount.FromToken(big.NewInt(1), &other))
+	assert.ErrorIs(t, err, amount.ErrTokenMismatch)
+}
+
+func TestArithmetic(t *testing.T) {
+	a := mustParse(t, "1.5", 18)
+	b := mustParse(t, "2.25", 18)
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	assert.Equal(t, "-0.75", diff.String())
+	assert.Equal(t, -1, diff.Sign())
+	assert.Equal(t, "0.75", diff.Abs().String())
+	assert.Equal(t, "0.75", diff.Neg().String())
+	assert.Equal(t, "-0.75", diff.String(), "operations don't modify the receiver")
+
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 0, a.Cmp(mustParse(t, "1.5", 6)), "values compare across decimals")
+	assert.Equal(t, 1, a.Cmp(mustParse(t, "1.499999", 6)))
+	assert.True(t, amount.Amount{}.IsZero())
+
+	_, err = a.Add(mustParse(t, "1", 6))
+	assert.ErrorIs(t, err, amount.ErrDecimalsMismatch)
+
+	// BaseUnits returns a copy
+	a.BaseUnits().SetInt64(0)
+	assert.Equal(t, "1.5", a.String())
+
+	gwei := mustParse(t, "1.5", 9)
+	assert.Equal(t, "15000000000000000000", gwei.Rescale(19, amount.RoundDown).BaseUnits().String())
+	assert.Equal(t, "2", gwei.Rescale(0, amount.RoundHalfUp).BaseUnits().String())
+	assert.Equal(t, "1", gwei.Rescale(0, amount.RoundDown).BaseUnits().String())
+	assert.Equal(t, "-2", gwei.Neg().Rescale(0, amount.RoundHalfUp).BaseUnits().String())
+}
+
+func TestUnits(t *testing.T) {
+	wei, err := amount.ParseEther("0.05")
+	require.NoError(t, err)
+	assert.Equal(t, "50000000000000000", wei.String())
+	assert.Equal(t, "0.05", amount.FormatEther(wei))
+
+	wei, err = amount.ParseGwei("1.234567891")
+	require.NoError(t, err)
+	assert.Equal(t, "1234567891", wei.String())
+	assert.Equal(t, "1.234567891", amount.FormatGwei(wei))
+
+	_, err = amount.ParseGwei("0.0000000001")
+	assert.ErrorIs(t, err, amount.ErrTooManyDecimals)
+
+	assert.Equal(t, "0", amount.FormatEther(nil))
+	assert.Equal(t, "1.5", amount.Gwei(big.NewInt(1500000000)).String())
+	assert.Equal(t, "<0.0001", amount.Ether(big.NewInt(1)).Format(amount.DefaultFormatOptions))
+}
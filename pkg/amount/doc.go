@@ -0,0 +1,10 @@
+// Package amount converts token amounts between base units and human-readable
+// decimal strings.
+//
+// An Amount is an integer number of base units (wei, satoshi-like units of an
+// ERC20, ...) together with the number of decimals of the unit, optionally
+// tied to a tokens/types.Token. Parsing and formatting are exact: no floating
+// point is involved, and rounding only happens where FormatOptions asks for it.
+// Strings are locale-neutral: "." is the decimal separator and no digit
+// grouping is used.
+package amount
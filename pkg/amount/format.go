@@ -0,0 +1,149 @@
+package amount
+
+import (
+	"math/big"
+	"strings"
+)
+
+type Rounding int
+
+const (
+	// Round to the nearest value, halves away from zero.
+	RoundHalfUp Rounding = iota
+	// Round toward zero, i.e. truncate.
+	RoundDown
+)
+
+// Decimals shown by Format when no limit applies.
+const AllDecimals = -1
+
+// Suffixes of compact notation, by power of 1000.
+var compactSuffixes = []string{"", "K", "M", "B", "T"}
+
+type FormatOptions struct {
+	// Maximum number of decimals shown, AllDecimals for no limit.
+	// Non-zero values that round to zero are shown as "<0.0001" (for 4 decimals).
+	MaxDecimals int
+	// Maximum number of significant digits shown, 0 for no limit.
+	// Digits of the integer part are never dropped.
+	SignificantDigits uint
+	// Rounding of the dropped digits
+	Rounding Rounding
+	// Compact notation for values of 1000 and above: 1.5K, 2M, 3.25B, 1T.
+	Compact bool
+	// Append the symbol of the token, if the amount is tied to one.
+	Symbol bool
+}
+
+// DefaultFormatOptions suits balances shown to users: 4 decimals at most and
+// 6 significant digits, so "1234.5678" and "0.0001234" both read well.
+var DefaultFormatOptions = FormatOptions{
+	MaxDecimals:       4,
+	SignificantDigits: 6,
+}
+
+// Format returns the value as a human-readable string. Trailing zeros are
+// trimmed.
+func (a Amount) Format(opts FormatOptions) string {
+	ret := formatAmount(a.int(), a.decimals, opts)
+	if opts.Symbol && a.token != nil && a.token.Symbol != "" {
+		ret += " " + a.token.Symbol
+	}
+	return ret
+}
+
+func formatAmount(value *big.Int, decimals uint, opts FormatOptions) string {
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(value)
+
+	exp := 0
+	if opts.Compact {
+		// Largest power of 1000 not above the value, then move up if rounding
+		// reaches 1000 of the current suffix (999.99K -> 1M).
+		for exp+1 < len(compactSuffixes) && abs.Cmp(new(big.Int).Mul(pow10(uint(3*(exp+1))), pow10(decimals))) >= 0 {
+			exp++
+		}
+	}
+
+	for {
+		scaled := decimals + uint(3*exp)
+		shown := shownDecimals(abs, scaled, opts)
+		rounded := roundInt(abs, scaled-shown, opts.Rounding)
+		if opts.Compact && exp+1 < len(compactSuffixes) && rounded.Cmp(new(big.Int).Mul(big.NewInt(1000), pow10(shown))) >= 0 {
+			exp++
+			continue
+		}
+		if rounded.Sign() == 0 && abs.Sign() != 0 {
+			return sign + "<" + formatFixed(big.NewInt(1), shown)
+		}
+		if rounded.Sign() == 0 {
+			sign = ""
+		}
+		return sign + trimZeros(formatFixed(rounded, shown)) + compactSuffixes[exp]
+	}
+}
+
+// Decimals shown for abs, a value with the given decimals.
+func shownDecimals(abs *big.Int, decimals uint, opts FormatOptions) uint {
+	shown := decimals
+	if opts.MaxDecimals >= 0 && uint(opts.MaxDecimals) < shown {
+		shown = uint(opts.MaxDecimals)
+	}
+	if opts.SignificantDigits > 0 && abs.Sign() != 0 {
+		// Position of the first significant digit relative to the decimal point:
+		// 2 for 12.3, 0 for 0.5, -2 for 0.005
+		magnitude := len(abs.String()) - int(decimals)
+		if keep := int(opts.SignificantDigits) - magnitude; keep < int(shown) {
+			shown = uint(max(keep, 0))
+		}
+	}
+	return shown
+}
+
+// Divides value by 10^drop, with the given rounding.
+func roundInt(value *big.Int, drop uint, rounding Rounding) *big.Int {
+	if drop == 0 {
+		return new(big.Int).Set(value)
+	}
+	divisor := pow10(drop)
+	quo, rem := new(big.Int).QuoRem(value, divisor, new(big.Int))
+	if rounding == RoundHalfUp {
+		rem.Abs(rem)
+		if rem.Mul(rem, big.NewInt(2)).Cmp(divisor) >= 0 {
+			if value.Sign() < 0 {
+				quo.Sub(quo, big.NewInt(1))
+			} else {
+				quo.Add(quo, big.NewInt(1))
+			}
+		}
+	}
+	return quo
+}
+
+// Formats value with the given decimals, keeping trailing zeros.
+func formatFixed(value *big.Int, decimals uint) string {
+	sign := ""
+	if value.Sign() < 0 {
+		sign = "-"
+	}
+	digits := new(big.Int).Abs(value).String()
+	if decimals == 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	point := len(digits) - int(decimals)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+func trimZeros(s string) string {
+	if !strings.Contains(s, ".") {
+		return s
+	}
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
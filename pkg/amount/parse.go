@@ -0,0 +1,91 @@
+package amount
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+// Exponents beyond this are rejected, to bound the size of parsed values.
+const maxExponent = 1000
+
+// Parse parses a decimal string into an amount of a unit with the given decimals.
+// Accepted forms are an optional sign, digits with an optional "." and an
+// optional exponent: "1", "-0.5", ".5", "1.", "1.5e3", "15E-1". Parsing is exact;
+// ErrTooManyDecimals is returned if the value isn't a whole number of base units.
+func Parse(s string, decimals uint) (Amount, error) {
+	value, err := parseBaseUnits(strings.TrimSpace(s), decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+	return Amount{value: value, decimals: decimals}, nil
+}
+
+// ParseToken parses a decimal string into an amount of token.
+func ParseToken(s string, token *types.Token) (Amount, error) {
+	ret, err := Parse(s, token.Decimals)
+	if err != nil {
+		return Amount{}, err
+	}
+	ret.token = token
+	return ret, nil
+}
+
+func parseBaseUnits(s string, decimals uint) (*big.Int, error) {
+	negative := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		negative = s[0] == '-'
+		s = s[1:]
+	}
+
+	exponent := 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return nil, ErrInvalidAmount
+		}
+		exponent = exp
+		s = s[:i]
+	}
+
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, ErrInvalidAmount
+	}
+
+	digits := intPart + fracPart
+	// Decimals of the digits once the exponent is applied
+	scale := len(fracPart) - exponent
+	if scale > int(decimals) {
+		excess := scale - int(decimals)
+		if excess > len(digits) {
+			excess = len(digits)
+		}
+		if strings.Trim(digits[len(digits)-excess:], "0") != "" {
+			return nil, ErrTooManyDecimals
+		}
+		digits = digits[:len(digits)-excess]
+		scale = int(decimals)
+	}
+
+	value := new(big.Int)
+	if digits != "" {
+		value.SetString(digits, 10)
+	}
+	value.Mul(value, pow10(uint(int(decimals)-scale)))
+	if negative {
+		value.Neg(value)
+	}
+	return value, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
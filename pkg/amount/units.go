@@ -0,0 +1,41 @@
+package amount
+
+import (
+	"math/big"
+	"strings"
+)
+
+const (
+	EtherDecimals = 18
+	GweiDecimals  = 9
+)
+
+// Ether returns an amount of wei in ether.
+func Ether(wei *big.Int) Amount {
+	return New(wei, EtherDecimals)
+}
+
+// Gwei returns an amount of wei in gwei.
+func Gwei(wei *big.Int) Amount {
+	return New(wei, GweiDecimals)
+}
+
+// ParseEther parses an ether amount, e.g. "0.05", into wei.
+func ParseEther(s string) (*big.Int, error) {
+	return parseBaseUnits(strings.TrimSpace(s), EtherDecimals)
+}
+
+// ParseGwei parses a gwei amount, e.g. "1.5", into wei.
+func ParseGwei(s string) (*big.Int, error) {
+	return parseBaseUnits(strings.TrimSpace(s), GweiDecimals)
+}
+
+// FormatEther returns the exact value of wei in ether, e.g. "0.05".
+func FormatEther(wei *big.Int) string {
+	return Ether(wei).String()
+}
+
+// FormatGwei returns the exact value of wei in gwei, e.g. "1.5".
+func FormatGwei(wei *big.Int) string {
+	return Gwei(wei).String()
+}
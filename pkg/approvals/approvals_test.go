@@ -0,0 +1,190 @@
+package approvals_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/status-im/go-wallet-sdk/pkg/approvals"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+	mock_multicall "github.com/status-im/go-wallet-sdk/pkg/multicall/mock"
+)
+
+var (
+	owner    = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	newOwner = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	spender  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	operator = common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	usdc = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	bayc = common.HexToAddress("0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D")
+)
+
+type fakeFilterClient struct {
+	logs    []types.Log
+	queries []ethereum.FilterQuery
+}
+
+func (c *fakeFilterClient) FilterLogs(_ context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.queries = append(c.queries, query)
+	return c.logs, nil
+}
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func uintWord(value *big.Int) []byte {
+	return common.LeftPadBytes(value.Bytes(), 32)
+}
+
+func erc20ApprovalLog(block uint64, index uint, amount *big.Int) types.Log {
+	return types.Log{
+		Address:     usdc,
+		Topics:      []common.Hash{eventlog.ERC20ApprovalID, addressTopic(owner), addressTopic(spender)},
+		Data:        uintWord(amount),
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func erc721ApprovalLog(block uint64, index uint, tokenID int64) types.Log {
+	return types.Log{
+		Address:     bayc,
+		Topics:      []common.Hash{eventlog.ERC721ApprovalID, addressTopic(owner), addressTopic(spender), common.BigToHash(big.NewInt(tokenID))},
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func approvalForAllLog(block uint64, index uint, approved bool) types.Log {
+	data := make([]byte, 32)
+	if approved {
+		data[31] = 1
+	}
+	return types.Log{
+		Address:     bayc,
+		Topics:      []common.Hash{eventlog.ERC721ApprovalForAllID, addressTopic(owner), addressTopic(operator)},
+		Data:        data,
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func result(data []byte) multicall3.IMulticall3Result {
+	return multicall3.IMulticall3Result{Success: true, ReturnData: data}
+}
+
+func TestCandidatesFromEvents(t *testing.T) {
+	var events []eventlog.Event
+	for _, log := range []types.Log{
+		erc20ApprovalLog(10, 0, big.NewInt(100)),
+		erc20ApprovalLog(12, 3, big.NewInt(0)),
+		erc721ApprovalLog(11, 1, 7),
+		approvalForAllLog(9, 0, true),
+	} {
+		events = append(events, eventlog.ParseLog(log)...)
+	}
+
+	candidates := approvals.CandidatesFromEvents(events)
+	require.Len(t, candidates, 3)
+
+	// Most recent first, one candidate per (owner, contract, spender/token)
+	assert.Equal(t, approvals.ApprovalTypeERC20Allowance, candidates[0].Type)
+	assert.Equal(t, uint64(12), candidates[0].BlockNumber)
+	assert.Equal(t, spender, candidates[0].Spender)
+
+	assert.Equal(t, approvals.ApprovalTypeERC721Token, candidates[1].Type)
+	assert.Equal(t, big.NewInt(7), candidates[1].TokenID)
+	assert.Equal(t, common.Address{}, candidates[1].Spender)
+
+	// ERC721 and ERC1155 ApprovalForAll share the signature, the log is parsed
+	// as both but yields a single candidate
+	assert.Equal(t, approvals.ApprovalTypeApprovalForAll, candidates[2].Type)
+	assert.Equal(t, operator, candidates[2].Spender)
+}
+
+func TestScanApprovals(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	filterClient := &fakeFilterClient{
+		logs: []types.Log{
+			erc20ApprovalLog(10, 0, big.NewInt(100)),
+			erc721ApprovalLog(11, 0, 7),
+			erc721ApprovalLog(11, 1, 8),
+			approvalForAllLog(12, 0, true),
+		},
+	}
+
+	unlimited := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+
+	expectedCalls := []multicall3.IMulticall3Call{
+		multicall.BuildERC721IsApprovedForAllCall(owner, operator, bayc),
+		multicall.BuildERC721OwnerOfCall(bayc, big.NewInt(8)),
+		multicall.BuildERC721GetApprovedCall(bayc, big.NewInt(8)),
+		multicall.BuildERC721OwnerOfCall(bayc, big.NewInt(7)),
+		multicall.BuildERC721GetApprovedCall(bayc, big.NewInt(7)),
+		multicall.BuildERC20AllowanceCall(owner, spender, usdc),
+	}
+	results := []multicall3.IMulticall3Result{
+		// Operator approval was revoked since
+		result(make([]byte, 32)),
+		// Token 8 was transferred
+		result(uintWord(new(big.Int).SetBytes(newOwner.Bytes()))),
+		result(uintWord(new(big.Int).SetBytes(spender.Bytes()))),
+		// Token 7 is still owned and approved
+		result(uintWord(new(big.Int).SetBytes(owner.Bytes()))),
+		result(uintWord(new(big.Int).SetBytes(spender.Bytes()))),
+		// Unlimited allowance
+		result(uintWord(unlimited)),
+	}
+	mockCaller.EXPECT().
+		ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).
+		DoAndReturn(func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+			require.Equal(t, expectedCalls, calls)
+			return big.NewInt(100), [32]byte{1}, results, nil
+		})
+
+	config := eventfilter.ApprovalQueryConfig{
+		Accounts:      []common.Address{owner, newOwner},
+		TransferTypes: []eventfilter.TransferType{eventfilter.TransferTypeERC20, eventfilter.TransferTypeERC721},
+	}
+	ret, err := approvals.ScanApprovals(context.Background(), filterClient, mockCaller, config, 100)
+	require.NoError(t, err)
+	require.Len(t, filterClient.queries, 1)
+
+	assert.Empty(t, ret[newOwner])
+	require.Len(t, ret[owner], 2)
+
+	assert.Equal(t, approvals.ApprovalTypeERC721Token, ret[owner][0].Type)
+	assert.Equal(t, big.NewInt(7), ret[owner][0].TokenID)
+	assert.Equal(t, spender, ret[owner][0].Spender)
+	assert.False(t, ret[owner][0].IsUnlimited())
+
+	assert.Equal(t, approvals.ApprovalTypeERC20Allowance, ret[owner][1].Type)
+	assert.Equal(t, unlimited, ret[owner][1].Amount)
+	assert.True(t, ret[owner][1].IsUnlimited())
+}
+
+func TestUnlimitedAllowanceThreshold(t *testing.T) {
+	threshold := approvals.UnlimitedAllowanceThreshold()
+	approval := approvals.Approval{Type: approvals.ApprovalTypeERC20Allowance, Amount: threshold}
+	assert.True(t, approval.IsUnlimited())
+
+	// Changing the returned value doesn't change the threshold
+	threshold.SetInt64(1)
+	assert.False(t, approvals.Approval{Type: approvals.ApprovalTypeERC20Allowance, Amount: big.NewInt(2)}.IsUnlimited())
+	assert.Equal(t, 96, approvals.UnlimitedAllowanceThreshold().BitLen())
+}
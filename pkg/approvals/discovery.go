@@ -0,0 +1,96 @@
+package approvals
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+// Scans approval events for the configured accounts and returns the candidate approvals.
+func DiscoverCandidates(ctx context.Context, client eventfilter.FilterClient, config eventfilter.ApprovalQueryConfig) ([]Candidate, error) {
+	events, err := eventfilter.FilterApprovals(ctx, client, config)
+	if err != nil {
+		return nil, err
+	}
+	return CandidatesFromEvents(events), nil
+}
+
+// Builds the deduplicated list of candidate approvals from parsed approval events.
+// Events that are not approval events are ignored. Revocations (zero allowance,
+// ApprovalForAll with approved=false) are kept as candidates, since the current
+// state is always read from the chain.
+func CandidatesFromEvents(events []eventlog.Event) []Candidate {
+	candidates := make(map[candidateKey]Candidate)
+	for _, event := range events {
+		candidate, ok := candidateFromEvent(event)
+		if !ok {
+			continue
+		}
+		key := candidate.key()
+		if existing, ok := candidates[key]; ok && !isLater(candidate, existing) {
+			continue
+		}
+		candidates[key] = candidate
+	}
+
+	ret := make([]Candidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		ret = append(ret, candidate)
+	}
+	slices.SortFunc(ret, compareCandidates)
+	return ret
+}
+
+func candidateFromEvent(event eventlog.Event) (Candidate, bool) {
+	switch unpacked := event.Unpacked.(type) {
+	case erc20.Erc20Approval:
+		return newCandidate(ApprovalTypeERC20Allowance, unpacked.Raw, unpacked.Owner, unpacked.Spender, nil), true
+	case erc721.Erc721Approval:
+		return newCandidate(ApprovalTypeERC721Token, unpacked.Raw, unpacked.Owner, common.Address{}, unpacked.TokenId), true
+	case erc721.Erc721ApprovalForAll:
+		return newCandidate(ApprovalTypeApprovalForAll, unpacked.Raw, unpacked.Owner, unpacked.Operator, nil), true
+	case erc1155.Erc1155ApprovalForAll:
+		return newCandidate(ApprovalTypeApprovalForAll, unpacked.Raw, unpacked.Account, unpacked.Operator, nil), true
+	}
+	return Candidate{}, false
+}
+
+func newCandidate(approvalType ApprovalType, log types.Log, owner common.Address, spender common.Address, tokenID *big.Int) Candidate {
+	return Candidate{
+		Type:        approvalType,
+		Owner:       owner,
+		Contract:    log.Address,
+		Spender:     spender,
+		TokenID:     tokenID,
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+		LogIndex:    log.Index,
+	}
+}
+
+func isLater(a Candidate, b Candidate) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber > b.BlockNumber
+	}
+	return a.LogIndex > b.LogIndex
+}
+
+// Most recent first
+func compareCandidates(a Candidate, b Candidate) int {
+	if isLater(a, b) {
+		return -1
+	}
+	if isLater(b, a) {
+		return 1
+	}
+	return a.Contract.Cmp(b.Contract)
+}
@@ -0,0 +1,8 @@
+// Package approvals reports which spenders an account has approved to move its
+// tokens (ERC20 allowances, ERC721 per-token approvals and ERC721/ERC1155
+// operator approvals).
+//
+// Candidate (token, spender) pairs are discovered from Approval/ApprovalForAll
+// logs through pkg/eventfilter, and the current state is then read in bulk
+// through Multicall3, so revoked or consumed approvals are not reported.
+package approvals
@@ -0,0 +1,158 @@
+package approvals
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// Active approvals grouped by owner
+type ApprovalsPerAccount = map[common.Address][]Approval
+
+// Discovers candidate approvals for the configured accounts and returns the ones
+// that are currently active. Every account in the config is present in the result,
+// with an empty list if it has no active approvals.
+func ScanApprovals(
+	ctx context.Context,
+	filterClient eventfilter.FilterClient,
+	caller multicall.Caller,
+	config eventfilter.ApprovalQueryConfig,
+	batchSize int,
+) (ApprovalsPerAccount, error) {
+	candidates, err := DiscoverCandidates(ctx, filterClient, config)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := FetchApprovals(ctx, caller, candidates, batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, account := range config.Accounts {
+		if _, ok := approvals[account]; !ok {
+			approvals[account] = []Approval{}
+		}
+	}
+	return approvals, nil
+}
+
+// Reads the current state of the candidate approvals through Multicall3 and
+// returns the active ones, grouped by owner, in the same order as the candidates.
+func FetchApprovals(
+	ctx context.Context,
+	caller multicall.Caller,
+	candidates []Candidate,
+	batchSize int,
+) (ApprovalsPerAccount, error) {
+	jobs := make([]multicall.Job, 0, len(candidates))
+	for _, candidate := range candidates {
+		jobs = append(jobs, buildCandidateJob(candidate))
+	}
+
+	ret := make(ApprovalsPerAccount)
+	results := multicall.RunSync(ctx, jobs, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), caller, batchSize)
+	for i, jobResult := range results {
+		if jobResult.Err != nil {
+			return nil, jobResult.Err
+		}
+		approval, ok, err := processCandidateJobResult(candidates[i], jobResult)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ret[approval.Owner] = append(ret[approval.Owner], approval)
+		}
+	}
+
+	return ret, nil
+}
+
+func buildCandidateJob(candidate Candidate) multicall.Job {
+	var calls []multicall3.IMulticall3Call
+	switch candidate.Type {
+	case ApprovalTypeERC20Allowance:
+		calls = []multicall3.IMulticall3Call{
+			multicall.BuildERC20AllowanceCall(candidate.Owner, candidate.Spender, candidate.Contract),
+		}
+	case ApprovalTypeERC721Token:
+		// The approval is cleared when the token is transferred, but the new
+		// owner may have approved someone else, so ownership is checked too
+		calls = []multicall3.IMulticall3Call{
+			multicall.BuildERC721OwnerOfCall(candidate.Contract, candidate.TokenID),
+			multicall.BuildERC721GetApprovedCall(candidate.Contract, candidate.TokenID),
+		}
+	case ApprovalTypeApprovalForAll:
+		// Same selector for ERC721 and ERC1155
+		calls = []multicall3.IMulticall3Call{
+			multicall.BuildERC721IsApprovedForAllCall(candidate.Owner, candidate.Spender, candidate.Contract),
+		}
+	}
+
+	return multicall.Job{
+		Calls: calls,
+		CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+			return result, nil
+		},
+	}
+}
+
+func processCandidateJobResult(candidate Candidate, jobResult multicall.JobResult) (Approval, bool, error) {
+	approval := Approval{
+		Type:        candidate.Type,
+		Owner:       candidate.Owner,
+		Contract:    candidate.Contract,
+		Spender:     candidate.Spender,
+		TokenID:     candidate.TokenID,
+		BlockNumber: candidate.BlockNumber,
+		TxHash:      candidate.TxHash,
+	}
+
+	results := make([]multicall3.IMulticall3Result, 0, len(jobResult.Results))
+	for _, callResult := range jobResult.Results {
+		result, _ := callResult.Value.(multicall3.IMulticall3Result)
+		results = append(results, result)
+	}
+
+	expectedCallCount := 1
+	if candidate.Type == ApprovalTypeERC721Token {
+		expectedCallCount = 2
+	}
+	if len(results) != expectedCallCount {
+		return approval, false, fmt.Errorf("expected %d call results, got %d", expectedCallCount, len(results))
+	}
+
+	// Failed calls mean the approval can't be confirmed (e.g. burnt token,
+	// self-destructed contract), those are not reported
+	switch candidate.Type {
+	case ApprovalTypeERC20Allowance:
+		amount, err := multicall.ProcessUint256Result(results[0])
+		if err != nil || amount.Sign() == 0 {
+			return approval, false, nil
+		}
+		approval.Amount = amount
+	case ApprovalTypeERC721Token:
+		owner, err := multicall.ProcessAddressResult(results[0])
+		if err != nil || owner != candidate.Owner {
+			return approval, false, nil
+		}
+		approved, err := multicall.ProcessAddressResult(results[1])
+		if err != nil || approved == (common.Address{}) {
+			return approval, false, nil
+		}
+		approval.Spender = approved
+	case ApprovalTypeApprovalForAll:
+		approved, err := multicall.ProcessBoolResult(results[0])
+		if err != nil || !approved {
+			return approval, false, nil
+		}
+	}
+
+	return approval, true, nil
+}
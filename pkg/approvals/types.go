@@ -0,0 +1,92 @@
+package approvals
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+type ApprovalType string
+
+const (
+	// ERC20 allowance(owner, spender)
+	ApprovalTypeERC20Allowance ApprovalType = "erc20allowance"
+	// ERC721 getApproved(tokenId)
+	ApprovalTypeERC721Token ApprovalType = "erc721token"
+	// ERC721/ERC1155 isApprovedForAll(owner, operator)
+	ApprovalTypeApprovalForAll ApprovalType = "approvalforall"
+)
+
+// Max uint96 is used instead of max uint256 since some tokens (e.g. UNI, COMP)
+// cap allowances to it.
+var unlimitedAllowanceThreshold = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 96), big.NewInt(1))
+
+// UnlimitedAllowanceThreshold returns a copy of the value from which ERC20
+// allowances are reported as unlimited (max uint96).
+func UnlimitedAllowanceThreshold() *big.Int {
+	return new(big.Int).Set(unlimitedAllowanceThreshold)
+}
+
+// Candidate is a possibly active approval, derived from an approval event.
+type Candidate struct {
+	Type     ApprovalType
+	Owner    common.Address
+	Contract common.Address
+	// Spender or operator, unset for ApprovalTypeERC721Token (read from chain)
+	Spender common.Address
+	// Set for ApprovalTypeERC721Token
+	TokenID *big.Int
+	// Location of the most recent approval event for this candidate
+	BlockNumber uint64
+	TxHash      common.Hash
+	LogIndex    uint
+}
+
+// Approval is an approval that is active at the block the state was read.
+type Approval struct {
+	Type     ApprovalType
+	Owner    common.Address
+	Contract common.Address
+	// Spender or operator
+	Spender common.Address
+	// Set for ApprovalTypeERC721Token
+	TokenID *big.Int
+	// Set for ApprovalTypeERC20Allowance
+	Amount *big.Int
+	// Location of the most recent approval event
+	BlockNumber uint64
+	TxHash      common.Hash
+}
+
+// Reports whether the approval lets the spender move any amount of the owner's tokens.
+func (a Approval) IsUnlimited() bool {
+	switch a.Type {
+	case ApprovalTypeApprovalForAll:
+		return true
+	case ApprovalTypeERC20Allowance:
+		return a.Amount != nil && a.Amount.Cmp(unlimitedAllowanceThreshold) >= 0
+	default:
+		return false
+	}
+}
+
+type candidateKey struct {
+	approvalType ApprovalType
+	owner        common.Address
+	contract     common.Address
+	spender      common.Address
+	tokenID      [32]byte
+}
+
+func (c Candidate) key() candidateKey {
+	ret := candidateKey{
+		approvalType: c.Type,
+		owner:        c.Owner,
+		contract:     c.Contract,
+		spender:      c.Spender,
+	}
+	if c.TokenID != nil {
+		c.TokenID.FillBytes(ret.tokenID[:])
+	}
+	return ret
+}
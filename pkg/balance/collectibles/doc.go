@@ -0,0 +1,10 @@
+// Package collectibles lists the collectibles (NFTs) owned by an account.
+//
+// ERC721 contracts implementing ERC721Enumerable are listed with
+// tokenOfOwnerByIndex through Multicall3. For other contracts, holdings are
+// reconstructed from Transfer logs and verified with ownerOf at the same block.
+//
+// ERC1155 holdings are discovered from TransferSingle/TransferBatch logs and
+// confirmed with balanceOfBatch, so that they can be added to a
+// multistandardfetcher.FetchConfig.
+package collectibles
@@ -0,0 +1,229 @@
+package collectibles
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// Token IDs per balanceOfBatch call
+const balanceOfBatchSize = 100
+
+type ERC1155Config struct {
+	Account common.Address
+	// Restricts the scan to these contracts, all contracts are scanned when empty
+	Contracts []common.Address
+	// Block range of the TransferSingle/TransferBatch log scan.
+	// Balances are read at ToBlock, nil scans and reads up to the latest block.
+	FromBlock *big.Int
+	ToBlock   *big.Int
+}
+
+type ERC1155Result struct {
+	Account common.Address
+	// Non-zero balances of the discovered collectibles
+	Balances map[multistandardfetcher.HashableCollectibleID]*big.Int
+	// Contracts whose balances couldn't be confirmed
+	ContractErrs  map[common.Address]error
+	AtBlockNumber *big.Int
+	AtBlockHash   common.Hash
+	Err           error
+}
+
+// Finds the ERC1155 collectibles held by the account: every (contract, tokenID)
+// received according to the transfer logs is a candidate, kept if its current
+// balanceOfBatch balance is non-zero.
+func DiscoverERC1155(ctx context.Context, caller multicall.Caller, filterClient eventfilter.FilterClient, config ERC1155Config, batchSize int) ERC1155Result {
+	ret := ERC1155Result{
+		Account:      config.Account,
+		Balances:     make(map[multistandardfetcher.HashableCollectibleID]*big.Int),
+		ContractErrs: make(map[common.Address]error),
+	}
+
+	candidates, err := receivedERC1155Tokens(ctx, filterClient, config)
+	if err != nil {
+		ret.Err = err
+		return ret
+	}
+	if len(candidates) == 0 {
+		return ret
+	}
+
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
+	if config.ToBlock != nil {
+		atBlock = gethrpc.BlockNumberOrHashWithNumber(gethrpc.BlockNumber(config.ToBlock.Int64()))
+	}
+
+	// One job per contract, one balanceOfBatch call per balanceOfBatchSize token IDs
+	contracts := make([]common.Address, 0, len(candidates))
+	for contractAddress := range candidates {
+		contracts = append(contracts, contractAddress)
+	}
+	sort.Slice(contracts, func(i, j int) bool { return contracts[i].Cmp(contracts[j]) < 0 })
+
+	jobs := make([]multicall.Job, 0, len(contracts))
+	for _, contractAddress := range contracts {
+		tokenIDs := candidates[contractAddress]
+		job := multicall.Job{
+			Calls: make([]multicall3.IMulticall3Call, 0, len(tokenIDs)/balanceOfBatchSize+1),
+			CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+				return multicall.ProcessERC1155BalanceOfBatchResult(result)
+			},
+		}
+		for start := 0; start < len(tokenIDs); start += balanceOfBatchSize {
+			end := min(start+balanceOfBatchSize, len(tokenIDs))
+			accounts := make([]common.Address, end-start)
+			for i := range accounts {
+				accounts[i] = config.Account
+			}
+			job.Calls = append(job.Calls, multicall.BuildERC1155BalanceOfBatchCall(accounts, contractAddress, tokenIDs[start:end]))
+		}
+		jobs = append(jobs, job)
+	}
+	jobResults := multicall.RunSync(ctx, jobs, atBlock, caller, batchSize)
+
+	for i, contractAddress := range contracts {
+		jobResult := jobResults[i]
+		if jobResult.Err != nil {
+			ret.ContractErrs[contractAddress] = jobResult.Err
+			continue
+		}
+		if ret.AtBlockNumber == nil {
+			ret.AtBlockNumber = jobResult.BlockNumber
+			ret.AtBlockHash = jobResult.BlockHash
+		}
+
+		balances, err := batchBalances(jobResult, len(candidates[contractAddress]))
+		if err != nil {
+			ret.ContractErrs[contractAddress] = err
+			continue
+		}
+		for j, tokenID := range candidates[contractAddress] {
+			if balances[j].Sign() <= 0 {
+				continue
+			}
+			id := multistandardfetcher.CollectibleID{
+				ContractAddress: contractAddress,
+				TokenID:         tokenID,
+			}.ToHashableCollectibleID()
+			ret.Balances[id] = balances[j]
+		}
+	}
+
+	return ret
+}
+
+// Returns the discovered collectibles, sorted by contract and token ID.
+func (r ERC1155Result) CollectibleIDs() []multistandardfetcher.CollectibleID {
+	ret := make([]multistandardfetcher.CollectibleID, 0, len(r.Balances))
+	for id := range r.Balances {
+		ret = append(ret, id.ToCollectibleID())
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if c := ret[i].ContractAddress.Cmp(ret[j].ContractAddress); c != 0 {
+			return c < 0
+		}
+		return ret[i].TokenID.Cmp(ret[j].TokenID) < 0
+	})
+	return ret
+}
+
+// Adds the discovered collectibles to the ERC1155 collectibles of the account
+// in the config, so that their balances are tracked by multistandardfetcher.
+func (r ERC1155Result) AddToFetchConfig(config *multistandardfetcher.FetchConfig) {
+	if len(r.Balances) == 0 {
+		return
+	}
+	if config.ERC1155 == nil {
+		config.ERC1155 = make(map[multistandardfetcher.AccountAddress][]multistandardfetcher.CollectibleID)
+	}
+
+	known := make(map[multistandardfetcher.HashableCollectibleID]bool, len(config.ERC1155[r.Account]))
+	for _, id := range config.ERC1155[r.Account] {
+		known[id.ToHashableCollectibleID()] = true
+	}
+	for _, id := range r.CollectibleIDs() {
+		if known[id.ToHashableCollectibleID()] {
+			continue
+		}
+		config.ERC1155[r.Account] = append(config.ERC1155[r.Account], id)
+	}
+}
+
+// Returns the token IDs received by the account in each contract, as found in the
+// TransferSingle and TransferBatch logs.
+func receivedERC1155Tokens(ctx context.Context, filterClient eventfilter.FilterClient, config ERC1155Config) (map[common.Address][]*big.Int, error) {
+	events, err := eventfilter.FilterTransfers(ctx, filterClient, eventfilter.TransferQueryConfig{
+		FromBlock:         config.FromBlock,
+		ToBlock:           config.ToBlock,
+		ContractAddresses: config.Contracts,
+		Accounts:          []common.Address{config.Account},
+		TransferTypes:     []eventfilter.TransferType{eventfilter.TransferTypeERC1155},
+		Direction:         eventfilter.Receive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[multistandardfetcher.HashableCollectibleID]bool)
+	ret := make(map[common.Address][]*big.Int)
+	add := func(contractAddress common.Address, tokenID *big.Int) {
+		if tokenID == nil {
+			return
+		}
+		id := multistandardfetcher.CollectibleID{
+			ContractAddress: contractAddress,
+			TokenID:         tokenID,
+		}.ToHashableCollectibleID()
+		if seen[id] {
+			return
+		}
+		seen[id] = true
+		ret[contractAddress] = append(ret[contractAddress], tokenID)
+	}
+
+	for _, event := range events {
+		switch transfer := event.Unpacked.(type) {
+		case erc1155.Erc1155TransferSingle:
+			if transfer.To == config.Account {
+				add(transfer.Raw.Address, transfer.Id)
+			}
+		case erc1155.Erc1155TransferBatch:
+			if transfer.To == config.Account {
+				for _, tokenID := range transfer.Ids {
+					add(transfer.Raw.Address, tokenID)
+				}
+			}
+		}
+	}
+	return ret, nil
+}
+
+// Concatenates the balances of the balanceOfBatch calls of a job.
+func batchBalances(jobResult multicall.JobResult, expected int) ([]*big.Int, error) {
+	ret := make([]*big.Int, 0, expected)
+	for _, callResult := range jobResult.Results {
+		if callResult.Err != nil {
+			return nil, callResult.Err
+		}
+		balances, ok := callResult.Value.([]*big.Int)
+		if !ok {
+			return nil, multicall.ErrInvalidReturnData
+		}
+		ret = append(ret, balances...)
+	}
+	if len(ret) != expected {
+		return nil, errors.New("unexpected number of balances")
+	}
+	return ret, nil
+}
@@ -0,0 +1,149 @@
+package collectibles_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/collectibles"
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+var (
+	multiToken = common.HexToAddress("0xb000000000000000000000000000000000000001")
+	gameItems  = common.HexToAddress("0xb000000000000000000000000000000000000002")
+	brokenMT   = common.HexToAddress("0xb000000000000000000000000000000000000003")
+)
+
+func transferSingleLog(contract common.Address, to common.Address, tokenID int64, value int64) types.Log {
+	data, err := erc1155ABI.Events["TransferSingle"].Inputs.NonIndexed().Pack(big.NewInt(tokenID), big.NewInt(value))
+	if err != nil {
+		panic(err)
+	}
+	return types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			eventlog.ERC1155TransferSingleID,
+			common.BytesToHash(other.Bytes()),
+			{},
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func transferBatchLog(contract common.Address, to common.Address, tokenIDs ...int64) types.Log {
+	ids := make([]*big.Int, 0, len(tokenIDs))
+	values := make([]*big.Int, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		ids = append(ids, big.NewInt(tokenID))
+		values = append(values, big.NewInt(1))
+	}
+	data, err := erc1155ABI.Events["TransferBatch"].Inputs.NonIndexed().Pack(ids, values)
+	if err != nil {
+		panic(err)
+	}
+	return types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			eventlog.ERC1155TransferBatchID,
+			common.BytesToHash(other.Bytes()),
+			{},
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: data,
+	}
+}
+
+func newFakeERC1155Chain(t *testing.T) *fakeChain {
+	// More candidates than fit in a single balanceOfBatch call
+	manyIDs := make([]int64, 0, 250)
+	gameBalances := make(map[int64]int64)
+	for i := int64(0); i < 250; i++ {
+		manyIDs = append(manyIDs, i)
+		if i%50 == 0 {
+			gameBalances[i] = i + 1
+		}
+	}
+
+	return &fakeChain{
+		t: t,
+		contracts: map[common.Address]*fakeContract{
+			multiToken: {balances: map[int64]int64{1: 10, 2: 0, 3: 5}},
+			gameItems:  {balances: gameBalances},
+			brokenMT:   {brokenBalance: true},
+		},
+		logs: []types.Log{
+			transferSingleLog(multiToken, account, 1, 10),
+			// Spent since
+			transferSingleLog(multiToken, account, 2, 1),
+			transferBatchLog(multiToken, account, 1, 3),
+			transferBatchLog(gameItems, account, manyIDs...),
+			transferSingleLog(brokenMT, account, 1, 1),
+		},
+	}
+}
+
+func TestDiscoverERC1155(t *testing.T) {
+	chain := newFakeERC1155Chain(t)
+	result := collectibles.DiscoverERC1155(context.Background(), chain, chain, collectibles.ERC1155Config{
+		Account:   account,
+		FromBlock: big.NewInt(10),
+		ToBlock:   big.NewInt(900),
+	}, 100)
+
+	require.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(headBlock), result.AtBlockNumber)
+
+	ids := result.CollectibleIDs()
+	require.Len(t, ids, 2+5)
+	assertIDs(t, multiToken, []int64{1, 3}, ids[:2])
+	assertIDs(t, gameItems, []int64{0, 50, 100, 150, 200}, ids[2:])
+
+	balance := result.Balances[multistandardfetcher.CollectibleID{ContractAddress: gameItems, TokenID: big.NewInt(150)}.ToHashableCollectibleID()]
+	assert.Equal(t, int64(151), balance.Int64())
+
+	require.Len(t, result.ContractErrs, 1)
+	assert.Error(t, result.ContractErrs[brokenMT])
+
+	// Balances are read at the end of the scanned range
+	require.Len(t, chain.queries, 1)
+	assert.Equal(t, big.NewInt(900), chain.queries[0].ToBlock)
+	for _, atBlock := range chain.atBlocks {
+		assert.Equal(t, big.NewInt(900), atBlock)
+	}
+}
+
+func TestERC1155Result_AddToFetchConfig(t *testing.T) {
+	chain := newFakeERC1155Chain(t)
+	result := collectibles.DiscoverERC1155(context.Background(), chain, chain, collectibles.ERC1155Config{
+		Account:   account,
+		Contracts: []common.Address{multiToken},
+	}, 100)
+	require.NoError(t, result.Err)
+
+	known := multistandardfetcher.CollectibleID{ContractAddress: multiToken, TokenID: big.NewInt(3)}
+	config := multistandardfetcher.FetchConfig{
+		ERC1155: map[multistandardfetcher.AccountAddress][]multistandardfetcher.CollectibleID{account: {known}},
+	}
+	result.AddToFetchConfig(&config)
+	assertIDs(t, multiToken, []int64{3, 1}, config.ERC1155[account])
+
+	empty := multistandardfetcher.FetchConfig{}
+	result.AddToFetchConfig(&empty)
+	assertIDs(t, multiToken, []int64{1, 3}, empty.ERC1155[account])
+}
+
+func TestDiscoverERC1155_FilterLogsError(t *testing.T) {
+	chain := newFakeERC1155Chain(t)
+	chain.filterErr = errFilterLogs
+	result := collectibles.DiscoverERC1155(context.Background(), chain, chain, collectibles.ERC1155Config{Account: account}, 100)
+	assert.ErrorIs(t, result.Err, errFilterLogs)
+	assert.Empty(t, chain.atBlocks)
+}
@@ -0,0 +1,288 @@
+package collectibles
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// Balances above this are not listed with tokenOfOwnerByIndex, as it would
+// take one call per token. Such contracts use the Transfer logs instead.
+const maxEnumeratedBalance = 10_000
+
+type ERC721Config struct {
+	Account   common.Address
+	Contracts []common.Address
+	// First block scanned for Transfer logs of contracts that don't support
+	// ERC721Enumerable. Nil scans from genesis; tokens received before this
+	// block are not found.
+	FromBlock *big.Int
+}
+
+type ERC721Holdings struct {
+	ContractAddress common.Address
+	// Value of balanceOf(account)
+	Balance *big.Int
+	// Whether the token IDs were listed with tokenOfOwnerByIndex rather than
+	// reconstructed from Transfer logs
+	Enumerated     bool
+	CollectibleIDs []multistandardfetcher.CollectibleID
+	Err            error
+}
+
+type ERC721Result struct {
+	Account       common.Address
+	Holdings      map[common.Address]ERC721Holdings
+	AtBlockNumber *big.Int
+	AtBlockHash   common.Hash
+	Err           error
+}
+
+// Lists the ERC721 tokens owned by the account in each of the configured contracts.
+// All contract reads happen at the same block, which also bounds the Transfer log scan.
+// Failures are reported per contract (Err), except when no contract could be read at all.
+func EnumerateERC721(ctx context.Context, caller multicall.Caller, filterClient eventfilter.FilterClient, config ERC721Config, batchSize int) ERC721Result {
+	ret := ERC721Result{
+		Account:  config.Account,
+		Holdings: make(map[common.Address]ERC721Holdings, len(config.Contracts)),
+	}
+	if len(config.Contracts) == 0 {
+		return ret
+	}
+
+	// ERC165 check and balance of every contract
+	probeJobs := make([]multicall.Job, 0, len(config.Contracts))
+	for _, contractAddress := range config.Contracts {
+		probeJobs = append(probeJobs, multicall.Job{
+			Calls: []multicall3.IMulticall3Call{
+				multicall.BuildSupportsInterfaceCall(contractAddress, multicall.ERC721EnumerableInterfaceID),
+				multicall.BuildSupportsInterfaceCall(contractAddress, multicall.InvalidInterfaceID),
+				multicall.BuildERC721BalanceCall(config.Account, contractAddress),
+			},
+			CallResultFn: multicall.RawCallResult,
+		})
+	}
+	probeResults := multicall.RunSync(ctx, probeJobs, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), caller, batchSize)
+
+	enumerable := make([]common.Address, 0)
+	fromLogs := make([]common.Address, 0)
+	probeErrs := make([]error, 0)
+	for i, contractAddress := range config.Contracts {
+		holdings := ERC721Holdings{ContractAddress: contractAddress}
+		jobResult := probeResults[i]
+		if jobResult.Err == nil && len(jobResult.Results) != 3 {
+			jobResult.Err = errors.New("unexpected number of call results")
+		}
+		if jobResult.Err != nil {
+			holdings.Err = jobResult.Err
+			probeErrs = append(probeErrs, jobResult.Err)
+			ret.Holdings[contractAddress] = holdings
+			continue
+		}
+		if ret.AtBlockNumber == nil {
+			ret.AtBlockNumber = jobResult.BlockNumber
+			ret.AtBlockHash = jobResult.BlockHash
+		}
+
+		results := multicall.RawResults(jobResult)
+		supported, _ := multicall.ProcessSupportsInterfaceResults(results[0], results[1])
+		balance, err := multicall.ProcessERC721BalanceResult(results[2])
+		if err != nil {
+			holdings.Err = err
+			ret.Holdings[contractAddress] = holdings
+			continue
+		}
+		holdings.Balance = balance
+		ret.Holdings[contractAddress] = holdings
+
+		switch {
+		case balance.Sign() == 0:
+		case supported && balance.IsInt64() && balance.Int64() <= maxEnumeratedBalance:
+			enumerable = append(enumerable, contractAddress)
+		default:
+			fromLogs = append(fromLogs, contractAddress)
+		}
+	}
+	if ret.AtBlockNumber == nil {
+		ret.Err = errors.Join(probeErrs...)
+		return ret
+	}
+
+	// Follow-up reads are pinned to the block of the balances
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.BlockNumber(ret.AtBlockNumber.Int64()))
+
+	// Contracts reverting on tokenOfOwnerByIndex despite reporting support
+	// fall back to the Transfer logs
+	fromLogs = append(fromLogs, enumerateTokens(ctx, caller, config.Account, enumerable, ret.Holdings, atBlock, batchSize)...)
+	if len(fromLogs) == 0 {
+		return ret
+	}
+
+	candidates, err := receivedTokens(ctx, filterClient, config.Account, fromLogs, config.FromBlock, ret.AtBlockNumber)
+	if err != nil {
+		for _, contractAddress := range fromLogs {
+			holdings := ret.Holdings[contractAddress]
+			holdings.Err = err
+			ret.Holdings[contractAddress] = holdings
+		}
+		return ret
+	}
+	verifyOwnership(ctx, caller, config.Account, fromLogs, candidates, ret.Holdings, atBlock, batchSize)
+
+	return ret
+}
+
+// Lists tokens with tokenOfOwnerByIndex, returns the contracts which couldn't be enumerated.
+func enumerateTokens(ctx context.Context, caller multicall.Caller, account common.Address, contracts []common.Address, holdings map[common.Address]ERC721Holdings, atBlock gethrpc.BlockNumberOrHash, batchSize int) []common.Address {
+	jobs := make([]multicall.Job, 0, len(contracts))
+	for _, contractAddress := range contracts {
+		balance := holdings[contractAddress].Balance.Int64()
+		job := multicall.Job{
+			Calls: make([]multicall3.IMulticall3Call, 0, balance),
+			CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+				return multicall.ProcessUint256Result(result)
+			},
+		}
+		for i := int64(0); i < balance; i++ {
+			job.Calls = append(job.Calls, multicall.BuildERC721TokenOfOwnerByIndexCall(account, contractAddress, big.NewInt(i)))
+		}
+		jobs = append(jobs, job)
+	}
+	jobResults := multicall.RunSync(ctx, jobs, atBlock, caller, batchSize)
+
+	failed := make([]common.Address, 0)
+	for i, contractAddress := range contracts {
+		tokenIDs, ok := uint256Results(jobResults[i], len(jobs[i].Calls))
+		if !ok {
+			failed = append(failed, contractAddress)
+			continue
+		}
+		contractHoldings := holdings[contractAddress]
+		contractHoldings.Enumerated = true
+		contractHoldings.CollectibleIDs = collectibleIDs(contractAddress, tokenIDs)
+		holdings[contractAddress] = contractHoldings
+	}
+	return failed
+}
+
+// Returns the token IDs received by the account in each contract, as found in the Transfer logs.
+func receivedTokens(ctx context.Context, filterClient eventfilter.FilterClient, account common.Address, contracts []common.Address, fromBlock *big.Int, toBlock *big.Int) (map[common.Address][]*big.Int, error) {
+	events, err := eventfilter.FilterTransfers(ctx, filterClient, eventfilter.TransferQueryConfig{
+		FromBlock:         fromBlock,
+		ToBlock:           toBlock,
+		ContractAddresses: contracts,
+		Accounts:          []common.Address{account},
+		TransferTypes:     []eventfilter.TransferType{eventfilter.TransferTypeERC721},
+		Direction:         eventfilter.Receive,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[multistandardfetcher.HashableCollectibleID]bool)
+	ret := make(map[common.Address][]*big.Int)
+	for _, event := range events {
+		if event.EventKey != eventlog.ERC721Transfer {
+			continue
+		}
+		transfer, ok := event.Unpacked.(erc721.Erc721Transfer)
+		if !ok || transfer.To != account || transfer.TokenId == nil {
+			continue
+		}
+		id := multistandardfetcher.CollectibleID{
+			ContractAddress: transfer.Raw.Address,
+			TokenID:         transfer.TokenId,
+		}.ToHashableCollectibleID()
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ret[transfer.Raw.Address] = append(ret[transfer.Raw.Address], transfer.TokenId)
+	}
+	return ret, nil
+}
+
+// Keeps the candidate tokens whose current owner is the account.
+func verifyOwnership(ctx context.Context, caller multicall.Caller, account common.Address, contracts []common.Address, candidates map[common.Address][]*big.Int, holdings map[common.Address]ERC721Holdings, atBlock gethrpc.BlockNumberOrHash, batchSize int) {
+	jobContracts := make([]common.Address, 0, len(contracts))
+	jobs := make([]multicall.Job, 0, len(contracts))
+	for _, contractAddress := range contracts {
+		tokenIDs := candidates[contractAddress]
+		if len(tokenIDs) == 0 {
+			continue
+		}
+		job := multicall.Job{
+			Calls: make([]multicall3.IMulticall3Call, 0, len(tokenIDs)),
+			CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+				return multicall.ProcessAddressResult(result)
+			},
+		}
+		for _, tokenID := range tokenIDs {
+			job.Calls = append(job.Calls, multicall.BuildERC721OwnerOfCall(contractAddress, tokenID))
+		}
+		jobContracts = append(jobContracts, contractAddress)
+		jobs = append(jobs, job)
+	}
+	jobResults := multicall.RunSync(ctx, jobs, atBlock, caller, batchSize)
+
+	for i, contractAddress := range jobContracts {
+		contractHoldings := holdings[contractAddress]
+		jobResult := jobResults[i]
+		if jobResult.Err != nil {
+			contractHoldings.Err = jobResult.Err
+			holdings[contractAddress] = contractHoldings
+			continue
+		}
+
+		owned := make([]*big.Int, 0)
+		for j, callResult := range jobResult.Results {
+			// ownerOf reverts for burned tokens
+			owner, ok := callResult.Value.(common.Address)
+			if callResult.Err != nil || !ok || owner != account {
+				continue
+			}
+			owned = append(owned, candidates[contractAddress][j])
+		}
+		contractHoldings.CollectibleIDs = collectibleIDs(contractAddress, owned)
+		holdings[contractAddress] = contractHoldings
+	}
+}
+
+func uint256Results(jobResult multicall.JobResult, expected int) ([]*big.Int, bool) {
+	if jobResult.Err != nil || len(jobResult.Results) != expected {
+		return nil, false
+	}
+	ret := make([]*big.Int, 0, expected)
+	for _, callResult := range jobResult.Results {
+		value, ok := callResult.Value.(*big.Int)
+		if callResult.Err != nil || !ok || value == nil {
+			return nil, false
+		}
+		ret = append(ret, value)
+	}
+	return ret, true
+}
+
+// Sorted by token ID
+func collectibleIDs(contractAddress common.Address, tokenIDs []*big.Int) []multistandardfetcher.CollectibleID {
+	sort.Slice(tokenIDs, func(i, j int) bool { return tokenIDs[i].Cmp(tokenIDs[j]) < 0 })
+	ret := make([]multistandardfetcher.CollectibleID, 0, len(tokenIDs))
+	for _, tokenID := range tokenIDs {
+		ret = append(ret, multistandardfetcher.CollectibleID{
+			ContractAddress: contractAddress,
+			TokenID:         tokenID,
+		})
+	}
+	return ret
+}
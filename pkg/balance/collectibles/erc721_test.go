@@ -0,0 +1,297 @@
+package collectibles_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/collectibles"
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+var (
+	account = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	enumerable    = common.HexToAddress("0xa000000000000000000000000000000000000001")
+	plain         = common.HexToAddress("0xa000000000000000000000000000000000000002")
+	brokenEnum    = common.HexToAddress("0xa000000000000000000000000000000000000003")
+	empty         = common.HexToAddress("0xa000000000000000000000000000000000000004")
+	notERC721     = common.HexToAddress("0xa000000000000000000000000000000000000005")
+	headBlock     = int64(1000)
+	erc721ABI     = mustABI(erc721.Erc721EnumerableMetaData)
+	erc1155ABI    = mustABI(erc1155.Erc1155MetaData)
+	revertedCall  = multicall3.IMulticall3Result{Success: false}
+	errFilterLogs = errors.New("filter logs failed")
+)
+
+func mustABI(metaData *bind.MetaData) *abi.ABI {
+	ret, err := metaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return ret
+}
+
+type fakeContract struct {
+	enumerable bool
+	// supportsInterface returns true for any interface ID
+	supportsAll bool
+	// tokenOfOwnerByIndex reverts
+	brokenEnumeration bool
+	// balanceOf reverts
+	brokenBalance bool
+	owners        map[int64]common.Address
+	// ERC1155 balances of the account
+	balances map[int64]int64
+}
+
+// Fake chain answering Multicall3 batches from the contract states
+type fakeChain struct {
+	t         *testing.T
+	contracts map[common.Address]*fakeContract
+	logs      []types.Log
+	queries   []ethereum.FilterQuery
+	atBlocks  []*big.Int
+	filterErr error
+}
+
+func (c *fakeChain) ViewTryBlockAndAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+	results, err := c.ViewTryAggregate(opts, requireSuccess, calls)
+	return big.NewInt(headBlock), [32]byte{1}, results, err
+}
+
+func (c *fakeChain) ViewTryAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) ([]multicall3.IMulticall3Result, error) {
+	c.atBlocks = append(c.atBlocks, opts.BlockNumber)
+	results := make([]multicall3.IMulticall3Result, 0, len(calls))
+	for _, call := range calls {
+		results = append(results, c.call(call))
+	}
+	return results, nil
+}
+
+func (c *fakeChain) call(call multicall3.IMulticall3Call) multicall3.IMulticall3Result {
+	contract, ok := c.contracts[call.Target]
+	if !ok {
+		return revertedCall
+	}
+	method, err := erc721ABI.MethodById(call.CallData[:4])
+	if err != nil {
+		method, err = erc1155ABI.MethodById(call.CallData[:4])
+	}
+	require.NoError(c.t, err)
+	args, err := method.Inputs.Unpack(call.CallData[4:])
+	require.NoError(c.t, err)
+
+	var ret []byte
+	switch method.Name {
+	case "supportsInterface":
+		ret, err = method.Outputs.Pack(contract.supportsAll || contract.enumerable && args[0].([4]byte) == multicall.ERC721EnumerableInterfaceID)
+	case "balanceOf":
+		if contract.brokenBalance {
+			return revertedCall
+		}
+		ret, err = method.Outputs.Pack(big.NewInt(int64(len(contract.tokensOf(args[0].(common.Address))))))
+	case "tokenOfOwnerByIndex":
+		tokens := contract.tokensOf(args[0].(common.Address))
+		index := args[1].(*big.Int).Int64()
+		if !contract.enumerable || contract.brokenEnumeration || index >= int64(len(tokens)) {
+			return revertedCall
+		}
+		ret, err = method.Outputs.Pack(big.NewInt(tokens[index]))
+	case "balanceOfBatch":
+		if contract.brokenBalance {
+			return revertedCall
+		}
+		balances := make([]*big.Int, 0)
+		for _, tokenID := range args[1].([]*big.Int) {
+			balances = append(balances, big.NewInt(contract.balances[tokenID.Int64()]))
+		}
+		ret, err = method.Outputs.Pack(balances)
+	case "ownerOf":
+		owner, ok := contract.owners[args[0].(*big.Int).Int64()]
+		if !ok {
+			return revertedCall
+		}
+		ret, err = method.Outputs.Pack(owner)
+	default:
+		return revertedCall
+	}
+	require.NoError(c.t, err)
+	return multicall3.IMulticall3Result{Success: true, ReturnData: ret}
+}
+
+// Sorted, so that tokenOfOwnerByIndex is stable across calls
+func (c *fakeContract) tokensOf(owner common.Address) []int64 {
+	ret := make([]int64, 0)
+	for tokenID, tokenOwner := range c.owners {
+		if tokenOwner == owner {
+			ret = append(ret, tokenID)
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.queries = append(c.queries, query)
+	if c.filterErr != nil {
+		return nil, c.filterErr
+	}
+	ret := make([]types.Log, 0)
+	for _, log := range c.logs {
+		if len(query.Addresses) == 0 || slices.Contains(query.Addresses, log.Address) {
+			ret = append(ret, log)
+		}
+	}
+	return ret, nil
+}
+
+func transferLog(contract common.Address, from common.Address, to common.Address, tokenID int64) types.Log {
+	return types.Log{
+		Address: contract,
+		Topics: []common.Hash{
+			eventlog.ERC721TransferID,
+			common.BytesToHash(from.Bytes()),
+			common.BytesToHash(to.Bytes()),
+			common.BigToHash(big.NewInt(tokenID)),
+		},
+	}
+}
+
+func newFakeChain(t *testing.T) *fakeChain {
+	return &fakeChain{
+		t: t,
+		contracts: map[common.Address]*fakeContract{
+			enumerable: {enumerable: true, owners: map[int64]common.Address{5: account, 2: account, 3: other}},
+			plain:      {owners: map[int64]common.Address{7: account, 9: account, 3: other}},
+			brokenEnum: {enumerable: true, brokenEnumeration: true, owners: map[int64]common.Address{11: account}},
+			empty:      {enumerable: true, owners: map[int64]common.Address{1: other}},
+			notERC721:  {brokenBalance: true},
+		},
+		logs: []types.Log{
+			transferLog(plain, common.Address{}, account, 7),
+			transferLog(plain, common.Address{}, account, 9),
+			// Received and sent away
+			transferLog(plain, common.Address{}, account, 3),
+			// Received back, duplicate candidate
+			transferLog(plain, other, account, 9),
+			// Burned afterwards, ownerOf reverts
+			transferLog(plain, common.Address{}, account, 100),
+			transferLog(brokenEnum, common.Address{}, account, 11),
+		},
+	}
+}
+
+// Compares hashable IDs, as decoded big.Ints differ in their internal representation
+func assertIDs(t *testing.T, contract common.Address, expected []int64, actual []multistandardfetcher.CollectibleID) {
+	expectedIDs := make([]multistandardfetcher.HashableCollectibleID, 0, len(expected))
+	for _, tokenID := range expected {
+		expectedIDs = append(expectedIDs, multistandardfetcher.CollectibleID{ContractAddress: contract, TokenID: big.NewInt(tokenID)}.ToHashableCollectibleID())
+	}
+	actualIDs := make([]multistandardfetcher.HashableCollectibleID, 0, len(actual))
+	for _, id := range actual {
+		actualIDs = append(actualIDs, id.ToHashableCollectibleID())
+	}
+	assert.Equal(t, expectedIDs, actualIDs)
+}
+
+func TestEnumerateERC721(t *testing.T) {
+	chain := newFakeChain(t)
+	result := collectibles.EnumerateERC721(context.Background(), chain, chain, collectibles.ERC721Config{
+		Account:   account,
+		Contracts: []common.Address{enumerable, plain, brokenEnum, empty, notERC721},
+		FromBlock: big.NewInt(100),
+	}, 100)
+
+	require.NoError(t, result.Err)
+	assert.Equal(t, big.NewInt(headBlock), result.AtBlockNumber)
+	require.Len(t, result.Holdings, 5)
+
+	enumerated := result.Holdings[enumerable]
+	assert.NoError(t, enumerated.Err)
+	assert.True(t, enumerated.Enumerated)
+	assert.Equal(t, int64(2), enumerated.Balance.Int64())
+	assertIDs(t, enumerable, []int64{2, 5}, enumerated.CollectibleIDs)
+
+	fromLogs := result.Holdings[plain]
+	assert.NoError(t, fromLogs.Err)
+	assert.False(t, fromLogs.Enumerated)
+	assertIDs(t, plain, []int64{7, 9}, fromLogs.CollectibleIDs)
+
+	fallback := result.Holdings[brokenEnum]
+	assert.NoError(t, fallback.Err)
+	assert.False(t, fallback.Enumerated)
+	assertIDs(t, brokenEnum, []int64{11}, fallback.CollectibleIDs)
+
+	assert.Zero(t, result.Holdings[empty].Balance.Sign())
+	assert.Empty(t, result.Holdings[empty].CollectibleIDs)
+	assert.Error(t, result.Holdings[notERC721].Err)
+
+	// Logs are only scanned for contracts that can't be enumerated, up to the balances block
+	require.NotEmpty(t, chain.queries)
+	for _, query := range chain.queries {
+		assert.ElementsMatch(t, []common.Address{plain, brokenEnum}, query.Addresses)
+		assert.Equal(t, big.NewInt(100), query.FromBlock)
+		assert.Equal(t, big.NewInt(headBlock), query.ToBlock)
+	}
+
+	// Follow-up reads are pinned to the block of the balances
+	for _, atBlock := range chain.atBlocks[1:] {
+		assert.Equal(t, big.NewInt(headBlock), atBlock)
+	}
+}
+
+func TestEnumerateERC721_SupportsAnyInterface(t *testing.T) {
+	chain := newFakeChain(t)
+	chain.contracts[plain].enumerable = true
+	chain.contracts[plain].supportsAll = true
+	result := collectibles.EnumerateERC721(context.Background(), chain, chain, collectibles.ERC721Config{
+		Account:   account,
+		Contracts: []common.Address{plain},
+	}, 100)
+
+	// Answering true for the invalid interface ID rules out ERC165, so tokenOfOwnerByIndex
+	// isn't trusted and tokens are read from logs
+	require.NoError(t, result.Err)
+	holdings := result.Holdings[plain]
+	assert.NoError(t, holdings.Err)
+	assert.False(t, holdings.Enumerated)
+	assertIDs(t, plain, []int64{7, 9}, holdings.CollectibleIDs)
+}
+
+func TestEnumerateERC721_FilterLogsError(t *testing.T) {
+	chain := newFakeChain(t)
+	chain.filterErr = errFilterLogs
+	result := collectibles.EnumerateERC721(context.Background(), chain, chain, collectibles.ERC721Config{
+		Account:   account,
+		Contracts: []common.Address{enumerable, plain},
+	}, 100)
+
+	require.NoError(t, result.Err)
+	assert.Len(t, result.Holdings[enumerable].CollectibleIDs, 2)
+	assert.ErrorIs(t, result.Holdings[plain].Err, errFilterLogs)
+}
+
+func TestEnumerateERC721_Empty(t *testing.T) {
+	chain := newFakeChain(t)
+	result := collectibles.EnumerateERC721(context.Background(), chain, chain, collectibles.ERC721Config{Account: account}, 100)
+	assert.NoError(t, result.Err)
+	assert.Empty(t, result.Holdings)
+	assert.Empty(t, chain.atBlocks)
+}
@@ -4,6 +4,7 @@ package fetcher
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -22,10 +23,37 @@ type RPCClient interface {
 func FetchNativeBalances(
 	ctx context.Context,
 	addresses []common.Address,
-	atBlock gethrpc.BlockNumber,
+	atBlock gethrpc.BlockNumberOrHash,
 	rpcClient RPCClient,
 	batchSize int,
 ) (BalancePerAccountAddress, error) {
+	fetchFn, err := nativeBalancesFetchFn(ctx, rpcClient, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFn(ctx, addresses, atBlock)
+}
+
+func FetchErc20Balances(
+	ctx context.Context,
+	addresses []common.Address,
+	tokenAddresses []common.Address,
+	atBlock gethrpc.BlockNumberOrHash,
+	rpcClient RPCClient,
+	batchSize int,
+) (BalancePerAccountAndTokenAddress, error) {
+	fetchFn, err := erc20BalancesFetchFn(ctx, rpcClient, batchSize)
+	if err != nil {
+		return nil, err
+	}
+	return fetchFn(ctx, addresses, tokenAddresses, atBlock)
+}
+
+type nativeBalancesFn func(ctx context.Context, addresses []common.Address, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAddress, error)
+
+type erc20BalancesFn func(ctx context.Context, addresses []common.Address, tokenAddresses []common.Address, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAndTokenAddress, error)
+
+func nativeBalancesFetchFn(ctx context.Context, rpcClient RPCClient, batchSize int) (nativeBalancesFn, error) {
 	chainID, err := rpcClient.ChainID(ctx)
 	if err != nil {
 		return nil, err
@@ -36,22 +64,24 @@ func FetchNativeBalances(
 	if exists {
 		multicallCaller, err := multicall3.NewMulticall3Caller(multicallAddress, rpcClient)
 		if err == nil {
-			return FetchNativeBalancesWithMulticall(ctx, addresses, atBlock, multicallCaller, multicallAddress, batchSize)
+			return func(ctx context.Context, addresses []common.Address, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAddress, error) {
+				balances, err := FetchNativeBalancesWithMulticall(ctx, addresses, atBlock, multicallCaller, multicallAddress, batchSize)
+				// Blocks before the Multicall3 deployment
+				if errors.Is(err, bind.ErrNoCode) {
+					return FetchNativeBalancesStandard(ctx, addresses, atBlock, rpcClient, batchSize)
+				}
+				return balances, err
+			}, nil
 		}
 	}
 
 	// As last resort, use less efficient batch call
-	return FetchNativeBalancesStandard(ctx, addresses, atBlock, rpcClient, batchSize)
+	return func(ctx context.Context, addresses []common.Address, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAddress, error) {
+		return FetchNativeBalancesStandard(ctx, addresses, atBlock, rpcClient, batchSize)
+	}, nil
 }
 
-func FetchErc20Balances(
-	ctx context.Context,
-	addresses []common.Address,
-	tokenAddresses []common.Address,
-	atBlock gethrpc.BlockNumber,
-	rpcClient RPCClient,
-	batchSize int,
-) (BalancePerAccountAndTokenAddress, error) {
+func erc20BalancesFetchFn(ctx context.Context, rpcClient RPCClient, batchSize int) (erc20BalancesFn, error) {
 	chainID, err := rpcClient.ChainID(ctx)
 	if err != nil {
 		return nil, err
@@ -62,10 +92,19 @@ func FetchErc20Balances(
 	if exists {
 		multicallCaller, err := multicall3.NewMulticall3Caller(multicallAddress, rpcClient)
 		if err == nil {
-			return FetchErc20BalancesWithMulticall(ctx, addresses, tokenAddresses, atBlock, multicallCaller, batchSize)
+			return func(ctx context.Context, addresses []common.Address, tokenAddresses []common.Address, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAndTokenAddress, error) {
+				balances, err := FetchErc20BalancesWithMulticall(ctx, addresses, tokenAddresses, atBlock, multicallCaller, batchSize)
+				// Blocks before the Multicall3 deployment
+				if errors.Is(err, bind.ErrNoCode) {
+					return FetchErc20BalancesStandard(ctx, addresses, tokenAddresses, atBlock, rpcClient, batchSize)
+				}
+				return balances, err
+			}, nil
 		}
 	}
 
 	// As last resort, use less efficient batch call
-	return FetchErc20BalancesStandard(ctx, addresses, tokenAddresses, atBlock, rpcClient, batchSize)
+	return func(ctx context.Context, addresses []common.Address, tokenAddresses []common.Address, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAndTokenAddress, error) {
+		return FetchErc20BalancesStandard(ctx, addresses, tokenAddresses, atBlock, rpcClient, batchSize)
+	}, nil
 }
@@ -0,0 +1,217 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+)
+
+// ErrStateUnavailable is set on history points whose state could not be read,
+// typically because the node is not an archive node and has pruned it.
+var ErrStateUnavailable = errors.New("state not available at block")
+
+// ErrNoBalance is set on history points for which no balance was returned.
+var ErrNoBalance = errors.New("no balance returned")
+
+// Maximum number of blocks fetched concurrently in historical mode
+const maxConcurrentHistoryBlocks = 4
+
+// Substrings of the errors returned by the main node implementations when the
+// requested state has been pruned. Errors for blocks that don't exist (e.g.
+// "header not found" for future blocks) are not matched: they fail the fetch.
+var stateUnavailableErrors = []string{
+	"missing trie node",
+	"historical state",
+	"state histories haven't been fully indexed",
+	"state is not available",
+	"old data not available",
+	"distance to target block exceeds maximum",
+}
+
+type HeaderClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Reports whether the error means the state at the requested block is not available.
+func IsStateUnavailableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, ErrStateUnavailable) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, substr := range stateUnavailableErrors {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetches native balances at each of the given blocks, returning one point per
+// block for each address, sorted by ascending block number.
+// Blocks whose state is not available are reported per point through Err.
+func FetchNativeBalanceHistory(
+	ctx context.Context,
+	addresses []common.Address,
+	blockNumbers []uint64,
+	rpcClient RPCClient,
+	batchSize int,
+) (BalanceHistoryPerAccountAddress, error) {
+	fetchFn, err := nativeBalancesFetchFn(ctx, rpcClient, batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumbers = normalizeBlockNumbers(blockNumbers)
+	balancesPerBlock, errs, err := fetchPerBlock(ctx, blockNumbers, func(ctx context.Context, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAddress, error) {
+		return fetchFn(ctx, addresses, atBlock)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(BalanceHistoryPerAccountAddress, len(addresses))
+	for _, address := range addresses {
+		points := make(BalanceHistory, 0, len(blockNumbers))
+		for i, blockNumber := range blockNumbers {
+			points = append(points, newBalancePoint(blockNumber, balancesPerBlock[i], address, errs[i]))
+		}
+		history[address] = points
+	}
+	return history, nil
+}
+
+// Fetches ERC20 balances at each of the given blocks, returning one point per
+// block for each (account, token) pair, sorted by ascending block number.
+// Blocks whose state is not available are reported per point through Err.
+func FetchErc20BalanceHistory(
+	ctx context.Context,
+	addresses []common.Address,
+	tokenAddresses []common.Address,
+	blockNumbers []uint64,
+	rpcClient RPCClient,
+	batchSize int,
+) (BalanceHistoryPerAccountAndTokenAddress, error) {
+	fetchFn, err := erc20BalancesFetchFn(ctx, rpcClient, batchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	blockNumbers = normalizeBlockNumbers(blockNumbers)
+	balancesPerBlock, errs, err := fetchPerBlock(ctx, blockNumbers, func(ctx context.Context, atBlock gethrpc.BlockNumberOrHash) (BalancePerAccountAndTokenAddress, error) {
+		return fetchFn(ctx, addresses, tokenAddresses, atBlock)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make(BalanceHistoryPerAccountAndTokenAddress, len(addresses))
+	for _, address := range addresses {
+		history[address] = make(BalanceHistoryPerTokenAddress, len(tokenAddresses))
+		for _, tokenAddress := range tokenAddresses {
+			points := make(BalanceHistory, 0, len(blockNumbers))
+			for i, blockNumber := range blockNumbers {
+				points = append(points, newBalancePoint(blockNumber, balancesPerBlock[i][address], tokenAddress, errs[i]))
+			}
+			history[address][tokenAddress] = points
+		}
+	}
+	return history, nil
+}
+
+// Returns, for each timestamp, the number of the last block mined at or before it.
+// Timestamps before the genesis block resolve to block 0, timestamps after the
+// latest block resolve to the latest block.
+// Use an ethclient.BlockResolver directly to reuse known blocks across calls.
+func BlockNumbersAtTimestamps(ctx context.Context, client HeaderClient, timestamps []uint64) ([]uint64, error) {
+	return ethclient.NewBlockResolver(client).BlockNumbersAt(ctx, timestamps)
+}
+
+func newBalancePoint(blockNumber uint64, balances map[common.Address]*big.Int, address common.Address, err error) BalancePoint {
+	point := BalancePoint{
+		BlockNumber: blockNumber,
+		Err:         err,
+	}
+	if err != nil {
+		return point
+	}
+	point.Balance = balances[address]
+	if point.Balance == nil {
+		point.Err = ErrNoBalance
+	}
+	return point
+}
+
+func normalizeBlockNumbers(blockNumbers []uint64) []uint64 {
+	ret := slices.Clone(blockNumbers)
+	slices.Sort(ret)
+	return slices.Compact(ret)
+}
+
+// Runs fetchFn at each block, with bounded concurrency.
+// Errors caused by unavailable state are returned per block, any other error aborts the fetch.
+func fetchPerBlock[T any](
+	ctx context.Context,
+	blockNumbers []uint64,
+	fetchFn func(ctx context.Context, atBlock gethrpc.BlockNumberOrHash) (T, error),
+) ([]T, []error, error) {
+	results := make([]T, len(blockNumbers))
+	errs := make([]error, len(blockNumbers))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sem := make(chan struct{}, maxConcurrentHistoryBlocks)
+	wg := sync.WaitGroup{}
+	for i, blockNumber := range blockNumbers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			results[i], errs[i] = fetchFn(ctx, gethrpc.BlockNumberOrHashWithNumber(gethrpc.BlockNumber(blockNumber)))
+			if errs[i] != nil && !IsStateUnavailableError(errs[i]) {
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Report the error that caused the cancellation rather than the cancellations themselves
+	var fatalErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if IsStateUnavailableError(err) {
+			if !errors.Is(err, ErrStateUnavailable) {
+				errs[i] = fmt.Errorf("%w: %w", ErrStateUnavailable, err)
+			}
+			continue
+		}
+		if fatalErr == nil || errors.Is(fatalErr, context.Canceled) {
+			fatalErr = err
+		}
+	}
+	if fatalErr != nil {
+		return nil, nil, fatalErr
+	}
+	return results, errs, nil
+}
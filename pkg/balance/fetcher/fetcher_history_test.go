@@ -0,0 +1,249 @@
+package fetcher_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/fetcher"
+	mock_fetcher "github.com/status-im/go-wallet-sdk/pkg/balance/fetcher/mock"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+)
+
+func TestFetchNativeBalanceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRPC := mock_fetcher.NewMockRPCClient(ctrl)
+
+	addresses := []common.Address{
+		common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		common.HexToAddress("0x2222222222222222222222222222222222222222"),
+	}
+
+	mockRPC.EXPECT().ChainID(ctx).Return(big.NewInt(99999), nil)
+
+	// Balance is block number * (index + 1), block 100 has been pruned
+	var mu sync.Mutex
+	requestedBlocks := make(map[gethrpc.BlockNumber]int)
+	mockRPC.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch []gethrpc.BatchElem) error {
+		for i := range batch {
+			atBlock := batch[i].Args[1].(gethrpc.BlockNumber)
+			mu.Lock()
+			requestedBlocks[atBlock]++
+			mu.Unlock()
+			if atBlock == 100 {
+				batch[i].Error = errors.New("missing trie node 0x1234 (path )")
+				continue
+			}
+			batch[i].Result = (*hexutil.Big)(big.NewInt(int64(atBlock) * int64(i+1)))
+		}
+		return nil
+	}).Times(3)
+
+	history, err := fetcher.FetchNativeBalanceHistory(ctx, addresses, []uint64{300, 100, 200, 300}, mockRPC, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+
+	// Each block is requested once, even if duplicated
+	assert.Len(t, requestedBlocks, 3)
+
+	for i, address := range addresses {
+		points := history[address]
+		require.Len(t, points, 3)
+
+		assert.Equal(t, uint64(100), points[0].BlockNumber)
+		assert.Nil(t, points[0].Balance)
+		assert.ErrorIs(t, points[0].Err, fetcher.ErrStateUnavailable)
+
+		assert.Equal(t, uint64(200), points[1].BlockNumber)
+		assert.NoError(t, points[1].Err)
+		assert.Equal(t, big.NewInt(200*int64(i+1)), points[1].Balance)
+
+		assert.Equal(t, uint64(300), points[2].BlockNumber)
+		assert.NoError(t, points[2].Err)
+		assert.Equal(t, big.NewInt(300*int64(i+1)), points[2].Balance)
+	}
+}
+
+func TestFetchNativeBalanceHistory_FatalError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRPC := mock_fetcher.NewMockRPCClient(ctrl)
+
+	mockRPC.EXPECT().ChainID(ctx).Return(big.NewInt(99999), nil)
+
+	expectedError := errors.New("connection refused")
+	mockRPC.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).Return(expectedError).AnyTimes()
+
+	addresses := []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111")}
+	history, err := fetcher.FetchNativeBalanceHistory(ctx, addresses, []uint64{100, 200}, mockRPC, 10)
+	assert.ErrorIs(t, err, expectedError)
+	assert.Nil(t, history)
+}
+
+func TestFetchNativeBalanceHistory_NonexistentBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRPC := mock_fetcher.NewMockRPCClient(ctrl)
+
+	mockRPC.EXPECT().ChainID(ctx).Return(big.NewInt(99999), nil)
+
+	// Block 1000 is past the head
+	mockRPC.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch []gethrpc.BatchElem) error {
+		for i := range batch {
+			if batch[i].Args[1].(gethrpc.BlockNumber) == 1000 {
+				batch[i].Error = errors.New("header not found")
+				continue
+			}
+			batch[i].Result = (*hexutil.Big)(big.NewInt(1))
+		}
+		return nil
+	}).AnyTimes()
+
+	addresses := []common.Address{common.HexToAddress("0x1111111111111111111111111111111111111111")}
+	history, err := fetcher.FetchNativeBalanceHistory(ctx, addresses, []uint64{100, 1000}, mockRPC, 10)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, fetcher.ErrStateUnavailable)
+	assert.Contains(t, err.Error(), "header not found")
+	assert.Nil(t, history)
+}
+
+func TestFetchNativeBalanceHistory_BeforeMulticall3(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRPC := mock_fetcher.NewMockRPCClient(ctrl)
+
+	const deploymentBlock = 14353601
+	multicallABI, err := multicall3.Multicall3MetaData.GetAbi()
+	require.NoError(t, err)
+
+	// Mainnet, Multicall3 has no code before its deployment
+	mockRPC.EXPECT().ChainID(ctx).Return(big.NewInt(1), nil)
+	mockRPC.EXPECT().CallContract(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+		if blockNumber.Uint64() < deploymentBlock {
+			return nil, nil
+		}
+		method := multicallABI.Methods["tryBlockAndAggregate"]
+		args, err := method.Inputs.Unpack(call.Data[4:])
+		require.NoError(t, err)
+		results := make([]multicall3.IMulticall3Result, reflect.ValueOf(args[1]).Len())
+		for i := range results {
+			results[i] = multicall3.IMulticall3Result{Success: true, ReturnData: common.BigToHash(big.NewInt(2)).Bytes()}
+		}
+		return method.Outputs.Pack(blockNumber, common.Hash{}, results)
+	}).AnyTimes()
+	mockRPC.EXPECT().CodeAt(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	mockRPC.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch []gethrpc.BatchElem) error {
+		for i := range batch {
+			require.Less(t, int64(batch[i].Args[1].(gethrpc.BlockNumber)), int64(deploymentBlock))
+			batch[i].Result = (*hexutil.Big)(big.NewInt(1))
+		}
+		return nil
+	}).Times(1)
+
+	address := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	history, err := fetcher.FetchNativeBalanceHistory(ctx, []common.Address{address}, []uint64{100, 20000000}, mockRPC, 10)
+	require.NoError(t, err)
+
+	points := history[address]
+	require.Len(t, points, 2)
+	// Read with the standard RPC calls
+	assert.NoError(t, points[0].Err)
+	assert.Equal(t, big.NewInt(1), points[0].Balance)
+	// Read through Multicall3
+	assert.NoError(t, points[1].Err)
+	assert.Equal(t, big.NewInt(2), points[1].Balance)
+}
+
+func TestFetchErc20BalanceHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockRPC := mock_fetcher.NewMockRPCClient(ctrl)
+
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	tokens := []common.Address{
+		common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"),
+		common.HexToAddress("0xdAC17F958D2ee523a2206206994597C13D831ec7"),
+	}
+
+	mockRPC.EXPECT().ChainID(ctx).Return(big.NewInt(99999), nil)
+	mockRPC.EXPECT().BatchCallContext(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, batch []gethrpc.BatchElem) error {
+		for i := range batch {
+			batch[i].Result = (*hexutil.Big)(big.NewInt(int64(i + 1)))
+		}
+		return nil
+	}).Times(2)
+
+	history, err := fetcher.FetchErc20BalanceHistory(ctx, []common.Address{account}, tokens, []uint64{10, 20}, mockRPC, 10)
+	require.NoError(t, err)
+	require.Len(t, history[account], 2)
+
+	for i, token := range tokens {
+		points := history[account][token]
+		require.Len(t, points, 2)
+		for _, point := range points {
+			assert.NoError(t, point.Err)
+			assert.Equal(t, big.NewInt(int64(i+1)), point.Balance)
+		}
+		assert.Equal(t, uint64(10), points[0].BlockNumber)
+		assert.Equal(t, uint64(20), points[1].BlockNumber)
+	}
+}
+
+type fakeHeaderClient struct {
+	// Block time of each block, indexed by block number
+	times []uint64
+}
+
+func (c *fakeHeaderClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	if number == nil {
+		number = big.NewInt(int64(len(c.times) - 1))
+	}
+	return &types.Header{Number: number, Time: c.times[number.Uint64()]}, nil
+}
+
+func TestBlockNumbersAtTimestamps(t *testing.T) {
+	client := &fakeHeaderClient{times: []uint64{1000, 1012, 1024, 1036, 1048, 1100, 1112}}
+
+	blockNumbers, err := fetcher.BlockNumbersAtTimestamps(context.Background(), client, []uint64{
+		900,  // Before genesis
+		1000, // Genesis
+		1030, // Between blocks 2 and 3
+		1036, // Exactly block 3
+		1099, // In a gap
+		2000, // After latest
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 0, 2, 3, 4, 6}, blockNumbers)
+}
+
+func TestIsStateUnavailableError(t *testing.T) {
+	assert.True(t, fetcher.IsStateUnavailableError(errors.New("missing trie node abc")))
+	assert.True(t, fetcher.IsStateUnavailableError(errors.New("historical state not available in path scheme yet")))
+	assert.False(t, fetcher.IsStateUnavailableError(errors.New("header not found")))
+	assert.True(t, fetcher.IsStateUnavailableError(fetcher.ErrStateUnavailable))
+	assert.False(t, fetcher.IsStateUnavailableError(errors.New("connection refused")))
+	assert.False(t, fetcher.IsStateUnavailableError(nil))
+}
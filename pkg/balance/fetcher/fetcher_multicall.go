@@ -14,7 +14,7 @@ import (
 func FetchNativeBalancesWithMulticall(
 	ctx context.Context,
 	accountAddresses []common.Address,
-	atBlock gethrpc.BlockNumber,
+	atBlock gethrpc.BlockNumberOrHash,
 	multicallCaller multicall.Caller,
 	multicallAddress common.Address,
 	batchSize int,
@@ -34,7 +34,7 @@ func FetchNativeBalancesWithMulticall(
 		},
 	}
 
-	results := multicall.RunSync(ctx, jobs, big.NewInt(int64(atBlock)), multicallCaller, batchSize)
+	results := multicall.RunSync(ctx, jobs, atBlock, multicallCaller, batchSize)
 
 	for _, result := range results {
 		if result.Err != nil {
@@ -60,7 +60,7 @@ func FetchErc20BalancesWithMulticall(
 	ctx context.Context,
 	accountAddresses []common.Address,
 	tokenAddresses []common.Address,
-	atBlock gethrpc.BlockNumber,
+	atBlock gethrpc.BlockNumberOrHash,
 	multicallCaller multicall.Caller,
 	batchSize int,
 ) (BalancePerAccountAndTokenAddress, error) {
@@ -81,7 +81,7 @@ func FetchErc20BalancesWithMulticall(
 		},
 	}
 
-	results := multicall.RunSync(ctx, jobs, big.NewInt(int64(atBlock)), multicallCaller, batchSize)
+	results := multicall.RunSync(ctx, jobs, atBlock, multicallCaller, batchSize)
 
 	for _, result := range results {
 		if result.Err != nil {
@@ -33,7 +33,7 @@ func TestFetchNativeBalancesWithMulticall_Success(t *testing.T) {
 		addresses[i] = common.HexToAddress(gofakeit.HexUint(160))
 	}
 
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -48,7 +48,7 @@ func TestFetchNativeBalancesWithMulticall_Success(t *testing.T) {
 	mockMulticallCaller.EXPECT().ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).DoAndReturn(
 		func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
 			require.Equal(t, ctx, opts.Context)
-			require.Equal(t, big.NewInt(int64(atBlock)), opts.BlockNumber)
+			require.Equal(t, big.NewInt(1000), opts.BlockNumber)
 			require.Equal(t, false, requireSuccess)
 			require.Len(t, calls, 3)
 
@@ -63,7 +63,7 @@ func TestFetchNativeBalancesWithMulticall_Success(t *testing.T) {
 			for i := range calls {
 				results[i] = multicall3.IMulticall3Result{
 					Success:    true,
-					ReturnData: expectedBalances[i].Bytes(),
+					ReturnData: common.LeftPadBytes(expectedBalances[i].Bytes(), 32),
 				}
 			}
 
@@ -96,7 +96,7 @@ func TestFetchNativeBalancesWithMulticall_EmptyAddresses(t *testing.T) {
 	mockMulticallCaller := mock_multicall.NewMockCaller(ctrl)
 
 	addresses := []common.Address{}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -120,7 +120,7 @@ func TestFetchNativeBalancesWithMulticall_MulticallError(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -151,7 +151,7 @@ func TestFetchNativeBalancesWithMulticall_LargeBatch(t *testing.T) {
 		addresses[i] = common.HexToAddress(gofakeit.HexUint(160))
 	}
 
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10 // Should create 3 chunks: 10, 10, 5
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -166,7 +166,7 @@ func TestFetchNativeBalancesWithMulticall_LargeBatch(t *testing.T) {
 				balance := new(big.Int).Mul(big.NewInt(int64(i)), big.NewInt(1000000000000000000))
 				results[i] = multicall3.IMulticall3Result{
 					Success:    true,
-					ReturnData: balance.Bytes(),
+					ReturnData: common.LeftPadBytes(balance.Bytes(), 32),
 				}
 			}
 
@@ -185,7 +185,7 @@ func TestFetchNativeBalancesWithMulticall_LargeBatch(t *testing.T) {
 				balance := new(big.Int).Mul(big.NewInt(int64(i+10)), big.NewInt(1000000000000000000))
 				results[i] = multicall3.IMulticall3Result{
 					Success:    true,
-					ReturnData: balance.Bytes(),
+					ReturnData: common.LeftPadBytes(balance.Bytes(), 32),
 				}
 			}
 
@@ -202,7 +202,7 @@ func TestFetchNativeBalancesWithMulticall_LargeBatch(t *testing.T) {
 				balance := new(big.Int).Mul(big.NewInt(int64(i+20)), big.NewInt(1000000000000000000))
 				results[i] = multicall3.IMulticall3Result{
 					Success:    true,
-					ReturnData: balance.Bytes(),
+					ReturnData: common.LeftPadBytes(balance.Bytes(), 32),
 				}
 			}
 
@@ -221,7 +221,7 @@ func TestFetchNativeBalancesWithMulticall_LargeBatch(t *testing.T) {
 	for i, addr := range addresses {
 		assert.Contains(t, result, addr)
 		expectedBalance := new(big.Int).Mul(big.NewInt(int64(i)), big.NewInt(1000000000000000000))
-		assert.Equal(t, expectedBalance, result[addr])
+		assert.Equal(t, expectedBalance.String(), result[addr].String())
 	}
 }
 
@@ -237,7 +237,7 @@ func TestFetchNativeBalancesWithMulticall_FailedResults(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -247,7 +247,7 @@ func TestFetchNativeBalancesWithMulticall_FailedResults(t *testing.T) {
 			results := []multicall3.IMulticall3Result{
 				{
 					Success:    true,
-					ReturnData: big.NewInt(1000000000000000000).Bytes(), // 1 ETH
+					ReturnData: common.LeftPadBytes(big.NewInt(1000000000000000000).Bytes(), 32), // 1 ETH
 				},
 				{
 					Success:    false, // Failed result
@@ -285,7 +285,7 @@ func TestFetchNativeBalancesWithMulticall_ZeroBalance(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -295,7 +295,7 @@ func TestFetchNativeBalancesWithMulticall_ZeroBalance(t *testing.T) {
 			results := []multicall3.IMulticall3Result{
 				{
 					Success:    true,
-					ReturnData: big.NewInt(0).Bytes(), // Zero balance
+					ReturnData: common.LeftPadBytes(big.NewInt(0).Bytes(), 32), // Zero balance
 				},
 			}
 
@@ -311,7 +311,7 @@ func TestFetchNativeBalancesWithMulticall_ZeroBalance(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Len(t, result, 1)
-	assert.Equal(t, big.NewInt(0), result[addresses[0]])
+	assert.Equal(t, "0", result[addresses[0]].String())
 }
 
 func TestFetchNativeBalancesWithMulticall_ContextCancellation(t *testing.T) {
@@ -325,7 +325,7 @@ func TestFetchNativeBalancesWithMulticall_ContextCancellation(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -343,7 +343,7 @@ func TestFetchNativeBalancesWithMulticall_ContextCancellation(t *testing.T) {
 				results := []multicall3.IMulticall3Result{
 					{
 						Success:    true,
-						ReturnData: big.NewInt(1000000000000000000).Bytes(),
+						ReturnData: common.LeftPadBytes(big.NewInt(1000000000000000000).Bytes(), 32),
 					},
 				}
 				blockNumber := big.NewInt(1000)
@@ -372,7 +372,7 @@ func TestFetchNativeBalancesWithMulticall_InvalidData(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 	multicallAddress := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
 
@@ -409,7 +409,7 @@ func generateTestMulticallResults(count int) []multicall3.IMulticall3Result {
 		balance := new(big.Int).Mul(big.NewInt(int64(i)), big.NewInt(1000000000000000000))
 		results[i] = multicall3.IMulticall3Result{
 			Success:    true,
-			ReturnData: balance.Bytes(),
+			ReturnData: common.LeftPadBytes(balance.Bytes(), 32),
 		}
 	}
 	return results
@@ -433,7 +433,7 @@ func TestFetchErc20BalancesWithMulticall_Success(t *testing.T) {
 		tokenAddresses[i] = common.HexToAddress(gofakeit.HexUint(160))
 	}
 
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Expected balances for each account-token combination
@@ -447,7 +447,7 @@ func TestFetchErc20BalancesWithMulticall_Success(t *testing.T) {
 	mockMulticallCaller.EXPECT().ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).DoAndReturn(
 		func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
 			require.Equal(t, ctx, opts.Context)
-			require.Equal(t, big.NewInt(int64(atBlock)), opts.BlockNumber)
+			require.Equal(t, big.NewInt(1000), opts.BlockNumber)
 			require.Equal(t, false, requireSuccess)
 			require.Len(t, calls, 6) // 3 accounts * 2 tokens
 
@@ -465,7 +465,7 @@ func TestFetchErc20BalancesWithMulticall_Success(t *testing.T) {
 				tokenIdx := i % 2
 				results[i] = multicall3.IMulticall3Result{
 					Success:    true,
-					ReturnData: expectedBalances[accountIdx][tokenIdx].Bytes(),
+					ReturnData: common.LeftPadBytes(expectedBalances[accountIdx][tokenIdx].Bytes(), 32),
 				}
 			}
 
@@ -505,7 +505,7 @@ func TestFetchErc20BalancesWithMulticall_EmptyAddresses(t *testing.T) {
 	tokenAddresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Test
@@ -529,7 +529,7 @@ func TestFetchErc20BalancesWithMulticall_EmptyTokenAddresses(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
 	tokenAddresses := []common.Address{}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Test
@@ -556,7 +556,7 @@ func TestFetchErc20BalancesWithMulticall_MulticallError(t *testing.T) {
 	tokenAddresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations - simulate multicall error
@@ -19,7 +19,7 @@ type BatchCaller interface {
 func FetchNativeBalancesStandard(
 	ctx context.Context,
 	accountAddresses []common.Address,
-	atBlock gethrpc.BlockNumber,
+	atBlock gethrpc.BlockNumberOrHash,
 	batchCaller BatchCaller,
 	batchSize int,
 ) (BalancePerAccountAddress, error) {
@@ -31,7 +31,7 @@ func FetchNativeBalancesStandard(
 			res := (*hexutil.Big)(big.NewInt(0))
 			batch[i] = gethrpc.BatchElem{
 				Method: "eth_getBalance",
-				Args:   []interface{}{address, atBlock},
+				Args:   []interface{}{address, blockArg(atBlock)},
 				Result: res,
 			}
 		}
@@ -55,7 +55,7 @@ func FetchErc20BalancesStandard(
 	ctx context.Context,
 	accountAddresses []common.Address,
 	tokenAddresses []common.Address,
-	atBlock gethrpc.BlockNumber,
+	atBlock gethrpc.BlockNumberOrHash,
 	batchCaller BatchCaller,
 	batchSize int,
 ) (BalancePerAccountAndTokenAddress, error) {
@@ -92,7 +92,7 @@ func FetchErc20BalancesStandard(
 			}
 			batch[i] = gethrpc.BatchElem{
 				Method: "eth_call",
-				Args:   []interface{}{map[string]interface{}{"to": pair.tokenAddress, "data": input, "blockNumber": atBlock}, pair.tokenAddress},
+				Args:   []interface{}{map[string]interface{}{"to": pair.tokenAddress, "data": hexutil.Bytes(input)}, blockArg(atBlock)},
 				Result: res,
 			}
 		}
@@ -111,3 +111,15 @@ func FetchErc20BalancesStandard(
 	}
 	return balances, nil
 }
+
+// Returns the block parameter of the requests: a block number or tag, or an EIP-1898
+// object for block hashes.
+func blockArg(atBlock gethrpc.BlockNumberOrHash) interface{} {
+	if blockNumber, ok := atBlock.Number(); ok {
+		return blockNumber
+	}
+	if _, ok := atBlock.Hash(); ok {
+		return atBlock
+	}
+	return gethrpc.LatestBlockNumber
+}
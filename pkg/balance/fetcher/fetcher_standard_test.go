@@ -2,8 +2,10 @@ package fetcher_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/brianvoe/gofakeit/v7"
@@ -32,7 +34,7 @@ func TestFetchNativeBalancesStandard_Success(t *testing.T) {
 		addresses[i] = common.HexToAddress(gofakeit.HexUint(160))
 	}
 
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Expected balances
@@ -52,7 +54,7 @@ func TestFetchNativeBalancesStandard_Success(t *testing.T) {
 				assert.Equal(t, "eth_getBalance", elem.Method)
 				assert.Len(t, elem.Args, 2)
 				assert.Equal(t, addresses[i], elem.Args[0])
-				assert.Equal(t, atBlock, elem.Args[1])
+				assert.Equal(t, gethrpc.BlockNumber(1000), elem.Args[1])
 
 				// Set the result directly on the batch element
 				if hexResult, ok := elem.Result.(*hexutil.Big); ok {
@@ -86,7 +88,7 @@ func TestFetchNativeBalancesStandard_EmptyAddresses(t *testing.T) {
 	mockBatchCaller := mock_fetcher.NewMockBatchCaller(ctrl)
 
 	addresses := []common.Address{}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Test
@@ -109,7 +111,7 @@ func TestFetchNativeBalancesStandard_BatchCallError(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations - simulate batch call error
@@ -136,7 +138,7 @@ func TestFetchNativeBalancesStandard_BatchElementError(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations - simulate batch element error
@@ -172,7 +174,7 @@ func TestFetchNativeBalancesStandard_LargeBatch(t *testing.T) {
 		addresses[i] = common.HexToAddress(gofakeit.HexUint(160))
 	}
 
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10 // Should create 3 chunks: 10, 10, 5
 
 	// Mock expectations for multiple chunks
@@ -245,7 +247,7 @@ func TestFetchNativeBalancesStandard_ZeroBalance(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations - simulate zero balance
@@ -281,7 +283,7 @@ func TestFetchNativeBalancesStandard_ContextCancellation(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Cancel context before calling
@@ -311,3 +313,37 @@ func TestFetchNativeBalancesStandard_ContextCancellation(t *testing.T) {
 	assert.Contains(t, err.Error(), "context canceled")
 	assert.Nil(t, result)
 }
+
+func TestFetchErc20BalancesStandard_AtBlockHash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	mockBatchCaller := mock_fetcher.NewMockBatchCaller(ctrl)
+
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	blockHash := common.HexToHash("0xabcd")
+	atBlock := gethrpc.BlockNumberOrHashWithHash(blockHash, true)
+
+	mockBatchCaller.EXPECT().BatchCallContext(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, batch []gethrpc.BatchElem) error {
+			require.Len(t, batch, 1)
+			assert.Equal(t, "eth_call", batch[0].Method)
+
+			// Call object with hex encoded data, then EIP-1898 block parameter
+			encoded, err := json.Marshal(batch[0].Args)
+			require.NoError(t, err)
+			assert.JSONEq(t, `[
+				{"to": "`+strings.ToLower(token.Hex())+`", "data": "0x70a08231000000000000000000000000`+strings.ToLower(account.Hex()[2:])+`"},
+				{"blockHash": "`+blockHash.Hex()+`", "requireCanonical": true}
+			]`, string(encoded))
+
+			*batch[0].Result.(*hexutil.Big) = hexutil.Big(*big.NewInt(42))
+			return nil
+		})
+
+	result, err := fetcher.FetchErc20BalancesStandard(ctx, []common.Address{account}, []common.Address{token}, atBlock, mockBatchCaller, 10)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), result[account][token])
+}
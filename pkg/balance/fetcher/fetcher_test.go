@@ -33,7 +33,7 @@ func TestFetchNativeBalances_Success(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations
@@ -73,7 +73,7 @@ func TestFetchNativeBalances_ChainIDError(t *testing.T) {
 	addresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations - simulate ChainID error
@@ -99,7 +99,7 @@ func TestFetchNativeBalances_EmptyAddresses(t *testing.T) {
 
 	addresses := []common.Address{}
 	chainID := big.NewInt(1)
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations
@@ -128,7 +128,7 @@ func TestFetchNativeBalances_WithMulticall(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -172,7 +172,7 @@ func TestFetchNativeBalances_WithLargeBatch(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10 // Should create 3 chunks: 10, 10, 5
 
 	// Mock expectations
@@ -214,7 +214,7 @@ func TestFetchNativeBalances_WithoutMulticall(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -260,7 +260,7 @@ func TestFetchNativeBalances_WithBatchCallError(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -291,7 +291,7 @@ func TestFetchNativeBalances_WithBatchElementError(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -344,7 +344,7 @@ func TestFetchNativeBalances_Integration(t *testing.T) {
 
 	addresses := generateTestAddresses(5)
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 3
 
 	// Mock chain ID
@@ -392,7 +392,7 @@ func TestFetchErc20Balances_Success(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations
@@ -444,7 +444,7 @@ func TestFetchErc20Balances_ChainIDError(t *testing.T) {
 	tokenAddresses := []common.Address{
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations - simulate ChainID error
@@ -473,7 +473,7 @@ func TestFetchErc20Balances_EmptyAddresses(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
 	chainID := big.NewInt(1)
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations
@@ -501,7 +501,7 @@ func TestFetchErc20Balances_EmptyTokenAddresses(t *testing.T) {
 	}
 	tokenAddresses := []common.Address{}
 	chainID := big.NewInt(1)
-	atBlock := gethrpc.LatestBlockNumber
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
 	batchSize := 10
 
 	// Mock expectations
@@ -533,7 +533,7 @@ func TestFetchErc20Balances_WithMulticall(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -579,7 +579,7 @@ func TestFetchErc20Balances_WithoutMulticall(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -636,7 +636,7 @@ func TestFetchErc20Balances_WithLargeBatch(t *testing.T) {
 	}
 
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10 // Should create multiple chunks for 25 total calls (5*5)
 
 	// Mock expectations
@@ -681,7 +681,7 @@ func TestFetchErc20Balances_WithBatchCallError(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -715,7 +715,7 @@ func TestFetchErc20Balances_WithBatchElementError(t *testing.T) {
 		common.HexToAddress(gofakeit.HexUint(160)),
 	}
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -749,7 +749,7 @@ func TestFetchErc20Balances_Integration(t *testing.T) {
 	addresses := generateTestAddresses(3)
 	tokenAddresses := generateTestAddresses(2)
 	chainID := big.NewInt(99999) // Chain without multicall3 support
-	atBlock := gethrpc.BlockNumber(1000)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(1000)
 	batchSize := 10
 
 	// Mock expectations
@@ -11,3 +11,19 @@ type BalancePerAccountAddress = map[common.Address]*big.Int
 type BalancePerTokenAddress = map[common.Address]*big.Int
 
 type BalancePerAccountAndTokenAddress = map[common.Address]BalancePerTokenAddress
+
+// BalancePoint is the balance at a given block. Balance is nil when Err is set.
+type BalancePoint struct {
+	BlockNumber uint64
+	Balance     *big.Int
+	Err         error
+}
+
+// Balances sorted by ascending block number
+type BalanceHistory = []BalancePoint
+
+type BalanceHistoryPerAccountAddress = map[common.Address]BalanceHistory
+
+type BalanceHistoryPerTokenAddress = map[common.Address]BalanceHistory
+
+type BalanceHistoryPerAccountAndTokenAddress = map[common.Address]BalanceHistoryPerTokenAddress
@@ -1,5 +1,6 @@
 // Package multistandardfetcher fetches balances across multiple token standards
-// (native, ERC20, ERC721, ERC1155) using Multicall3 batched calls.
+// (native, ERC20, ERC721, ERC1155) using Multicall3 batched calls, falling back to
+// JSON-RPC batches on chains where Multicall3 is not deployed or fails.
 //
 // It exposes an asynchronous, channel-based API for streaming results.
 package multistandardfetcher
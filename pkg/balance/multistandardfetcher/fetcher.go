@@ -5,6 +5,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/go-wallet-sdk/pkg/multicall"
 )
@@ -51,77 +52,104 @@ type FetchResult struct {
 	Result     any
 }
 
-// Fetches balances asynchronously using Multicall3 batched calls.
+// Fetches balances asynchronously using Multicall3 batched calls, at the given block
+// (number, hash or tag, see multicall.RunAsync).
 // Returns a channel where a FetchResult will be sent for each account address specified in the FetchConfig.
 // The channel is closed when all results have been sent.
-func FetchBalances(ctx context.Context, multicall3Address common.Address, caller multicall.Caller, config FetchConfig, batchSize int) <-chan FetchResult {
-	// One job per account
-	nativeJobCount := len(config.Native)
-	erc20JobCount := len(config.ERC20)
-	erc721JobCount := len(config.ERC721)
-	erc1155JobCount := len(config.ERC1155)
-	jobCount := nativeJobCount + erc20JobCount + erc721JobCount + erc1155JobCount
+func FetchBalances(ctx context.Context, multicall3Address common.Address, caller multicall.Caller, config FetchConfig, atBlock gethrpc.BlockNumberOrHash, batchSize int) <-chan FetchResult {
+	fetchJobs := buildFetchJobs(multicall3Address, config)
+	resultsCh := make(chan FetchResult, len(fetchJobs))
 
-	resultsCh := make(chan FetchResult, jobCount)
-	jobs := make([]multicall.Job, 0, jobCount)
+	jobResultsCh := multicall.RunAsync(ctx, multicallJobs(fetchJobs), atBlock, caller, batchSize)
 
-	type jobResultProcessor func(multicall.JobResult) FetchResult
-	jobResultProcessors := make([]jobResultProcessor, 0, jobCount)
+	go func() {
+		defer close(resultsCh)
+		// jobResultsCh is closed when all results have been sent, causing the
+		// loop to exit and the goroutine to end.
+		for jobResult := range jobResultsCh {
+			resultsCh <- fetchJobs[jobResult.JobIdx].process(jobResult.JobResult)
+		}
+	}()
+
+	return resultsCh
+}
+
+// A balance read for a single account, runnable either through Multicall3 or plain RPC calls
+type fetchJob struct {
+	resultType ResultType
+	account    AccountAddress
+	job        multicall.Job
+	process    func(multicall.JobResult) FetchResult
+}
+
+// Builds one job per account and token standard
+func buildFetchJobs(multicall3Address common.Address, config FetchConfig) []fetchJob {
+	jobCount := len(config.Native) + len(config.ERC20) + len(config.ERC721) + len(config.ERC1155)
+	fetchJobs := make([]fetchJob, 0, jobCount)
 
 	for _, account := range config.Native {
-		job := buildNativeJob(account, multicall3Address)
-		jobs = append(jobs, job)
-		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) FetchResult {
-			return FetchResult{
-				ResultType: ResultTypeNative,
-				Result:     processNativeJobResult(account, jobResult),
-			}
+		fetchJobs = append(fetchJobs, fetchJob{
+			resultType: ResultTypeNative,
+			account:    account,
+			job:        buildNativeJob(account, multicall3Address),
+			process: func(jobResult multicall.JobResult) FetchResult {
+				return FetchResult{
+					ResultType: ResultTypeNative,
+					Result:     processNativeJobResult(account, jobResult),
+				}
+			},
 		})
 	}
 
 	for account, contractAddresses := range config.ERC20 {
-		job := buildERC20Job(account, contractAddresses)
-		jobs = append(jobs, job)
-		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) FetchResult {
-			return FetchResult{
-				ResultType: ResultTypeERC20,
-				Result:     processERC20JobResult(account, contractAddresses, jobResult),
-			}
+		fetchJobs = append(fetchJobs, fetchJob{
+			resultType: ResultTypeERC20,
+			account:    account,
+			job:        buildERC20Job(account, contractAddresses),
+			process: func(jobResult multicall.JobResult) FetchResult {
+				return FetchResult{
+					ResultType: ResultTypeERC20,
+					Result:     processERC20JobResult(account, contractAddresses, jobResult),
+				}
+			},
 		})
 	}
 
 	for account, contractAddresses := range config.ERC721 {
-		job := buildERC721Job(account, contractAddresses)
-		jobs = append(jobs, job)
-		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) FetchResult {
-			return FetchResult{
-				ResultType: ResultTypeERC721,
-				Result:     processERC721JobResult(account, contractAddresses, jobResult),
-			}
+		fetchJobs = append(fetchJobs, fetchJob{
+			resultType: ResultTypeERC721,
+			account:    account,
+			job:        buildERC721Job(account, contractAddresses),
+			process: func(jobResult multicall.JobResult) FetchResult {
+				return FetchResult{
+					ResultType: ResultTypeERC721,
+					Result:     processERC721JobResult(account, contractAddresses, jobResult),
+				}
+			},
 		})
 	}
 
 	for account, tokens := range config.ERC1155 {
-		job := buildERC1155JobRunner(account, tokens)
-		jobs = append(jobs, job)
-		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) FetchResult {
-			return FetchResult{
-				ResultType: ResultTypeERC1155,
-				Result:     processERC1155JobResult(account, tokens, jobResult),
-			}
+		fetchJobs = append(fetchJobs, fetchJob{
+			resultType: ResultTypeERC1155,
+			account:    account,
+			job:        buildERC1155JobRunner(account, tokens),
+			process: func(jobResult multicall.JobResult) FetchResult {
+				return FetchResult{
+					ResultType: ResultTypeERC1155,
+					Result:     processERC1155JobResult(account, tokens, jobResult),
+				}
+			},
 		})
 	}
 
-	jobResultsCh := multicall.RunAsync(ctx, jobs, nil, caller, batchSize)
-
-	go func() {
-		defer close(resultsCh)
-		// jobResultsCh is closed when all results have been sent, causing the
-		// loop to exit and the goroutine to end.
-		for jobResult := range jobResultsCh {
-			resultsCh <- jobResultProcessors[jobResult.JobIdx](jobResult.JobResult)
-		}
-	}()
+	return fetchJobs
+}
 
-	return resultsCh
+func multicallJobs(fetchJobs []fetchJob) []multicall.Job {
+	jobs := make([]multicall.Job, 0, len(fetchJobs))
+	for _, fetchJob := range fetchJobs {
+		jobs = append(jobs, fetchJob.job)
+	}
+	return jobs
 }
@@ -0,0 +1,97 @@
+package multistandardfetcher
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+type RPCClient interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BatchCaller
+	bind.ContractCaller
+}
+
+// Fetches balances asynchronously, choosing the strategy for the chain of the client.
+// Multicall3 batched calls are used when Multicall3 is deployed on the chain. Accounts for
+// which they fail (or all of them if there is no deployment) are fetched with
+// FetchBalancesStandard instead, at the block number of the Multicall3 results
+// when atBlock is a block tag, so that all results come from the same block.
+// Returns a channel where a FetchResult will be sent for each account address specified in the FetchConfig.
+// The channel is closed when all results have been sent.
+func FetchBalancesWithFallback(ctx context.Context, rpcClient RPCClient, config FetchConfig, atBlock gethrpc.BlockNumberOrHash, batchSize int) <-chan FetchResult {
+	multicall3Address, caller, ok := multicallCaller(ctx, rpcClient)
+	fetchJobs := buildFetchJobs(multicall3Address, config)
+	resultsCh := make(chan FetchResult, len(fetchJobs))
+
+	send := func(result FetchResult) {
+		resultsCh <- result
+	}
+
+	go func() {
+		defer close(resultsCh)
+
+		if !ok {
+			runFetchJobsStandard(ctx, rpcClient, fetchJobs, atBlock, batchSize, send)
+			return
+		}
+
+		// Failed jobs are not reported, but retried with the standard strategy
+		succeeded := make([]bool, len(fetchJobs))
+		fallbackBlock := atBlock
+		for jobResult := range multicall.RunAsync(ctx, multicallJobs(fetchJobs), atBlock, caller, batchSize) {
+			if jobResult.JobResult.Err != nil {
+				continue
+			}
+			succeeded[jobResult.JobIdx] = true
+			fallbackBlock = pinnedBlock(atBlock, jobResult.JobResult.BlockNumber)
+			send(fetchJobs[jobResult.JobIdx].process(jobResult.JobResult))
+		}
+
+		failedJobs := make([]fetchJob, 0, len(fetchJobs))
+		for i, fetchJob := range fetchJobs {
+			if !succeeded[i] {
+				failedJobs = append(failedJobs, fetchJob)
+			}
+		}
+		runFetchJobsStandard(ctx, rpcClient, failedJobs, fallbackBlock, batchSize, send)
+	}()
+
+	return resultsCh
+}
+
+// Returns the block of the results read at blockNumber for atBlock: block
+// tags are replaced by the number, block hashes and the pending state are
+// kept.
+func pinnedBlock(atBlock gethrpc.BlockNumberOrHash, blockNumber *big.Int) gethrpc.BlockNumberOrHash {
+	if _, ok := atBlock.Hash(); ok || blockNumber == nil || !blockNumber.IsInt64() {
+		return atBlock
+	}
+	if number, ok := atBlock.Number(); ok && number == gethrpc.PendingBlockNumber {
+		return atBlock
+	}
+	return gethrpc.BlockNumberOrHashWithNumber(gethrpc.BlockNumber(blockNumber.Int64()))
+}
+
+// Returns the Multicall3 caller for the chain of the client, if there is a deployment.
+func multicallCaller(ctx context.Context, rpcClient RPCClient) (common.Address, multicall.Caller, bool) {
+	chainID, err := rpcClient.ChainID(ctx)
+	if err != nil {
+		return common.Address{}, nil, false
+	}
+	multicall3Address, exists := multicall3.GetMulticall3Address(chainID.Int64())
+	if !exists {
+		return common.Address{}, nil, false
+	}
+	caller, err := multicall3.NewMulticall3Caller(multicall3Address, rpcClient)
+	if err != nil {
+		return common.Address{}, nil, false
+	}
+	return multicall3Address, caller, true
+}
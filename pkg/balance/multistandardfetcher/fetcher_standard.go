@@ -0,0 +1,219 @@
+package multistandardfetcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+var ErrBlockNotFound = errors.New("block not found")
+
+type BatchCaller interface {
+	BatchCallContext(ctx context.Context, b []gethrpc.BatchElem) error
+}
+
+// Fetches balances asynchronously without Multicall3, using JSON-RPC batches of
+// eth_getBalance (native) and eth_call (balanceOf of each token) requests.
+// The block is resolved first and all requests are pinned to it: block tags
+// (latest, safe, finalized) to the number of the block they point to at the time
+// of the call, block hashes to the hash (EIP-1898). Pending reads use the pending
+// state for all requests.
+// Returns a channel where a FetchResult will be sent for each account address specified in the FetchConfig.
+// The channel is closed when all results have been sent.
+func FetchBalancesStandard(ctx context.Context, batchCaller BatchCaller, config FetchConfig, atBlock gethrpc.BlockNumberOrHash, batchSize int) <-chan FetchResult {
+	fetchJobs := buildFetchJobs(common.Address{}, config)
+	resultsCh := make(chan FetchResult, len(fetchJobs))
+
+	go func() {
+		defer close(resultsCh)
+		runFetchJobsStandard(ctx, batchCaller, fetchJobs, atBlock, batchSize, func(result FetchResult) {
+			resultsCh <- result
+		})
+	}()
+
+	return resultsCh
+}
+
+type blockHead struct {
+	Number *hexutil.Big `json:"number"`
+	Hash   common.Hash  `json:"hash"`
+}
+
+type callArgs struct {
+	To   common.Address `json:"to"`
+	Data hexutil.Bytes  `json:"data"`
+}
+
+// Runs each call of the jobs as an individual RPC request, sent in batches of batchSize requests.
+// The result of a job is sent as soon as all of its requests are done.
+func runFetchJobsStandard(ctx context.Context, batchCaller BatchCaller, fetchJobs []fetchJob, atBlock gethrpc.BlockNumberOrHash, batchSize int, send func(FetchResult)) {
+	if len(fetchJobs) == 0 {
+		return
+	}
+
+	head, blockArg, err := resolveBlock(ctx, batchCaller, atBlock)
+	if err != nil {
+		for _, fetchJob := range fetchJobs {
+			send(fetchJob.process(multicall.JobResult{Err: err}))
+		}
+		return
+	}
+
+	type elemRef struct {
+		jobIdx  int
+		callIdx int
+	}
+
+	elems := make([]gethrpc.BatchElem, 0, len(fetchJobs))
+	refs := make([]elemRef, 0, len(fetchJobs))
+	jobEnds := make([]int, len(fetchJobs))
+	jobResults := make([]multicall.JobResult, len(fetchJobs))
+	for i, fetchJob := range fetchJobs {
+		jobResults[i] = multicall.JobResult{
+			BlockNumber: head.Number.ToInt(),
+			BlockHash:   head.Hash,
+		}
+		if fetchJob.resultType == ResultTypeNative {
+			// Multicall3 getEthBalance has no standalone equivalent
+			elems = append(elems, gethrpc.BatchElem{
+				Method: "eth_getBalance",
+				Args:   []interface{}{fetchJob.account, blockArg},
+				Result: new(hexutil.Big),
+			})
+			refs = append(refs, elemRef{i, 0})
+			jobResults[i].Results = make([]multicall.CallResult, 1)
+		} else {
+			for j, call := range fetchJob.job.Calls {
+				elems = append(elems, gethrpc.BatchElem{
+					Method: "eth_call",
+					Args:   []interface{}{callArgs{To: call.Target, Data: call.CallData}, blockArg},
+					Result: new(hexutil.Bytes),
+				})
+				refs = append(refs, elemRef{i, j})
+			}
+			jobResults[i].Results = make([]multicall.CallResult, len(fetchJob.job.Calls))
+		}
+		jobEnds[i] = len(elems)
+	}
+
+	nextJobIdx := 0
+	sendDoneJobs := func(doneElems int) {
+		for ; nextJobIdx < len(fetchJobs) && jobEnds[nextJobIdx] <= doneElems; nextJobIdx++ {
+			send(fetchJobs[nextJobIdx].process(jobResults[nextJobIdx]))
+		}
+	}
+
+	for start := 0; start < len(elems); start += batchSize {
+		end := min(start+batchSize, len(elems))
+		chunk := elems[start:end]
+
+		err := batchCaller.BatchCallContext(ctx, chunk)
+		for k, elem := range chunk {
+			ref := refs[start+k]
+			jobResult := &jobResults[ref.jobIdx]
+			if err != nil {
+				if jobResult.Err == nil {
+					jobResult.Err = err
+				}
+				continue
+			}
+			jobResult.Results[ref.callIdx] = decodeStandardResult(fetchJobs[ref.jobIdx], elem)
+		}
+		sendDoneJobs(end)
+	}
+	sendDoneJobs(len(elems))
+}
+
+// Fetches the header of the block and returns the block parameter pinning requests to it.
+func resolveBlock(ctx context.Context, batchCaller BatchCaller, atBlock gethrpc.BlockNumberOrHash) (*blockHead, any, error) {
+	head := new(blockHead)
+	headElem := gethrpc.BatchElem{
+		Result: head,
+	}
+
+	blockHash, isHash := atBlock.Hash()
+	blockNumber, isNumber := atBlock.Number()
+	switch {
+	case isHash:
+		headElem.Method = "eth_getBlockByHash"
+		headElem.Args = []interface{}{blockHash, false}
+	case isNumber:
+		headElem.Method = "eth_getBlockByNumber"
+		headElem.Args = []interface{}{blockNumber, false}
+	default:
+		headElem.Method = "eth_getBlockByNumber"
+		headElem.Args = []interface{}{gethrpc.LatestBlockNumber, false}
+	}
+
+	batch := []gethrpc.BatchElem{headElem}
+	err := batchCaller.BatchCallContext(ctx, batch)
+	if err == nil {
+		err = batch[0].Error
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if head.Number == nil {
+		return nil, nil, ErrBlockNotFound
+	}
+
+	switch {
+	case isHash:
+		return head, atBlock, nil
+	case isNumber && blockNumber == gethrpc.PendingBlockNumber:
+		return head, gethrpc.PendingBlockNumber, nil
+	}
+	return head, hexutil.EncodeBig(head.Number.ToInt()), nil
+}
+
+func decodeStandardResult(fetchJob fetchJob, elem gethrpc.BatchElem) multicall.CallResult {
+	if elem.Error != nil {
+		// Reverted calls are processed like failed Multicall3 results, giving
+		// the same *multicall.RevertError
+		if data, ok := revertData(elem.Error); ok && elem.Method == "eth_call" && fetchJob.job.CallResultFn != nil {
+			value, err := fetchJob.job.CallResultFn(multicall3.IMulticall3Result{
+				Success:    false,
+				ReturnData: data,
+			})
+			return multicall.CallResult{Value: value, Err: err}
+		}
+		return multicall.CallResult{Err: elem.Error}
+	}
+
+	switch result := elem.Result.(type) {
+	case *hexutil.Big:
+		return multicall.CallResult{Value: (*big.Int)(result)}
+	case *hexutil.Bytes:
+		value, err := fetchJob.job.CallResultFn(multicall3.IMulticall3Result{
+			Success:    true,
+			ReturnData: *result,
+		})
+		return multicall.CallResult{Value: value, Err: err}
+	}
+	return multicall.CallResult{Err: errors.New("unexpected result type")}
+}
+
+// Returns the revert data of a failed eth_call: the data of the JSON-RPC error
+// (code 3 on geth), or no data for reverts reported without it.
+func revertData(err error) ([]byte, bool) {
+	var dataErr gethrpc.DataError
+	if errors.As(err, &dataErr) {
+		if encoded, ok := dataErr.ErrorData().(string); ok {
+			if data, err := hexutil.Decode(encoded); err == nil {
+				return data, true
+			}
+		}
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "execution reverted") {
+		return nil, true
+	}
+	return nil, false
+}
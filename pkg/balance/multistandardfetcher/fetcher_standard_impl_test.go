@@ -0,0 +1,54 @@
+package multistandardfetcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// JSON-RPC error of a reverted eth_call, as returned by geth
+type revertError struct {
+	data string
+}
+
+func (e revertError) Error() string  { return "execution reverted: insufficient balance" }
+func (e revertError) ErrorCode() int { return 3 }
+func (e revertError) ErrorData() any { return e.data }
+
+func TestDecodeStandardResult_Revert(t *testing.T) {
+	fetchJob := fetchJob{
+		resultType: ResultTypeERC20,
+		job:        buildERC20Job(common.HexToAddress("0x1111"), []common.Address{common.HexToAddress("0x2222")}),
+	}
+
+	testCases := []struct {
+		name   string
+		err    error
+		kind   multicall.RevertKind
+		reason string
+	}{
+		// Error("insufficient balance")
+		{"error data", revertError{data: "0x08c379a000000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000014696e73756666696369656e742062616c616e6365000000000000000000000000"}, multicall.RevertKindError, "insufficient balance"},
+		{"no data", errors.New("execution reverted"), multicall.RevertKindEmpty, ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := decodeStandardResult(fetchJob, gethrpc.BatchElem{Method: "eth_call", Error: tc.err})
+			var revertErr *multicall.RevertError
+			require.ErrorAs(t, result.Err, &revertErr)
+			assert.Equal(t, tc.kind, revertErr.Kind)
+			assert.Equal(t, tc.reason, revertErr.Reason)
+		})
+	}
+
+	// Other errors are kept
+	errTimeout := errors.New("request timed out")
+	result := decodeStandardResult(fetchJob, gethrpc.BatchElem{Method: "eth_call", Error: errTimeout})
+	assert.Equal(t, errTimeout, result.Err)
+}
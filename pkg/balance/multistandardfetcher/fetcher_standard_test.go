@@ -0,0 +1,306 @@
+package multistandardfetcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+)
+
+// Fake node answering JSON-RPC batches, Multicall3 calls fail except the
+// first tryBlockAndAggregate call when multicallBlock is set
+type fakeRPCClient struct {
+	chainID        int64
+	head           int64
+	native         map[common.Address]int64
+	contracts      map[common.Address]int64 // balanceOf result of each contract, other contracts revert
+	batchErr       error
+	multicallBlock int64 // Block number reported by Multicall3
+
+	mu              sync.Mutex
+	batches         [][]gethrpc.BatchElem
+	blockArgs       map[string]int // JSON encoded block parameters of the requests
+	multicallCalled bool
+}
+
+func (c *fakeRPCClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(c.chainID), nil
+}
+
+func (c *fakeRPCClient) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *fakeRPCClient) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	first := !c.multicallCalled
+	c.multicallCalled = true
+	if !first || c.multicallBlock == 0 {
+		return nil, errors.New("execution reverted")
+	}
+
+	// Every call returns 1
+	multicallABI, err := multicall3.Multicall3MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	method := multicallABI.Methods["tryBlockAndAggregate"]
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+	results := make([]multicall3.IMulticall3Result, reflect.ValueOf(args[1]).Len())
+	for i := range results {
+		results[i] = multicall3.IMulticall3Result{Success: true, ReturnData: common.LeftPadBytes([]byte{1}, 32)}
+	}
+	return method.Outputs.Pack(big.NewInt(c.multicallBlock), common.Hash{}, results)
+}
+
+func (c *fakeRPCClient) BatchCallContext(ctx context.Context, batch []gethrpc.BatchElem) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.batches = append(c.batches, batch)
+	if c.batchErr != nil {
+		return c.batchErr
+	}
+
+	for i := range batch {
+		elem := &batch[i]
+		switch elem.Method {
+		case "eth_getBlockByNumber", "eth_getBlockByHash":
+			number := c.head
+			if elem.Method == "eth_getBlockByHash" {
+				number = c.head - 5
+			} else if blockNumber := elem.Args[0].(gethrpc.BlockNumber); blockNumber >= 0 {
+				number = blockNumber.Int64()
+			} else if blockNumber == gethrpc.SafeBlockNumber {
+				number = c.head - 10
+			}
+			raw := `{"number":"` + hexutil.EncodeBig(big.NewInt(number)) + `","hash":"0x0100000000000000000000000000000000000000000000000000000000000000"}`
+			if err := json.Unmarshal([]byte(raw), elem.Result); err != nil {
+				return err
+			}
+		case "eth_getBalance":
+			c.countBlockArg(elem.Args[1])
+			*elem.Result.(*hexutil.Big) = hexutil.Big(*big.NewInt(c.native[elem.Args[0].(common.Address)]))
+		case "eth_call":
+			c.countBlockArg(elem.Args[1])
+			encoded, err := json.Marshal(elem.Args[0])
+			if err != nil {
+				return err
+			}
+			var args struct {
+				To common.Address `json:"to"`
+			}
+			if err := json.Unmarshal(encoded, &args); err != nil {
+				return err
+			}
+			balance, ok := c.contracts[args.To]
+			if !ok {
+				elem.Error = errors.New("execution reverted")
+				continue
+			}
+			*elem.Result.(*hexutil.Bytes) = common.LeftPadBytes(big.NewInt(balance).Bytes(), 32)
+		}
+	}
+	return nil
+}
+
+func (c *fakeRPCClient) countBlockArg(blockArg any) {
+	encoded, _ := json.Marshal(blockArg)
+	c.blockArgs[string(encoded)]++
+}
+
+var (
+	latest = gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber)
+
+	testAccount1 = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testAccount2 = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testToken    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	testNFT      = common.HexToAddress("0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D")
+	testERC1155  = common.HexToAddress("0x495f947276749Ce646f68AC8c248420045cb7b5e")
+	testBroken   = common.HexToAddress("0x9999999999999999999999999999999999999999")
+)
+
+func newFakeRPCClient(chainID int64) *fakeRPCClient {
+	return &fakeRPCClient{
+		chainID:   chainID,
+		head:      1000,
+		blockArgs: make(map[string]int),
+		native: map[common.Address]int64{
+			testAccount1: 1_000,
+			testAccount2: 2_000,
+		},
+		contracts: map[common.Address]int64{
+			testToken:   50,
+			testNFT:     2,
+			testERC1155: 7,
+		},
+	}
+}
+
+func fallbackTestConfig() multistandardfetcher.FetchConfig {
+	return multistandardfetcher.FetchConfig{
+		Native: []common.Address{testAccount1, testAccount2},
+		ERC20: map[common.Address][]common.Address{
+			testAccount1: {testToken, testBroken},
+		},
+		ERC721: map[common.Address][]common.Address{
+			testAccount1: {testNFT},
+		},
+		ERC1155: map[common.Address][]multistandardfetcher.CollectibleID{
+			testAccount2: {{ContractAddress: testERC1155, TokenID: big.NewInt(42)}},
+		},
+	}
+}
+
+func collectResults(t *testing.T, resultsCh <-chan multistandardfetcher.FetchResult) map[multistandardfetcher.ResultType][]any {
+	results := make(map[multistandardfetcher.ResultType][]any)
+	for result := range resultsCh {
+		results[result.ResultType] = append(results[result.ResultType], result.Result)
+	}
+	return results
+}
+
+func assertFallbackResults(t *testing.T, results map[multistandardfetcher.ResultType][]any) {
+	require.Len(t, results[multistandardfetcher.ResultTypeNative], 2)
+	for _, r := range results[multistandardfetcher.ResultTypeNative] {
+		native := r.(multistandardfetcher.NativeResult)
+		require.NoError(t, native.Err)
+		assert.Equal(t, big.NewInt(1000), native.AtBlockNumber)
+		if native.Account == testAccount1 {
+			assert.Equal(t, big.NewInt(1_000), native.Result)
+		} else {
+			assert.Equal(t, big.NewInt(2_000), native.Result)
+		}
+	}
+
+	require.Len(t, results[multistandardfetcher.ResultTypeERC20], 1)
+	erc20 := results[multistandardfetcher.ResultTypeERC20][0].(multistandardfetcher.ERC20Result)
+	require.NoError(t, erc20.Err)
+	// Reverted calls are skipped
+	assert.Equal(t, map[common.Address]*big.Int{testToken: big.NewInt(50)}, erc20.Results)
+
+	require.Len(t, results[multistandardfetcher.ResultTypeERC721], 1)
+	erc721 := results[multistandardfetcher.ResultTypeERC721][0].(multistandardfetcher.ERC721Result)
+	require.NoError(t, erc721.Err)
+	assert.Equal(t, map[common.Address]*big.Int{testNFT: big.NewInt(2)}, erc721.Results)
+
+	require.Len(t, results[multistandardfetcher.ResultTypeERC1155], 1)
+	erc1155 := results[multistandardfetcher.ResultTypeERC1155][0].(multistandardfetcher.ERC1155Result)
+	require.NoError(t, erc1155.Err)
+	id := multistandardfetcher.CollectibleID{ContractAddress: testERC1155, TokenID: big.NewInt(42)}
+	assert.Equal(t, big.NewInt(7), erc1155.Results[id.ToHashableCollectibleID()])
+	assert.Equal(t, testAccount2, erc1155.Account)
+}
+
+func TestFetchBalancesStandard(t *testing.T) {
+	client := newFakeRPCClient(1)
+
+	results := collectResults(t, multistandardfetcher.FetchBalancesStandard(context.Background(), client, fallbackTestConfig(), latest, 2))
+	assertFallbackResults(t, results)
+
+	// Head request, then 6 requests in batches of 2
+	require.Len(t, client.batches, 4)
+	for _, batch := range client.batches[1:] {
+		assert.Len(t, batch, 2)
+	}
+
+	// Pinned to the number of the latest block
+	assert.Equal(t, map[string]int{`"0x3e8"`: 6}, client.blockArgs)
+}
+
+func TestFetchBalancesStandard_AtBlock(t *testing.T) {
+	blockHash := common.HexToHash("0xabcd")
+	config := multistandardfetcher.FetchConfig{
+		Native: []common.Address{testAccount1},
+	}
+
+	testCases := []struct {
+		name             string
+		atBlock          gethrpc.BlockNumberOrHash
+		expectedNumber   int64
+		expectedBlockArg string
+	}{
+		{"number", gethrpc.BlockNumberOrHashWithNumber(500), 500, `"0x1f4"`},
+		{"safe", gethrpc.BlockNumberOrHashWithNumber(gethrpc.SafeBlockNumber), 990, `"0x3de"`},
+		{"pending", gethrpc.BlockNumberOrHashWithNumber(gethrpc.PendingBlockNumber), 1000, `"pending"`},
+		{"hash", gethrpc.BlockNumberOrHashWithHash(blockHash, true), 995, `{"blockHash":"` + blockHash.Hex() + `","requireCanonical":true}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := newFakeRPCClient(1)
+
+			results := collectResults(t, multistandardfetcher.FetchBalancesStandard(context.Background(), client, config, tc.atBlock, 10))
+			require.Len(t, results[multistandardfetcher.ResultTypeNative], 1)
+			native := results[multistandardfetcher.ResultTypeNative][0].(multistandardfetcher.NativeResult)
+			require.NoError(t, native.Err)
+			assert.Equal(t, big.NewInt(tc.expectedNumber), native.AtBlockNumber)
+			assert.Equal(t, map[string]int{tc.expectedBlockArg: 1}, client.blockArgs)
+		})
+	}
+}
+
+func TestFetchBalancesStandard_BatchError(t *testing.T) {
+	client := newFakeRPCClient(1)
+	client.batchErr = errors.New("connection refused")
+
+	results := collectResults(t, multistandardfetcher.FetchBalancesStandard(context.Background(), client, fallbackTestConfig(), latest, 2))
+	require.Len(t, results[multistandardfetcher.ResultTypeNative], 2)
+	for _, r := range results[multistandardfetcher.ResultTypeNative] {
+		assert.ErrorIs(t, r.(multistandardfetcher.NativeResult).Err, client.batchErr)
+	}
+	require.Len(t, results[multistandardfetcher.ResultTypeERC20], 1)
+	assert.ErrorIs(t, results[multistandardfetcher.ResultTypeERC20][0].(multistandardfetcher.ERC20Result).Err, client.batchErr)
+}
+
+func TestFetchBalancesWithFallback_NoMulticall3Deployment(t *testing.T) {
+	client := newFakeRPCClient(123456789)
+
+	results := collectResults(t, multistandardfetcher.FetchBalancesWithFallback(context.Background(), client, fallbackTestConfig(), latest, 100))
+	assertFallbackResults(t, results)
+	assert.False(t, client.multicallCalled)
+}
+
+func TestFetchBalancesWithFallback_PinnedToMulticall3Block(t *testing.T) {
+	client := newFakeRPCClient(1)
+	client.multicallBlock = 990
+	config := multistandardfetcher.FetchConfig{
+		Native: []common.Address{testAccount1, testAccount2},
+	}
+
+	// The first account is read through Multicall3, the second one in the
+	// next chunk, which fails
+	results := collectResults(t, multistandardfetcher.FetchBalancesWithFallback(context.Background(), client, config, latest, 1))
+	require.Len(t, results[multistandardfetcher.ResultTypeNative], 2)
+	for _, r := range results[multistandardfetcher.ResultTypeNative] {
+		native := r.(multistandardfetcher.NativeResult)
+		require.NoError(t, native.Err)
+		assert.Equal(t, big.NewInt(990), native.AtBlockNumber)
+	}
+	// Not the latest block
+	assert.Equal(t, map[string]int{`"0x3de"`: 1}, client.blockArgs)
+}
+
+func TestFetchBalancesWithFallback_Multicall3Failure(t *testing.T) {
+	client := newFakeRPCClient(1)
+
+	results := collectResults(t, multistandardfetcher.FetchBalancesWithFallback(context.Background(), client, fallbackTestConfig(), latest, 100))
+	assertFallbackResults(t, results)
+	assert.True(t, client.multicallCalled)
+}
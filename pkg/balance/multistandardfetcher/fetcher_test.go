@@ -9,6 +9,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -63,7 +64,7 @@ func TestFetchBalances_NativeBalances_Success(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -131,7 +132,7 @@ func TestFetchBalances_NativeBalances_Error(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -199,7 +200,7 @@ func TestFetchBalances_ERC20Balances_Success(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -299,7 +300,7 @@ func TestFetchBalances_ERC20Balances_MultipleAccounts(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -386,7 +387,7 @@ func TestFetchBalances_ERC721Balances_Success(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -463,7 +464,7 @@ func TestFetchBalances_ERC1155Balances_Success(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -561,7 +562,7 @@ func TestFetchBalances_MixedBalanceTypes(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -636,7 +637,7 @@ func TestFetchBalances_EmptyConfig(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -684,7 +685,7 @@ func TestFetchBalances_ContextCancellation(t *testing.T) {
 	}
 
 	// Execute
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
@@ -744,7 +745,7 @@ func TestFetchBalances_CallFailure(t *testing.T) {
 
 	// Execute
 	ctx := context.Background()
-	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, 10)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, multicall3Addr, mockCaller, config, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), 10)
 
 	// Collect results
 	var results []multistandardfetcher.FetchResult
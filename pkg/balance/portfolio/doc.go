@@ -0,0 +1,8 @@
+// Package portfolio fetches balances across several chains at once.
+//
+// It wires the per-chain Multicall3 caller for pkg/balance/multistandardfetcher
+// (or batched RPC calls on chains without Multicall3 deployment),
+// runs all chains concurrently with an optional per-chain timeout and streams the
+// results tagged with their chain ID. Fetch configurations can be given per chain
+// or built from token lists (e.g. a tokens/manager Manager).
+package portfolio
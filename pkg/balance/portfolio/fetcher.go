@@ -0,0 +1,140 @@
+package portfolio
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	ErrClientNotProvided      = errors.New("client not provided")
+	ErrMulticall3NotAvailable = errors.New("multicall3 not deployed on chain")
+)
+
+// Fetches the balances of all chains concurrently.
+// Returns a channel where the results of every chain are sent as soon as they are available.
+// The channel is closed when all chains are done.
+func FetchBalances(ctx context.Context, chains map[uint64]ChainConfig, options Options) <-chan ChainFetchResult {
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+
+	resultsCh := make(chan ChainFetchResult)
+
+	wg := sync.WaitGroup{}
+	for chainID, chain := range chains {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fetchChainBalances(ctx, chainID, chain, options, resultsCh)
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	return resultsCh
+}
+
+// Fetches the balances of all chains and consolidates them in a Summary.
+func FetchSummary(ctx context.Context, chains map[uint64]ChainConfig, options Options) *Summary {
+	summary := NewSummary()
+	for result := range FetchBalances(ctx, chains, options) {
+		summary.Add(result)
+	}
+	return summary
+}
+
+func fetchChainBalances(ctx context.Context, chainID uint64, chain ChainConfig, options Options, resultsCh chan<- ChainFetchResult) {
+	// Results are sent even after the chain timed out, so that its errors are reported
+	send := func(result ChainFetchResult) {
+		select {
+		case resultsCh <- result:
+		case <-ctx.Done():
+		}
+	}
+
+	chainCtx := ctx
+	if options.ChainTimeout > 0 {
+		var cancel context.CancelFunc
+		chainCtx, cancel = context.WithTimeout(ctx, options.ChainTimeout)
+		defer cancel()
+	}
+
+	if chain.Client == nil {
+		send(ChainFetchResult{ChainID: chainID, Err: ErrClientNotProvided})
+		return
+	}
+
+	multicall3Address, exists := multicall3.GetMulticall3Address(int64(chainID))
+	if !exists {
+		// Batched RPC calls when the client supports them
+		rpcClient, ok := chain.Client.(multistandardfetcher.RPCClient)
+		if !ok {
+			send(ChainFetchResult{ChainID: chainID, Err: ErrMulticall3NotAvailable})
+			return
+		}
+		for result := range multistandardfetcher.FetchBalancesWithFallback(chainCtx, rpcClient, chain.FetchConfig, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), options.BatchSize) {
+			send(ChainFetchResult{ChainID: chainID, FetchResult: result})
+		}
+		return
+	}
+	caller, err := multicall3.NewMulticall3Caller(multicall3Address, chain.Client)
+	if err != nil {
+		send(ChainFetchResult{ChainID: chainID, Err: err})
+		return
+	}
+
+	for result := range multistandardfetcher.FetchBalances(chainCtx, multicall3Address, caller, chain.FetchConfig, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), options.BatchSize) {
+		send(ChainFetchResult{ChainID: chainID, FetchResult: result})
+	}
+}
+
+// Builds a FetchConfig reading the native and ERC20 balances of all accounts for the given tokens.
+func FetchConfigFromTokens(accounts []common.Address, tokens []*types.Token) multistandardfetcher.FetchConfig {
+	config := multistandardfetcher.FetchConfig{
+		ERC20: make(map[multistandardfetcher.AccountAddress][]multistandardfetcher.ContractAddress),
+	}
+
+	var contracts []common.Address
+	hasNative := false
+	for _, token := range tokens {
+		if token.IsNative() {
+			hasNative = true
+			continue
+		}
+		contracts = append(contracts, token.Address)
+	}
+
+	for _, account := range accounts {
+		if hasNative {
+			config.Native = append(config.Native, account)
+		}
+		if len(contracts) > 0 {
+			config.ERC20[account] = contracts
+		}
+	}
+	return config
+}
+
+// Builds the chain configs for the given clients from the tokens known by the source for each chain.
+func ChainConfigsFromTokenSource(clients map[uint64]bind.ContractCaller, accounts []common.Address, source TokenSource) map[uint64]ChainConfig {
+	ret := make(map[uint64]ChainConfig, len(clients))
+	for chainID, client := range clients {
+		ret[chainID] = ChainConfig{
+			Client:      client,
+			FetchConfig: FetchConfigFromTokens(accounts, source.GetTokensByChain(chainID)),
+		}
+	}
+	return ret
+}
@@ -0,0 +1,200 @@
+package portfolio_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/balance/portfolio"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	account = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	usdc    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	dai     = common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+)
+
+// Fake chain answering every Multicall3 sub-call with the same balance
+type fakeContractCaller struct {
+	t       *testing.T
+	balance int64
+	block   int64
+	// Blocks until the context is done
+	hang bool
+}
+
+func (c *fakeContractCaller) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (c *fakeContractCaller) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	if c.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	contractABI, err := multicall3.Multicall3MetaData.GetAbi()
+	require.NoError(c.t, err)
+	method, err := contractABI.MethodById(call.Data[:4])
+	require.NoError(c.t, err)
+
+	args, err := method.Inputs.Unpack(call.Data[4:])
+	require.NoError(c.t, err)
+	calls := *abi.ConvertType(args[1], new([]multicall3.IMulticall3Call)).(*[]multicall3.IMulticall3Call)
+
+	results := make([]multicall3.IMulticall3Result, 0, len(calls))
+	for range calls {
+		results = append(results, multicall3.IMulticall3Result{
+			Success:    true,
+			ReturnData: common.LeftPadBytes(big.NewInt(c.balance).Bytes(), 32),
+		})
+	}
+
+	switch method.Name {
+	case "tryBlockAndAggregate":
+		return method.Outputs.Pack(big.NewInt(c.block), [32]byte{1}, results)
+	default:
+		return method.Outputs.Pack(results)
+	}
+}
+
+// Also answers JSON-RPC batches, every balance being the same
+type fakeRPCClient struct {
+	*fakeContractCaller
+	chainID int64
+}
+
+func (c *fakeRPCClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(c.chainID), nil
+}
+
+func (c *fakeRPCClient) BatchCallContext(ctx context.Context, batch []gethrpc.BatchElem) error {
+	for i := range batch {
+		switch result := batch[i].Result.(type) {
+		case *hexutil.Big:
+			*result = hexutil.Big(*big.NewInt(c.balance))
+		case *hexutil.Bytes:
+			*result = common.LeftPadBytes(big.NewInt(c.balance).Bytes(), 32)
+		default:
+			if err := json.Unmarshal([]byte(`{"number":"`+hexutil.EncodeBig(big.NewInt(c.block))+`"}`), result); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestFetchSummary(t *testing.T) {
+	tokens := []*types.Token{
+		{ChainID: 1, Address: common.Address{}, Symbol: "ETH"},
+		{ChainID: 1, Address: usdc, Symbol: "USDC"},
+		{ChainID: 1, Address: dai, Symbol: "DAI"},
+	}
+	config := portfolio.FetchConfigFromTokens([]common.Address{account}, tokens)
+	assert.Equal(t, []common.Address{account}, config.Native)
+	assert.Equal(t, []common.Address{usdc, dai}, config.ERC20[account])
+
+	chains := map[uint64]portfolio.ChainConfig{
+		1: {
+			Client:      &fakeContractCaller{t: t, balance: 100, block: 20_000_000},
+			FetchConfig: config,
+		},
+		10: {
+			Client: &fakeContractCaller{t: t, balance: 7, block: 130_000_000},
+			FetchConfig: multistandardfetcher.FetchConfig{
+				ERC721: map[common.Address][]common.Address{account: {dai}},
+			},
+		},
+		// No Multicall3 deployment
+		123456789: {
+			Client:      &fakeContractCaller{t: t},
+			FetchConfig: config,
+		},
+		// No Multicall3 deployment, read with batched RPC calls
+		987654321: {
+			Client:      &fakeRPCClient{fakeContractCaller: &fakeContractCaller{t: t, balance: 3, block: 500}, chainID: 987654321},
+			FetchConfig: config,
+		},
+		// Timed out
+		137: {
+			Client:      &fakeContractCaller{t: t, hang: true},
+			FetchConfig: config,
+		},
+	}
+
+	streamed := 0
+	summary := portfolio.NewSummary()
+	for result := range portfolio.FetchBalances(context.Background(), chains, portfolio.Options{ChainTimeout: 100 * time.Millisecond}) {
+		streamed++
+		summary.Add(result)
+	}
+	assert.Equal(t, 2+1+1+2+2, streamed)
+
+	mainnet := summary.Chains[1]
+	require.NotNil(t, mainnet)
+	assert.NoError(t, mainnet.Err)
+	assert.Empty(t, mainnet.ResultErrs)
+	assert.Equal(t, big.NewInt(100), mainnet.Native[account])
+	assert.Equal(t, big.NewInt(100), mainnet.ERC20[account][usdc])
+	assert.Equal(t, big.NewInt(100), mainnet.ERC20[account][dai])
+	assert.Equal(t, big.NewInt(20_000_000), mainnet.AtBlockNumber)
+
+	optimism := summary.Chains[10]
+	require.NotNil(t, optimism)
+	assert.Equal(t, big.NewInt(7), optimism.ERC721[account][dai])
+	assert.Empty(t, optimism.ERC20)
+
+	assert.ErrorIs(t, summary.Chains[123456789].Err, portfolio.ErrMulticall3NotAvailable)
+
+	batched := summary.Chains[987654321]
+	require.NotNil(t, batched)
+	assert.NoError(t, batched.Err)
+	assert.Empty(t, batched.ResultErrs)
+	assert.Equal(t, big.NewInt(3), batched.Native[account])
+	assert.Equal(t, big.NewInt(3), batched.ERC20[account][usdc])
+	assert.Equal(t, big.NewInt(500), batched.AtBlockNumber)
+
+	polygon := summary.Chains[137]
+	require.NotNil(t, polygon)
+	require.NotEmpty(t, polygon.ResultErrs)
+	assert.ErrorIs(t, polygon.ResultErrs[0], context.DeadlineExceeded)
+
+	assert.Equal(t, []uint64{137, 123456789}, summary.FailedChains())
+}
+
+type fakeTokenSource map[uint64][]*types.Token
+
+func (s fakeTokenSource) GetTokensByChain(chainID uint64) []*types.Token {
+	return s[chainID]
+}
+
+func TestChainConfigsFromTokenSource(t *testing.T) {
+	source := fakeTokenSource{
+		1:  {{ChainID: 1, Address: usdc}},
+		10: {{ChainID: 10, Address: common.Address{}}},
+	}
+	client := &fakeContractCaller{t: t}
+
+	configs := portfolio.ChainConfigsFromTokenSource(map[uint64]bind.ContractCaller{1: client, 10: client}, []common.Address{account}, source)
+	require.Len(t, configs, 2)
+
+	assert.Empty(t, configs[1].FetchConfig.Native)
+	assert.Equal(t, []common.Address{usdc}, configs[1].FetchConfig.ERC20[account])
+
+	assert.Equal(t, []common.Address{account}, configs[10].FetchConfig.Native)
+	assert.Empty(t, configs[10].FetchConfig.ERC20)
+}
@@ -0,0 +1,97 @@
+package portfolio
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+)
+
+func NewSummary() *Summary {
+	return &Summary{
+		Chains: make(map[uint64]*ChainSummary),
+	}
+}
+
+// Add merges a streamed result into the summary.
+func (s *Summary) Add(result ChainFetchResult) {
+	chain := s.chain(result.ChainID)
+	if result.Err != nil {
+		chain.Err = result.Err
+		return
+	}
+
+	switch r := result.Result.(type) {
+	case multistandardfetcher.NativeResult:
+		if r.Err != nil {
+			chain.ResultErrs = append(chain.ResultErrs, r.Err)
+			return
+		}
+		chain.Native[r.Account] = r.Result
+		chain.updateBlockNumber(r.AtBlockNumber)
+	// ERC20Result and ERC721Result are the same type
+	case multistandardfetcher.ERC20Result:
+		if r.Err != nil {
+			chain.ResultErrs = append(chain.ResultErrs, r.Err)
+			return
+		}
+		balances := chain.ERC20
+		if result.ResultType == multistandardfetcher.ResultTypeERC721 {
+			balances = chain.ERC721
+		}
+		if balances[r.Account] == nil {
+			balances[r.Account] = make(map[common.Address]*big.Int)
+		}
+		for contract, balance := range r.Results {
+			balances[r.Account][contract] = balance
+		}
+		chain.updateBlockNumber(r.AtBlockNumber)
+	case multistandardfetcher.ERC1155Result:
+		if r.Err != nil {
+			chain.ResultErrs = append(chain.ResultErrs, r.Err)
+			return
+		}
+		if chain.ERC1155[r.Account] == nil {
+			chain.ERC1155[r.Account] = make(map[multistandardfetcher.HashableCollectibleID]*big.Int)
+		}
+		for id, balance := range r.Results {
+			chain.ERC1155[r.Account][id] = balance
+		}
+		chain.updateBlockNumber(r.AtBlockNumber)
+	}
+}
+
+// Returns the IDs of the chains that failed entirely or had failed results, sorted.
+func (s *Summary) FailedChains() []uint64 {
+	var ret []uint64
+	for chainID, chain := range s.Chains {
+		if chain.Err != nil || len(chain.ResultErrs) > 0 {
+			ret = append(ret, chainID)
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+func (s *Summary) chain(chainID uint64) *ChainSummary {
+	chain, ok := s.Chains[chainID]
+	if !ok {
+		chain = &ChainSummary{
+			ChainID: chainID,
+			Native:  make(map[common.Address]*big.Int),
+			ERC20:   make(map[common.Address]map[common.Address]*big.Int),
+			ERC721:  make(map[common.Address]map[common.Address]*big.Int),
+			ERC1155: make(map[common.Address]map[multistandardfetcher.HashableCollectibleID]*big.Int),
+		}
+		s.Chains[chainID] = chain
+	}
+	return chain
+}
+
+func (c *ChainSummary) updateBlockNumber(blockNumber *big.Int) {
+	if blockNumber != nil && (c.AtBlockNumber == nil || blockNumber.Cmp(c.AtBlockNumber) > 0) {
+		c.AtBlockNumber = blockNumber
+	}
+}
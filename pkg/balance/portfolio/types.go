@@ -0,0 +1,64 @@
+package portfolio
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+const DefaultBatchSize = 100
+
+type ChainConfig struct {
+	// Chains without Multicall3 deployment require a client also implementing
+	// multistandardfetcher.RPCClient (e.g. backed by a go-ethereum rpc.Client),
+	// their balances are then read with batched RPC calls
+	Client      bind.ContractCaller
+	FetchConfig multistandardfetcher.FetchConfig
+}
+
+type Options struct {
+	// Maximum duration of the fetch for a single chain, unlimited if zero
+	ChainTimeout time.Duration
+	// Multicall3 batch size, defaults to DefaultBatchSize
+	BatchSize int
+}
+
+// TokenSource provides the tokens to fetch for each chain, implemented by tokens/manager.Manager.
+type TokenSource interface {
+	GetTokensByChain(chainID uint64) []*types.Token
+}
+
+// ChainFetchResult is a multistandardfetcher.FetchResult tagged with its chain ID.
+// Err is set instead of Result when the whole chain failed (e.g. no Multicall3
+// deployment and a client without batch calls), in which case it is the only
+// result sent for the chain.
+type ChainFetchResult struct {
+	ChainID uint64
+	multistandardfetcher.FetchResult
+	Err error
+}
+
+type ChainSummary struct {
+	ChainID uint64
+	// Chain level error
+	Err error
+	// Errors of the individual results
+	ResultErrs []error
+
+	Native  map[common.Address]*big.Int
+	ERC20   map[common.Address]map[common.Address]*big.Int
+	ERC721  map[common.Address]map[common.Address]*big.Int
+	ERC1155 map[common.Address]map[multistandardfetcher.HashableCollectibleID]*big.Int
+	// Highest block the balances of the chain were read at
+	AtBlockNumber *big.Int
+}
+
+// Summary consolidates the results of all chains.
+type Summary struct {
+	Chains map[uint64]*ChainSummary
+}
@@ -0,0 +1,80 @@
+package watcher
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+)
+
+const (
+	DefaultPollInterval  = 12 * time.Second
+	DefaultMaxReorgDepth = 64
+	DefaultMaxBlockRange = 1000
+	DefaultBatchSize     = 100
+)
+
+var (
+	ErrAccountsNotProvided = errors.New("at least one account is required")
+	ErrInvalidPollInterval = errors.New("poll interval must be positive")
+)
+
+type Config struct {
+	// Accounts whose balances are watched
+	Accounts []common.Address
+	// Token standards followed through Transfer logs, all of them if empty
+	TransferTypes []eventfilter.TransferType
+	// Optional restriction of the token contracts followed, all of them if empty
+	ContractAddresses []common.Address
+	// Re-read the native balance of every account at each new head.
+	// Native transfers and fees don't emit logs, so they can't be detected otherwise.
+	WatchNative bool
+	// Balances read when the watcher starts, no events are emitted for them.
+	// Changes of balances not read initially are reported with a nil Previous value.
+	Initial multistandardfetcher.FetchConfig
+
+	// Defaults to DefaultPollInterval
+	PollInterval time.Duration
+	// Number of processed heads kept to roll back reorgs, defaults to DefaultMaxReorgDepth
+	MaxReorgDepth int
+	// Maximum number of blocks processed per poll, defaults to DefaultMaxBlockRange
+	MaxBlockRange uint64
+	// Multicall3 batch size, defaults to DefaultBatchSize
+	BatchSize int
+}
+
+func (c *Config) Validate() error {
+	if len(c.Accounts) == 0 {
+		return ErrAccountsNotProvided
+	}
+	if c.PollInterval < 0 {
+		return ErrInvalidPollInterval
+	}
+	return nil
+}
+
+func (c Config) withDefaults() Config {
+	if c.PollInterval == 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.MaxReorgDepth <= 0 {
+		c.MaxReorgDepth = DefaultMaxReorgDepth
+	}
+	if c.MaxBlockRange == 0 {
+		c.MaxBlockRange = DefaultMaxBlockRange
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultBatchSize
+	}
+	if len(c.TransferTypes) == 0 {
+		c.TransferTypes = []eventfilter.TransferType{
+			eventfilter.TransferTypeERC20,
+			eventfilter.TransferTypeERC721,
+			eventfilter.TransferTypeERC1155,
+		}
+	}
+	return c
+}
@@ -0,0 +1,10 @@
+// Package watcher follows new heads of a chain and reports balance changes of a
+// set of accounts as they happen.
+//
+// Instead of re-reading every balance on every block, Transfer logs touching the
+// watched accounts (ERC20 Transfer, ERC721 Transfer, ERC1155 TransferSingle and
+// TransferBatch) are queried through pkg/eventfilter for the new blocks, and only
+// the affected (account, token) pairs are re-read through Multicall3.
+// Changes are emitted as typed events on a channel, and rolled back with
+// reverting events when the blocks they were observed in are reorged out.
+package watcher
@@ -0,0 +1,139 @@
+package watcher
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+func nativeKey(account common.Address) BalanceKey {
+	return BalanceKey{
+		Standard: multistandardfetcher.ResultTypeNative,
+		Account:  account,
+	}
+}
+
+func tokenKey(standard multistandardfetcher.ResultType, account common.Address, contract common.Address) BalanceKey {
+	return BalanceKey{
+		Standard: standard,
+		Account:  account,
+		Contract: contract,
+	}
+}
+
+func erc1155Key(account common.Address, contract common.Address, tokenID *big.Int) BalanceKey {
+	ret := tokenKey(multistandardfetcher.ResultTypeERC1155, account, contract)
+	tokenID.FillBytes(ret.TokenID[:])
+	return ret
+}
+
+func compareKeys(a BalanceKey, b BalanceKey) int {
+	if c := a.Account.Cmp(b.Account); c != 0 {
+		return c
+	}
+	if c := strings.Compare(string(a.Standard), string(b.Standard)); c != 0 {
+		return c
+	}
+	if c := a.Contract.Cmp(b.Contract); c != 0 {
+		return c
+	}
+	return bytes.Compare(a.TokenID[:], b.TokenID[:])
+}
+
+// Returns the balances of the watched accounts affected by the transfer events.
+func changedKeys(events []eventlog.Event, accounts map[common.Address]bool) map[BalanceKey]bool {
+	ret := make(map[BalanceKey]bool)
+	add := func(key BalanceKey) {
+		if accounts[key.Account] {
+			ret[key] = true
+		}
+	}
+
+	for _, event := range events {
+		switch unpacked := event.Unpacked.(type) {
+		case erc20.Erc20Transfer:
+			for _, account := range []common.Address{unpacked.From, unpacked.To} {
+				add(tokenKey(multistandardfetcher.ResultTypeERC20, account, unpacked.Raw.Address))
+			}
+		case erc721.Erc721Transfer:
+			for _, account := range []common.Address{unpacked.From, unpacked.To} {
+				add(tokenKey(multistandardfetcher.ResultTypeERC721, account, unpacked.Raw.Address))
+			}
+		case erc1155.Erc1155TransferSingle:
+			for _, account := range []common.Address{unpacked.From, unpacked.To} {
+				add(erc1155Key(account, unpacked.Raw.Address, unpacked.Id))
+			}
+		case erc1155.Erc1155TransferBatch:
+			for _, account := range []common.Address{unpacked.From, unpacked.To} {
+				for _, id := range unpacked.Ids {
+					add(erc1155Key(account, unpacked.Raw.Address, id))
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// Builds the multistandardfetcher config reading the given balances.
+func fetchConfigForKeys(keys map[BalanceKey]bool) multistandardfetcher.FetchConfig {
+	config := multistandardfetcher.FetchConfig{
+		ERC20:   make(map[multistandardfetcher.AccountAddress][]multistandardfetcher.ContractAddress),
+		ERC721:  make(map[multistandardfetcher.AccountAddress][]multistandardfetcher.ContractAddress),
+		ERC1155: make(map[multistandardfetcher.AccountAddress][]multistandardfetcher.CollectibleID),
+	}
+	for key := range keys {
+		switch key.Standard {
+		case multistandardfetcher.ResultTypeNative:
+			config.Native = append(config.Native, key.Account)
+		case multistandardfetcher.ResultTypeERC20:
+			config.ERC20[key.Account] = append(config.ERC20[key.Account], key.Contract)
+		case multistandardfetcher.ResultTypeERC721:
+			config.ERC721[key.Account] = append(config.ERC721[key.Account], key.Contract)
+		case multistandardfetcher.ResultTypeERC1155:
+			id := multistandardfetcher.HashableCollectibleID{ContractAddress: key.Contract, TokenID: key.TokenID}
+			config.ERC1155[key.Account] = append(config.ERC1155[key.Account], id.ToCollectibleID())
+		}
+	}
+	return config
+}
+
+// Flattens the fetch results into balances per key.
+// Returns the first error reported by any of the results.
+func balancesFromResults(results []multistandardfetcher.FetchResult) (map[BalanceKey]*big.Int, error) {
+	ret := make(map[BalanceKey]*big.Int)
+	for _, fetchResult := range results {
+		switch result := fetchResult.Result.(type) {
+		case multistandardfetcher.NativeResult:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			ret[nativeKey(result.Account)] = result.Result
+		// ERC20Result and ERC721Result are the same type
+		case multistandardfetcher.ERC20Result:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			for contract, balance := range result.Results {
+				ret[tokenKey(fetchResult.ResultType, result.Account, contract)] = balance
+			}
+		case multistandardfetcher.ERC1155Result:
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			for id, balance := range result.Results {
+				key := tokenKey(multistandardfetcher.ResultTypeERC1155, result.Account, id.ContractAddress)
+				key.TokenID = id.TokenID
+				ret[key] = balance
+			}
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,68 @@
+package watcher
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+)
+
+type EventType string
+
+const (
+	// A balance changed in a new block
+	EventTypeBalanceChanged EventType = "balance_changed"
+	// A previously reported change was reorged out, Current is the restored balance
+	EventTypeBalanceReverted EventType = "balance_reverted"
+	// A poll failed, the blocks will be processed again at the next poll
+	EventTypeError EventType = "error"
+)
+
+// BalanceKey identifies a watched balance.
+type BalanceKey struct {
+	Standard multistandardfetcher.ResultType
+	Account  common.Address
+	// Zero for native balances
+	Contract common.Address
+	// Set for ERC1155 balances only
+	TokenID multistandardfetcher.HashableTokenID
+}
+
+type Event struct {
+	Type EventType
+	Key  BalanceKey
+	// Balance before the change, nil if it was not known
+	Previous *big.Int
+	Current  *big.Int
+	// Head at which the change was observed (or reverted)
+	BlockNumber uint64
+	BlockHash   common.Hash
+	// Set for EventTypeError
+	Err error
+}
+
+// Returns Current - Previous, unknown previous balances count as zero.
+func (e Event) Delta() *big.Int {
+	ret := new(big.Int)
+	if e.Current != nil {
+		ret.Set(e.Current)
+	}
+	if e.Previous != nil {
+		ret.Sub(ret, e.Previous)
+	}
+	return ret
+}
+
+type balanceChange struct {
+	key      BalanceKey
+	previous *big.Int
+	current  *big.Int
+}
+
+// Head processed by the watcher, with the changes observed up to it
+type checkpoint struct {
+	number  uint64
+	hash    common.Hash
+	changes []balanceChange
+}
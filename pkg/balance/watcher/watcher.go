@@ -0,0 +1,355 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+var (
+	ErrClientNotProvided = errors.New("client not provided")
+	ErrCallerNotProvided = errors.New("multicall caller not provided")
+	// Reported when a reorg is deeper than the checkpoints kept, all known balances are re-read
+	ErrReorgTooDeep = errors.New("reorg deeper than the tracked checkpoints")
+)
+
+type Client interface {
+	eventfilter.FilterClient
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Watcher follows new heads and emits balance change events (thread-safe for concurrent access).
+type Watcher struct {
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	eventCh chan Event
+	wg      sync.WaitGroup
+
+	client            Client
+	caller            multicall.Caller
+	multicall3Address common.Address
+	config            Config
+	accounts          map[common.Address]bool
+
+	// Only accessed from the run goroutine
+	balances    map[BalanceKey]*big.Int
+	checkpoints []checkpoint // Oldest first
+}
+
+// New creates a watcher reading balances through the Multicall3 contract at multicall3Address.
+func New(client Client, caller multicall.Caller, multicall3Address common.Address, config Config) (*Watcher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrClientNotProvided
+	}
+	if caller == nil {
+		return nil, ErrCallerNotProvided
+	}
+
+	accounts := make(map[common.Address]bool, len(config.Accounts))
+	for _, account := range config.Accounts {
+		accounts[account] = true
+	}
+
+	return &Watcher{
+		client:            client,
+		caller:            caller,
+		multicall3Address: multicall3Address,
+		config:            config.withDefaults(),
+		accounts:          accounts,
+	}, nil
+}
+
+// Start starts following new heads in the background.
+// Events are sent on the returned channel, which is closed when the watcher is stopped.
+func (w *Watcher) Start(ctx context.Context) <-chan Event {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel != nil {
+		return w.eventCh
+	}
+
+	w.eventCh = make(chan Event)
+	w.balances = make(map[BalanceKey]*big.Int)
+	w.checkpoints = nil
+
+	childCtx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+
+	w.wg.Add(1)
+	go w.run(childCtx, w.eventCh)
+
+	return w.eventCh
+}
+
+// Stop stops the watcher and waits for the background goroutine to finish.
+func (w *Watcher) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.cancel == nil {
+		return
+	}
+
+	w.cancel()
+	w.wg.Wait()
+	w.cancel = nil
+}
+
+func (w *Watcher) run(ctx context.Context, eventCh chan Event) {
+	defer w.wg.Done()
+	defer close(eventCh)
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.poll(ctx, eventCh); err != nil && ctx.Err() == nil {
+			w.emit(ctx, eventCh, Event{Type: EventTypeError, Err: err})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *Watcher) emit(ctx context.Context, eventCh chan Event, event Event) {
+	select {
+	case eventCh <- event:
+	case <-ctx.Done():
+	}
+}
+
+// Processes the blocks mined since the last poll.
+func (w *Watcher) poll(ctx context.Context, eventCh chan Event) error {
+	head, err := w.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(w.checkpoints) == 0 {
+		return w.initialize(ctx, head)
+	}
+
+	last := w.checkpoints[len(w.checkpoints)-1]
+	if head.Hash() == last.hash {
+		return nil
+	}
+	// Lagging node (e.g. behind a load balancer), shorter reorgs are detected
+	// once the head moves past the last processed block
+	if head.Number.Uint64() < last.number {
+		return nil
+	}
+
+	// Fast path: head directly extends the last processed block
+	extendsLast := head.Number.Uint64() == last.number+1 && head.ParentHash == last.hash
+	if !extendsLast {
+		reorged, err := w.handleReorg(ctx, eventCh, head)
+		if err != nil || reorged {
+			return err
+		}
+		last = w.checkpoints[len(w.checkpoints)-1]
+	}
+
+	fromBlock := last.number + 1
+	toBlock := min(head.Number.Uint64(), fromBlock+w.config.MaxBlockRange-1)
+	if fromBlock > toBlock {
+		return nil
+	}
+	to := head
+	if toBlock != head.Number.Uint64() {
+		to, err = w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(toBlock))
+		if err != nil {
+			return err
+		}
+	}
+
+	events, err := eventfilter.FilterTransfers(ctx, w.client, eventfilter.TransferQueryConfig{
+		FromBlock:         new(big.Int).SetUint64(fromBlock),
+		ToBlock:           new(big.Int).SetUint64(toBlock),
+		ContractAddresses: w.config.ContractAddresses,
+		Accounts:          w.config.Accounts,
+		TransferTypes:     w.config.TransferTypes,
+		Direction:         eventfilter.Both,
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := changedKeys(events, w.accounts)
+	if w.config.WatchNative {
+		for _, account := range w.config.Accounts {
+			keys[nativeKey(account)] = true
+		}
+	}
+
+	changes, err := w.refresh(ctx, keys, to)
+	if err != nil {
+		return err
+	}
+
+	w.pushCheckpoint(checkpoint{
+		number:  toBlock,
+		hash:    to.Hash(),
+		changes: changes,
+	})
+	for _, change := range changes {
+		w.emit(ctx, eventCh, Event{
+			Type:        EventTypeBalanceChanged,
+			Key:         change.key,
+			Previous:    change.previous,
+			Current:     change.current,
+			BlockNumber: toBlock,
+			BlockHash:   to.Hash(),
+		})
+	}
+	return nil
+}
+
+// Reads the initial balances and sets the first checkpoint at the current head.
+func (w *Watcher) initialize(ctx context.Context, head *types.Header) error {
+	config := w.config.Initial
+	if w.config.WatchNative {
+		config.Native = append(append([]common.Address{}, config.Native...), w.config.Accounts...)
+	}
+
+	balances, err := w.fetch(ctx, config, head)
+	if err != nil {
+		return err
+	}
+	w.balances = balances
+	w.pushCheckpoint(checkpoint{
+		number: head.Number.Uint64(),
+		hash:   head.Hash(),
+	})
+	return nil
+}
+
+// Rolls back the checkpoints that are no longer part of the chain.
+// If no checkpoint is left, every known balance is re-read at the new head and
+// reorged is true, since the new head has been processed already.
+func (w *Watcher) handleReorg(ctx context.Context, eventCh chan Event, head *types.Header) (reorged bool, err error) {
+	for len(w.checkpoints) > 0 {
+		last := w.checkpoints[len(w.checkpoints)-1]
+		header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(last.number))
+		if err != nil {
+			return false, err
+		}
+		if header.Hash() == last.hash {
+			return false, nil
+		}
+
+		// Undo the changes in reverse order, restoring the previous balances
+		w.checkpoints = w.checkpoints[:len(w.checkpoints)-1]
+		for i := len(last.changes) - 1; i >= 0; i-- {
+			change := last.changes[i]
+			if change.previous == nil {
+				delete(w.balances, change.key)
+			} else {
+				w.balances[change.key] = change.previous
+			}
+			w.emit(ctx, eventCh, Event{
+				Type:        EventTypeBalanceReverted,
+				Key:         change.key,
+				Previous:    change.current,
+				Current:     change.previous,
+				BlockNumber: last.number,
+				BlockHash:   last.hash,
+			})
+		}
+	}
+
+	w.emit(ctx, eventCh, Event{Type: EventTypeError, Err: ErrReorgTooDeep})
+
+	keys := make(map[BalanceKey]bool, len(w.balances))
+	for key := range w.balances {
+		keys[key] = true
+	}
+	changes, err := w.refresh(ctx, keys, head)
+	if err != nil {
+		return false, err
+	}
+	w.pushCheckpoint(checkpoint{
+		number:  head.Number.Uint64(),
+		hash:    head.Hash(),
+		changes: changes,
+	})
+	for _, change := range changes {
+		w.emit(ctx, eventCh, Event{
+			Type:        EventTypeBalanceChanged,
+			Key:         change.key,
+			Previous:    change.previous,
+			Current:     change.current,
+			BlockNumber: head.Number.Uint64(),
+			BlockHash:   head.Hash(),
+		})
+	}
+	return true, nil
+}
+
+// Re-reads the given balances at block and applies the ones that changed.
+func (w *Watcher) refresh(ctx context.Context, keys map[BalanceKey]bool, block *types.Header) ([]balanceChange, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	balances, err := w.fetch(ctx, fetchConfigForKeys(keys), block)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []balanceChange
+	for key, current := range balances {
+		previous, known := w.balances[key]
+		if known && previous.Cmp(current) == 0 {
+			continue
+		}
+		changes = append(changes, balanceChange{
+			key:      key,
+			previous: previous,
+			current:  current,
+		})
+		w.balances[key] = current
+	}
+	slices.SortFunc(changes, func(a, b balanceChange) int {
+		return compareKeys(a.key, b.key)
+	})
+	return changes, nil
+}
+
+// Reads balances at the block by hash, so that they match the block the
+// checkpoint and events are stamped with even if the head has moved since.
+func (w *Watcher) fetch(ctx context.Context, config multistandardfetcher.FetchConfig, block *types.Header) (map[BalanceKey]*big.Int, error) {
+	atBlock := gethrpc.BlockNumberOrHashWithHash(block.Hash(), false)
+	resultsCh := multistandardfetcher.FetchBalances(ctx, w.multicall3Address, w.caller, config, atBlock, w.config.BatchSize)
+
+	results := make([]multistandardfetcher.FetchResult, 0)
+	for result := range resultsCh {
+		results = append(results, result)
+	}
+	return balancesFromResults(results)
+}
+
+func (w *Watcher) pushCheckpoint(cp checkpoint) {
+	w.checkpoints = append(w.checkpoints, cp)
+	if len(w.checkpoints) > w.config.MaxReorgDepth {
+		w.checkpoints = w.checkpoints[len(w.checkpoints)-w.config.MaxReorgDepth:]
+	}
+}
@@ -0,0 +1,364 @@
+package watcher
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+var (
+	alice = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bob   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	usdc     = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	opensea  = common.HexToAddress("0x495f947276749Ce646f68AC8c248420045cb7b5e")
+	mc3Addr  = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	tokenID7 = big.NewInt(7)
+)
+
+// Fake chain serving headers and logs
+type fakeChain struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	logs    map[uint64][]types.Log
+}
+
+func newFakeChain(length int) *fakeChain {
+	c := &fakeChain{logs: make(map[uint64][]types.Log)}
+	c.extend(length, 0)
+	return c
+}
+
+// Appends blocks, fork differentiates the hashes of competing chains
+func (c *fakeChain) extend(count int, fork byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := 0; i < count; i++ {
+		header := &types.Header{
+			Number: big.NewInt(int64(len(c.headers))),
+			Extra:  []byte{fork},
+		}
+		if len(c.headers) > 0 {
+			header.ParentHash = c.headers[len(c.headers)-1].Hash()
+		}
+		c.headers = append(c.headers, header)
+	}
+}
+
+// Drops the blocks from number onwards
+func (c *fakeChain) rewind(number uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.headers = c.headers[:number]
+	for n := range c.logs {
+		if n >= number {
+			delete(c.logs, n)
+		}
+	}
+}
+
+func (c *fakeChain) addLog(log types.Log) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logs[log.BlockNumber] = append(c.logs[log.BlockNumber], log)
+}
+
+func (c *fakeChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if number == nil {
+		return c.headers[len(c.headers)-1], nil
+	}
+	if number.Uint64() >= uint64(len(c.headers)) {
+		return nil, ethereum.NotFound
+	}
+	return c.headers[number.Uint64()], nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ret []types.Log
+	for n := query.FromBlock.Uint64(); n <= query.ToBlock.Uint64(); n++ {
+		for _, log := range c.logs[n] {
+			if matchesTopics(log, query.Topics) {
+				ret = append(ret, log)
+			}
+		}
+	}
+	return ret, nil
+}
+
+func matchesTopics(log types.Log, topics [][]common.Hash) bool {
+	for i, options := range topics {
+		if len(options) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) || !slices.Contains(options, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Fake Multicall3 returning the configured value for each call
+type fakeCaller struct {
+	mu      sync.Mutex
+	results map[string]*big.Int
+	// Block hash of each aggregate call
+	blockHashes []common.Hash
+}
+
+func callKey(call multicall3.IMulticall3Call) string {
+	return call.Target.Hex() + hexutil.Encode(call.CallData)
+}
+
+func (c *fakeCaller) set(call multicall3.IMulticall3Call, value int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.results[callKey(call)] = big.NewInt(value)
+}
+
+func (c *fakeCaller) ViewTryAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) ([]multicall3.IMulticall3Result, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blockHashes = append(c.blockHashes, opts.BlockHash)
+	results := make([]multicall3.IMulticall3Result, 0, len(calls))
+	for _, call := range calls {
+		value, ok := c.results[callKey(call)]
+		if !ok {
+			value = big.NewInt(0)
+		}
+		results = append(results, multicall3.IMulticall3Result{Success: true, ReturnData: common.LeftPadBytes(value.Bytes(), 32)})
+	}
+	return results, nil
+}
+
+func (c *fakeCaller) ViewTryBlockAndAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+	results, err := c.ViewTryAggregate(opts, requireSuccess, calls)
+	return big.NewInt(0), [32]byte{}, results, err
+}
+
+func erc20TransferLog(block uint64, from, to common.Address, value int64) types.Log {
+	return types.Log{
+		Address:     usdc,
+		Topics:      []common.Hash{eventlog.ERC20TransferID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:        common.LeftPadBytes(big.NewInt(value).Bytes(), 32),
+		BlockNumber: block,
+	}
+}
+
+func erc1155TransferSingleLog(block uint64, from, to common.Address, id *big.Int, value int64) types.Log {
+	data := append(common.LeftPadBytes(id.Bytes(), 32), common.LeftPadBytes(big.NewInt(value).Bytes(), 32)...)
+	return types.Log{
+		Address:     opensea,
+		Topics:      []common.Hash{eventlog.ERC1155TransferSingleID, common.BytesToHash(from.Bytes()), common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:        data,
+		BlockNumber: block,
+	}
+}
+
+func drain(eventCh chan Event) []Event {
+	var events []Event
+	for {
+		select {
+		case event := <-eventCh:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestWatcher_DeltasAndReorg(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeChain(11)
+	caller := &fakeCaller{results: make(map[string]*big.Int)}
+
+	caller.set(multicall.BuildNativeBalanceCall(alice, mc3Addr), 5)
+	caller.set(multicall.BuildERC20BalanceCall(alice, usdc), 100)
+
+	w, err := New(chain, caller, mc3Addr, Config{
+		Accounts:    []common.Address{alice},
+		WatchNative: true,
+		Initial: multistandardfetcher.FetchConfig{
+			ERC20: map[common.Address][]common.Address{alice: {usdc}},
+		},
+	})
+	require.NoError(t, err)
+	w.balances = make(map[BalanceKey]*big.Int)
+	eventCh := make(chan Event, 100)
+
+	// Initial read, no events
+	require.NoError(t, w.poll(ctx, eventCh))
+	assert.Empty(t, drain(eventCh))
+	assert.Equal(t, big.NewInt(100), w.balances[tokenKey(multistandardfetcher.ResultTypeERC20, alice, usdc)])
+
+	// ERC20 received in block 11
+	chain.extend(1, 0)
+	chain.addLog(erc20TransferLog(11, bob, alice, 50))
+	caller.set(multicall.BuildERC20BalanceCall(alice, usdc), 150)
+
+	require.NoError(t, w.poll(ctx, eventCh))
+	events := drain(eventCh)
+	require.Len(t, events, 1)
+	assert.Equal(t, EventTypeBalanceChanged, events[0].Type)
+	assert.Equal(t, tokenKey(multistandardfetcher.ResultTypeERC20, alice, usdc), events[0].Key)
+	assert.Equal(t, big.NewInt(100), events[0].Previous)
+	assert.Equal(t, big.NewInt(150), events[0].Current)
+	assert.Equal(t, big.NewInt(50), events[0].Delta())
+	assert.Equal(t, uint64(11), events[0].BlockNumber)
+
+	// ERC1155 received and gas spent in block 12
+	chain.extend(1, 0)
+	chain.addLog(erc1155TransferSingleLog(12, bob, alice, tokenID7, 1))
+	caller.set(multicall.BuildERC1155BalanceCall(alice, opensea, tokenID7), 1)
+	caller.set(multicall.BuildNativeBalanceCall(alice, mc3Addr), 4)
+
+	require.NoError(t, w.poll(ctx, eventCh))
+	events = drain(eventCh)
+	require.Len(t, events, 2)
+	assert.Equal(t, erc1155Key(alice, opensea, tokenID7), events[0].Key)
+	assert.Nil(t, events[0].Previous)
+	assert.Equal(t, big.NewInt(1), events[0].Current)
+	assert.Equal(t, multistandardfetcher.ResultTypeNative, events[1].Key.Standard)
+	assert.Equal(t, big.NewInt(-1), events[1].Delta())
+
+	// Block 12 is reorged out, the competing chain has no transfer and the fee was not spent
+	chain.rewind(12)
+	chain.extend(2, 1)
+	caller.set(multicall.BuildERC1155BalanceCall(alice, opensea, tokenID7), 0)
+	caller.set(multicall.BuildNativeBalanceCall(alice, mc3Addr), 5)
+
+	require.NoError(t, w.poll(ctx, eventCh))
+	events = drain(eventCh)
+	require.Len(t, events, 2)
+
+	// Changes of block 12 are reverted in reverse order
+	assert.Equal(t, EventTypeBalanceReverted, events[0].Type)
+	assert.Equal(t, multistandardfetcher.ResultTypeNative, events[0].Key.Standard)
+	assert.Equal(t, big.NewInt(5), events[0].Current)
+	assert.Equal(t, uint64(12), events[0].BlockNumber)
+	assert.Equal(t, EventTypeBalanceReverted, events[1].Type)
+	assert.Equal(t, erc1155Key(alice, opensea, tokenID7), events[1].Key)
+	assert.Nil(t, events[1].Current)
+
+	// Then the new blocks are processed, native is re-read but unchanged
+	_, known := w.balances[erc1155Key(alice, opensea, tokenID7)]
+	assert.False(t, known)
+	assert.Equal(t, big.NewInt(150), w.balances[tokenKey(multistandardfetcher.ResultTypeERC20, alice, usdc)])
+
+	last := w.checkpoints[len(w.checkpoints)-1]
+	assert.Equal(t, uint64(13), last.number)
+}
+
+func TestWatcher_ReorgTooDeep(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeChain(11)
+	caller := &fakeCaller{results: make(map[string]*big.Int)}
+	caller.set(multicall.BuildERC20BalanceCall(alice, usdc), 100)
+
+	w, err := New(chain, caller, mc3Addr, Config{
+		Accounts:      []common.Address{alice},
+		MaxReorgDepth: 2,
+		Initial: multistandardfetcher.FetchConfig{
+			ERC20: map[common.Address][]common.Address{alice: {usdc}},
+		},
+	})
+	require.NoError(t, err)
+	w.balances = make(map[BalanceKey]*big.Int)
+	eventCh := make(chan Event, 100)
+
+	require.NoError(t, w.poll(ctx, eventCh))
+	for i := 0; i < 3; i++ {
+		chain.extend(1, 0)
+		require.NoError(t, w.poll(ctx, eventCh))
+	}
+	assert.Empty(t, drain(eventCh))
+
+	// Whole tracked history replaced
+	chain.rewind(5)
+	chain.extend(10, 1)
+	caller.set(multicall.BuildERC20BalanceCall(alice, usdc), 70)
+
+	require.NoError(t, w.poll(ctx, eventCh))
+	events := drain(eventCh)
+	require.Len(t, events, 2)
+	assert.Equal(t, EventTypeError, events[0].Type)
+	assert.ErrorIs(t, events[0].Err, ErrReorgTooDeep)
+	assert.Equal(t, EventTypeBalanceChanged, events[1].Type)
+	assert.Equal(t, big.NewInt(-30), events[1].Delta())
+	assert.Equal(t, uint64(14), events[1].BlockNumber)
+}
+
+func TestWatcher_ReadsAtProcessedBlock(t *testing.T) {
+	ctx := context.Background()
+	chain := newFakeChain(11)
+	caller := &fakeCaller{results: make(map[string]*big.Int)}
+
+	w, err := New(chain, caller, mc3Addr, Config{
+		Accounts:      []common.Address{alice},
+		WatchNative:   true,
+		MaxBlockRange: 2,
+	})
+	require.NoError(t, err)
+	w.balances = make(map[BalanceKey]*big.Int)
+	eventCh := make(chan Event, 100)
+
+	require.NoError(t, w.poll(ctx, eventCh))
+	head, err := chain.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []common.Hash{head.Hash()}, caller.blockHashes)
+
+	// The poll is capped to blocks 11 and 12, balances are read at block 12
+	chain.extend(5, 0)
+	caller.set(multicall.BuildNativeBalanceCall(alice, mc3Addr), 3)
+	require.NoError(t, w.poll(ctx, eventCh))
+
+	block12, err := chain.HeaderByNumber(ctx, big.NewInt(12))
+	require.NoError(t, err)
+	assert.Equal(t, block12.Hash(), caller.blockHashes[len(caller.blockHashes)-1])
+
+	events := drain(eventCh)
+	require.Len(t, events, 1)
+	assert.Equal(t, uint64(12), events[0].BlockNumber)
+	assert.Equal(t, block12.Hash(), events[0].BlockHash)
+}
+
+func TestWatcher_StartStop(t *testing.T) {
+	chain := newFakeChain(11)
+	caller := &fakeCaller{results: make(map[string]*big.Int)}
+
+	w, err := New(chain, caller, mc3Addr, Config{Accounts: []common.Address{alice}})
+	require.NoError(t, err)
+
+	eventCh := w.Start(context.Background())
+	assert.Equal(t, eventCh, w.Start(context.Background()))
+	w.Stop()
+
+	_, ok := <-eventCh
+	assert.False(t, ok)
+}
+
+func TestNew_Validation(t *testing.T) {
+	_, err := New(newFakeChain(1), &fakeCaller{}, mc3Addr, Config{})
+	assert.ErrorIs(t, err, ErrAccountsNotProvided)
+
+	_, err = New(nil, &fakeCaller{}, mc3Addr, Config{Accounts: []common.Address{alice}})
+	assert.ErrorIs(t, err, ErrClientNotProvided)
+}
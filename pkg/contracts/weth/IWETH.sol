@@ -0,0 +1,12 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.0;
+
+// Wrapped native token (WETH9 and its forks on other chains), on top of ERC20.
+interface IWETH {
+  event Deposit(address indexed dst, uint256 wad);
+  event Withdrawal(address indexed src, uint256 wad);
+
+  function deposit() external payable;
+
+  function withdraw(uint256 wad) external;
+}
@@ -0,0 +1,21 @@
+package weth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	walletcommon "github.com/status-im/go-wallet-sdk/pkg/common"
+)
+
+// WrappedNativeAddresses maps chain ID to the wrapped native token contract
+// (WETH, or WBNB on BSC).
+var WrappedNativeAddresses = map[walletcommon.ChainID]common.Address{
+	walletcommon.EthereumMainnet: common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+	walletcommon.EthereumSepolia: common.HexToAddress("0xfFf9976782d46CC05630D1f6eBAb18b2324d6B14"),
+	walletcommon.OptimismMainnet: common.HexToAddress("0x4200000000000000000000000000000000000006"),
+	walletcommon.OptimismSepolia: common.HexToAddress("0x4200000000000000000000000000000000000006"),
+	walletcommon.ArbitrumMainnet: common.HexToAddress("0x82aF49447D8a07e3bd95BD0d56f35241523fBab1"),
+	walletcommon.ArbitrumSepolia: common.HexToAddress("0x980B62Da83eFf3D4576C647993b0c1D7faf17c73"),
+	walletcommon.BSCMainnet:      common.HexToAddress("0xbb4CdB9CBd36B01bD1cBaEBF2De08d9173bc095c"),
+	walletcommon.BaseMainnet:     common.HexToAddress("0x4200000000000000000000000000000000000006"),
+	walletcommon.BaseSepolia:     common.HexToAddress("0x4200000000000000000000000000000000000006"),
+}
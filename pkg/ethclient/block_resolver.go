@@ -0,0 +1,193 @@
+package ethclient
+
+import (
+	"cmp"
+	"context"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Default maximum number of (number, timestamp) anchors kept by a BlockResolver
+const DefaultMaxBlockAnchors = 4096
+
+type HeaderByNumberClient interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type blockAnchor struct {
+	number    uint64
+	timestamp uint64
+}
+
+// BlockResolver finds the block that was the chain head at a given time.
+//
+// Headers are looked up with an interpolation search, using the average block
+// time between the closest known blocks to guess the next one, and falling back
+// to bisection when the guesses do not converge (irregular block times, L2s
+// with periods of inactivity). Every header fetched is kept as an anchor, so
+// later lookups on the same chain need fewer requests.
+//
+// A BlockResolver must only be used for a single chain. It is safe for concurrent use.
+type BlockResolver struct {
+	client     HeaderByNumberClient
+	maxAnchors int
+
+	mu      sync.Mutex
+	anchors []blockAnchor // Sorted by number
+}
+
+func NewBlockResolver(client HeaderByNumberClient) *BlockResolver {
+	return NewBlockResolverWithMaxAnchors(client, DefaultMaxBlockAnchors)
+}
+
+func NewBlockResolverWithMaxAnchors(client HeaderByNumberClient, maxAnchors int) *BlockResolver {
+	return &BlockResolver{
+		client:     client,
+		maxAnchors: max(maxAnchors, 2),
+	}
+}
+
+// BlockNumberAt returns the number of the last block with a timestamp lower or
+// equal to the given one (unix seconds). Timestamps before the genesis block
+// resolve to block 0, timestamps after the latest block resolve to the latest block.
+func (r *BlockResolver) BlockNumberAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	lo, hi, loFound, hiFound := r.bracket(timestamp)
+
+	if !hiFound {
+		latest, err := r.fetchAnchor(ctx, nil)
+		if err != nil {
+			return 0, err
+		}
+		if latest.timestamp <= timestamp {
+			return latest.number, nil
+		}
+		hi = latest
+	}
+
+	if !loFound {
+		genesis, err := r.fetchAnchor(ctx, big.NewInt(0))
+		if err != nil {
+			return 0, err
+		}
+		if genesis.timestamp > timestamp {
+			return 0, nil
+		}
+		lo = genesis
+	}
+
+	// Invariant: lo.timestamp <= timestamp < hi.timestamp
+	bisect := false
+	for hi.number-lo.number > 1 {
+		var guess uint64
+		if bisect {
+			guess = lo.number + (hi.number-lo.number)/2
+		} else {
+			guess = interpolate(lo, hi, timestamp)
+		}
+
+		anchor, err := r.fetchAnchor(ctx, new(big.Int).SetUint64(guess))
+		if err != nil {
+			return 0, err
+		}
+
+		prevRange := hi.number - lo.number
+		if anchor.timestamp <= timestamp {
+			lo = anchor
+		} else {
+			hi = anchor
+		}
+
+		// Interpolation converges quickly when block times are regular, bisect
+		// whenever it fails to at least halve the range
+		bisect = !bisect && (hi.number-lo.number) > prevRange/2
+	}
+
+	return lo.number, nil
+}
+
+// BlockNumbersAt resolves each of the timestamps with BlockNumberAt.
+func (r *BlockResolver) BlockNumbersAt(ctx context.Context, timestamps []uint64) ([]uint64, error) {
+	ret := make([]uint64, 0, len(timestamps))
+	for _, timestamp := range timestamps {
+		blockNumber, err := r.BlockNumberAt(ctx, timestamp)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, blockNumber)
+	}
+	return ret, nil
+}
+
+// Guesses the block at the timestamp assuming a constant block time between lo and hi.
+// The result is strictly between lo and hi, which must be at least 2 blocks apart.
+func interpolate(lo blockAnchor, hi blockAnchor, timestamp uint64) uint64 {
+	guess := lo.number + 1
+	if hi.timestamp > lo.timestamp {
+		ratio := float64(timestamp-lo.timestamp) / float64(hi.timestamp-lo.timestamp)
+		guess = lo.number + uint64(ratio*float64(hi.number-lo.number))
+	}
+	return min(max(guess, lo.number+1), hi.number-1)
+}
+
+// Returns the closest known anchors around the timestamp.
+func (r *BlockResolver) bracket(timestamp uint64) (lo blockAnchor, hi blockAnchor, loFound bool, hiFound bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Timestamps are non-decreasing with the block number
+	idx, _ := slices.BinarySearchFunc(r.anchors, timestamp, func(anchor blockAnchor, timestamp uint64) int {
+		if anchor.timestamp <= timestamp {
+			return -1
+		}
+		return 1
+	})
+	if idx > 0 {
+		lo, loFound = r.anchors[idx-1], true
+	}
+	if idx < len(r.anchors) {
+		hi, hiFound = r.anchors[idx], true
+	}
+	return
+}
+
+func (r *BlockResolver) fetchAnchor(ctx context.Context, number *big.Int) (blockAnchor, error) {
+	header, err := r.client.HeaderByNumber(ctx, number)
+	if err != nil {
+		return blockAnchor{}, err
+	}
+	anchor := blockAnchor{
+		number:    header.Number.Uint64(),
+		timestamp: header.Time,
+	}
+	r.addAnchor(anchor)
+	return anchor, nil
+}
+
+func (r *BlockResolver) addAnchor(anchor blockAnchor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx, found := slices.BinarySearchFunc(r.anchors, anchor.number, func(anchor blockAnchor, number uint64) int {
+		return cmp.Compare(anchor.number, number)
+	})
+	if found {
+		// Blocks near the head may have been reorged
+		r.anchors[idx] = anchor
+		return
+	}
+	r.anchors = slices.Insert(r.anchors, idx, anchor)
+
+	// Drop every other anchor, keeping the cache evenly spread over the chain
+	if len(r.anchors) > r.maxAnchors {
+		kept := r.anchors[:0]
+		for i, anchor := range r.anchors {
+			if i%2 == 0 || i == len(r.anchors)-1 {
+				kept = append(kept, anchor)
+			}
+		}
+		r.anchors = kept
+	}
+}
@@ -0,0 +1,145 @@
+package ethclient_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+)
+
+type fakeHeaderClient struct {
+	times    []uint64
+	requests int
+	err      error
+}
+
+func (c *fakeHeaderClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.requests++
+	if c.err != nil {
+		return nil, c.err
+	}
+	if number == nil {
+		number = big.NewInt(int64(len(c.times) - 1))
+	}
+	return &types.Header{Number: number, Time: c.times[number.Uint64()]}, nil
+}
+
+// Reference implementation: last block with time <= timestamp
+func expectedBlockAt(times []uint64, timestamp uint64) uint64 {
+	idx := sort.Search(len(times), func(i int) bool { return times[i] > timestamp })
+	if idx == 0 {
+		return 0
+	}
+	return uint64(idx - 1)
+}
+
+func regularChain(blocks int, genesisTime uint64, blockTime uint64) []uint64 {
+	times := make([]uint64, blocks)
+	for i := range times {
+		times[i] = genesisTime + uint64(i)*blockTime
+	}
+	return times
+}
+
+func TestBlockResolver_RegularBlockTimes(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeHeaderClient{times: regularChain(1_000_000, 1_600_000_000, 12)}
+	resolver := ethclient.NewBlockResolver(client)
+
+	blockNumber, err := resolver.BlockNumberAt(ctx, 1_600_000_000+12*654_321+5)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(654_321), blockNumber)
+
+	// Latest, genesis, then a couple of interpolation steps
+	assert.LessOrEqual(t, client.requests, 5)
+}
+
+func TestBlockResolver_IrregularBlockTimes(t *testing.T) {
+	ctx := context.Background()
+
+	// L2-like chain: several blocks per second in bursts, with long idle periods
+	rng := rand.New(rand.NewSource(1))
+	times := make([]uint64, 50_000)
+	times[0] = 1_700_000_000
+	for i := 1; i < len(times); i++ {
+		switch r := rng.Intn(100); {
+		case r < 60:
+			times[i] = times[i-1] // Same second
+		case r < 98:
+			times[i] = times[i-1] + 1
+		default:
+			times[i] = times[i-1] + uint64(rng.Intn(3600))
+		}
+	}
+
+	client := &fakeHeaderClient{times: times}
+	resolver := ethclient.NewBlockResolver(client)
+
+	first, last := times[0], times[len(times)-1]
+	for i := 0; i < 200; i++ {
+		timestamp := first - 10 + uint64(rng.Int63n(int64(last-first+20)))
+		blockNumber, err := resolver.BlockNumberAt(ctx, timestamp)
+		require.NoError(t, err)
+		require.Equal(t, expectedBlockAt(times, timestamp), blockNumber, "timestamp %d", timestamp)
+	}
+
+	// Bisection fallback bounds the number of requests per lookup
+	assert.Less(t, client.requests, 200*40)
+}
+
+func TestBlockResolver_Bounds(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeHeaderClient{times: regularChain(100, 1000, 2)}
+	resolver := ethclient.NewBlockResolver(client)
+
+	blockNumbers, err := resolver.BlockNumbersAt(ctx, []uint64{10, 1000, 1001, 1002, 1197, 1198, 5000})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{0, 0, 0, 1, 98, 99, 99}, blockNumbers)
+}
+
+func TestBlockResolver_UsesCachedAnchors(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeHeaderClient{times: regularChain(1_000_000, 1_600_000_000, 12)}
+	resolver := ethclient.NewBlockResolver(client)
+
+	timestamp := uint64(1_600_000_000 + 12*500_000)
+	_, err := resolver.BlockNumberAt(ctx, timestamp)
+	require.NoError(t, err)
+
+	// Same timestamp is fully resolved from the anchors
+	requests := client.requests
+	blockNumber, err := resolver.BlockNumberAt(ctx, timestamp+1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(500_000), blockNumber)
+	assert.Equal(t, requests, client.requests)
+}
+
+func TestBlockResolver_SmallCache(t *testing.T) {
+	ctx := context.Background()
+	times := regularChain(10_000, 1000, 3)
+	client := &fakeHeaderClient{times: times}
+	resolver := ethclient.NewBlockResolverWithMaxAnchors(client, 4)
+
+	for _, timestamp := range []uint64{1500, 20000, 5000, 29000, 1003} {
+		blockNumber, err := resolver.BlockNumberAt(ctx, timestamp)
+		require.NoError(t, err)
+		assert.Equal(t, expectedBlockAt(times, timestamp), blockNumber)
+	}
+}
+
+func TestBlockResolver_Error(t *testing.T) {
+	expectedErr := errors.New("rpc error")
+	client := &fakeHeaderClient{times: regularChain(10, 1000, 12), err: expectedErr}
+	resolver := ethclient.NewBlockResolver(client)
+
+	_, err := resolver.BlockNumberAt(context.Background(), 1050)
+	assert.ErrorIs(t, err, expectedErr)
+}
@@ -42,6 +42,13 @@ func (c *Client) BalanceAt(ctx context.Context, address common.Address, blockNum
 	return c.gethEthClient.BalanceAt(ctx, address, blockNumber)
 }
 
+func (c *Client) BalanceAtHash(ctx context.Context, address common.Address, blockHash common.Hash) (*big.Int, error) {
+	if c.gethEthClient == nil {
+		return nil, ErrGethEthClientNotSupported
+	}
+	return c.gethEthClient.BalanceAtHash(ctx, address, blockHash)
+}
+
 // Might fail with ErrTxTypeNotSupported for some chains, use EthGetBlockByHashWithFullTxs instead
 func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
 	if c.gethEthClient == nil {
@@ -65,6 +72,13 @@ func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNu
 	return c.gethEthClient.CallContract(ctx, msg, blockNumber)
 }
 
+func (c *Client) CallContractAtHash(ctx context.Context, msg ethereum.CallMsg, blockHash common.Hash) ([]byte, error) {
+	if c.gethEthClient == nil {
+		return nil, ErrGethEthClientNotSupported
+	}
+	return c.gethEthClient.CallContractAtHash(ctx, msg, blockHash)
+}
+
 func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
 	if c.gethEthClient == nil {
 		return 0, ErrGethEthClientNotSupported
@@ -86,6 +100,13 @@ func (c *Client) CodeAt(ctx context.Context, address common.Address, blockNumber
 	return c.gethEthClient.CodeAt(ctx, address, blockNumber)
 }
 
+func (c *Client) CodeAtHash(ctx context.Context, address common.Address, blockHash common.Hash) ([]byte, error) {
+	if c.gethEthClient == nil {
+		return nil, ErrGethEthClientNotSupported
+	}
+	return c.gethEthClient.CodeAtHash(ctx, address, blockHash)
+}
+
 func (c *Client) StorageAt(ctx context.Context, address common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
 	if c.gethEthClient == nil {
 		return nil, ErrGethEthClientNotSupported
@@ -471,6 +471,14 @@ func testCallContract(t *testing.T, client *rpc.Client) {
 	if _, err := ec.PendingCallContract(context.Background(), msg); err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	// CallContractAtHash
+	header, err := ec.HeaderByNumber(context.Background(), big.NewInt(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := ec.CallContractAtHash(context.Background(), msg, header.Hash()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
 }
 
 func testAtFunctions(t *testing.T, client *rpc.Client) {
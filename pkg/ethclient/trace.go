@@ -0,0 +1,64 @@
+package ethclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DebugTraceBlockByNumberWithCallTracer returns the call tree of every transaction of a block,
+// using the geth built-in "callTracer" (debug_traceBlockByNumber)
+func (c *Client) DebugTraceBlockByNumberWithCallTracer(ctx context.Context, number *big.Int) ([]TxTraceResult, error) {
+	var result []TxTraceResult
+	blockArg := toBlockNumArg(number)
+	err := c.rpcClient.CallContext(ctx, &result, "debug_traceBlockByNumber", blockArg, map[string]interface{}{
+		"tracer": "callTracer",
+	})
+	return result, err
+}
+
+// TraceFilterArgs are the arguments of trace_filter. A trace matches if its sender is in
+// FromAddress (when not empty) and its recipient is in ToAddress (when not empty).
+type TraceFilterArgs struct {
+	FromBlock   *big.Int
+	ToBlock     *big.Int
+	FromAddress []common.Address
+	ToAddress   []common.Address
+	// Offset and maximum number of traces returned, for pagination
+	After *uint64
+	Count *uint64
+}
+
+// TraceFilter returns the traces matching the filter, in the Parity/OpenEthereum
+// trace format (trace_filter, supported by Erigon, Nethermind, Reth and Besu)
+func (c *Client) TraceFilter(ctx context.Context, args TraceFilterArgs) ([]Trace, error) {
+	var result []Trace
+	arg := map[string]interface{}{
+		"fromBlock": toBlockNumArg(args.FromBlock),
+		"toBlock":   toBlockNumArg(args.ToBlock),
+	}
+	if len(args.FromAddress) > 0 {
+		arg["fromAddress"] = args.FromAddress
+	}
+	if len(args.ToAddress) > 0 {
+		arg["toAddress"] = args.ToAddress
+	}
+	if args.After != nil {
+		arg["after"] = hexutil.Uint64(*args.After)
+	}
+	if args.Count != nil {
+		arg["count"] = hexutil.Uint64(*args.Count)
+	}
+	err := c.rpcClient.CallContext(ctx, &result, "trace_filter", arg)
+	return result, err
+}
+
+// TraceTransaction returns all traces of a transaction, in the Parity/OpenEthereum
+// trace format (trace_transaction)
+func (c *Client) TraceTransaction(ctx context.Context, txHash common.Hash) ([]Trace, error) {
+	var result []Trace
+	err := c.rpcClient.CallContext(ctx, &result, "trace_transaction", txHash)
+	return result, err
+}
@@ -0,0 +1,176 @@
+package ethclient_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+	mock_ethclient "github.com/status-im/go-wallet-sdk/pkg/ethclient/mock"
+)
+
+func TestDebugTraceBlockByNumberWithCallTracer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRPC := mock_ethclient.NewMockRPCClient(ctrl)
+	client := ethclient.NewClient(mockRPC)
+
+	responseJSON := `[{
+		"txHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+		"result": {
+			"type": "CALL",
+			"from": "0xa7d9ddbe1f17865597fbd27ec712455208b6b76d",
+			"to": "0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb",
+			"value": "0xde0b6b3a7640000",
+			"gas": "0x5208",
+			"gasUsed": "0x5208",
+			"input": "0x",
+			"calls": [{
+				"type": "CALL",
+				"from": "0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb",
+				"to": "0x0000000000000000000000000000000000000003",
+				"value": "0x1",
+				"gas": "0x0",
+				"gasUsed": "0x0",
+				"input": "0x",
+				"error": "execution reverted"
+			}]
+		}
+	}]`
+
+	mockRPC.EXPECT().
+		CallContext(gomock.Any(), gomock.Any(), "debug_traceBlockByNumber", "0x64", map[string]interface{}{"tracer": "callTracer"}).
+		DoAndReturn(func(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+			return json.Unmarshal([]byte(responseJSON), result)
+		})
+
+	result, err := client.DebugTraceBlockByNumberWithCallTracer(context.Background(), big.NewInt(100))
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"), result[0].TxHash)
+
+	frame := result[0].Result
+	require.NotNil(t, frame)
+	assert.Equal(t, "CALL", frame.Type)
+	assert.Equal(t, common.HexToAddress("0xa7d9ddbe1f17865597fbd27ec712455208b6b76d"), frame.From)
+	assert.Equal(t, big.NewInt(1000000000000000000), frame.Value)
+	assert.Equal(t, uint64(21000), frame.GasUsed)
+	require.Len(t, frame.Calls, 1)
+	assert.Equal(t, big.NewInt(1), frame.Calls[0].Value)
+	assert.Equal(t, "execution reverted", frame.Calls[0].Error)
+}
+
+func TestTraceFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRPC := mock_ethclient.NewMockRPCClient(ctrl)
+	client := ethclient.NewClient(mockRPC)
+
+	account := common.HexToAddress("0xa7d9ddbe1f17865597fbd27ec712455208b6b76d")
+	responseJSON := `[{
+		"action": {
+			"callType": "call",
+			"from": "0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb",
+			"to": "0xa7d9ddbe1f17865597fbd27ec712455208b6b76d",
+			"value": "0x2a",
+			"gas": "0x0",
+			"input": "0x"
+		},
+		"blockHash": "0x2222222222222222222222222222222222222222222222222222222222222222",
+		"blockNumber": 100,
+		"result": {"gasUsed": "0x0", "output": "0x"},
+		"subtraces": 0,
+		"traceAddress": [0, 1],
+		"transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+		"transactionPosition": 3,
+		"type": "call"
+	}]`
+
+	count := uint64(100)
+	mockRPC.EXPECT().
+		CallContext(gomock.Any(), gomock.Any(), "trace_filter", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+			require.Len(t, args, 1)
+			arg := args[0].(map[string]interface{})
+			assert.Equal(t, "0x1", arg["fromBlock"])
+			assert.Equal(t, "0x64", arg["toBlock"])
+			assert.Equal(t, []common.Address{account}, arg["toAddress"])
+			assert.NotContains(t, arg, "fromAddress")
+			assert.NotContains(t, arg, "after")
+			assert.Contains(t, arg, "count")
+			return json.Unmarshal([]byte(responseJSON), result)
+		})
+
+	traces, err := client.TraceFilter(context.Background(), ethclient.TraceFilterArgs{
+		FromBlock: big.NewInt(1),
+		ToBlock:   big.NewInt(100),
+		ToAddress: []common.Address{account},
+		Count:     &count,
+	})
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+
+	trace := traces[0]
+	assert.Equal(t, "call", trace.Type)
+	assert.Equal(t, "call", trace.Action.CallType)
+	assert.Equal(t, &account, trace.Action.To)
+	assert.Equal(t, big.NewInt(42), trace.Action.Value)
+	assert.Equal(t, []int{0, 1}, trace.TraceAddress)
+	assert.Equal(t, uint64(100), trace.BlockNumber)
+	require.NotNil(t, trace.TransactionPosition)
+	assert.Equal(t, uint64(3), *trace.TransactionPosition)
+	require.NotNil(t, trace.Result)
+	assert.Empty(t, trace.Error)
+}
+
+func TestTraceTransaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRPC := mock_ethclient.NewMockRPCClient(ctrl)
+	client := ethclient.NewClient(mockRPC)
+
+	txHash := common.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111")
+	responseJSON := `[{
+		"action": {
+			"address": "0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb",
+			"refundAddress": "0xa7d9ddbe1f17865597fbd27ec712455208b6b76d",
+			"balance": "0x64"
+		},
+		"blockHash": "0x2222222222222222222222222222222222222222222222222222222222222222",
+		"blockNumber": 100,
+		"subtraces": 0,
+		"traceAddress": [0],
+		"transactionHash": "0x1111111111111111111111111111111111111111111111111111111111111111",
+		"transactionPosition": 0,
+		"type": "suicide",
+		"error": "Reverted"
+	}]`
+
+	mockRPC.EXPECT().
+		CallContext(gomock.Any(), gomock.Any(), "trace_transaction", txHash).
+		DoAndReturn(func(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+			return json.Unmarshal([]byte(responseJSON), result)
+		})
+
+	traces, err := client.TraceTransaction(context.Background(), txHash)
+	require.NoError(t, err)
+	require.Len(t, traces, 1)
+
+	trace := traces[0]
+	assert.Equal(t, "suicide", trace.Type)
+	require.NotNil(t, trace.Action.Address)
+	assert.Equal(t, common.HexToAddress("0xf02c1c8e6114b1dbe8937a39260b5b0a374432bb"), *trace.Action.Address)
+	require.NotNil(t, trace.Action.RefundAddress)
+	assert.Equal(t, big.NewInt(100), trace.Action.Balance)
+	assert.Equal(t, "Reverted", trace.Error)
+	assert.Nil(t, trace.Result)
+}
@@ -0,0 +1,191 @@
+package ethclient
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// TxTraceResult is the trace of a transaction returned by debug_traceBlockByNumber
+type TxTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result *CallFrame  `json:"result"`
+	// Set if the transaction couldn't be traced
+	Error string `json:"error,omitempty"`
+}
+
+// CallFrame is a call of the callTracer call tree
+type CallFrame struct {
+	// CALL, STATICCALL, DELEGATECALL, CALLCODE, CREATE, CREATE2 or SELFDESTRUCT
+	Type    string
+	From    common.Address
+	To      *common.Address
+	Value   *big.Int
+	Gas     uint64
+	GasUsed uint64
+	Input   []byte
+	Output  []byte
+	// Set if the call reverted, in which case its subcalls are reverted too
+	Error        string
+	RevertReason string
+	Calls        []CallFrame
+}
+
+// callFrameJSON is the internal type used for JSON unmarshaling
+type callFrameJSON struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	To           *common.Address `json:"to,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrame     `json:"calls,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *CallFrame) UnmarshalJSON(data []byte) error {
+	var frame callFrameJSON
+	if err := json.Unmarshal(data, &frame); err != nil {
+		return err
+	}
+	f.Type = frame.Type
+	f.From = frame.From
+	f.To = frame.To
+	f.Value = (*big.Int)(frame.Value)
+	f.Gas = uint64(frame.Gas)
+	f.GasUsed = uint64(frame.GasUsed)
+	f.Input = frame.Input
+	f.Output = frame.Output
+	f.Error = frame.Error
+	f.RevertReason = frame.RevertReason
+	f.Calls = frame.Calls
+	return nil
+}
+
+// Trace is a trace in the Parity/OpenEthereum format returned by trace_filter
+type Trace struct {
+	// call, create, suicide (self-destruct) or reward
+	Type   string
+	Action TraceAction
+	// Nil for failed traces
+	Result *TraceResult
+	// Set if the call failed, in which case its subtraces failed too
+	Error string
+	// Position in the call tree of the transaction, empty for the transaction itself
+	TraceAddress        []int
+	Subtraces           int
+	BlockHash           common.Hash
+	BlockNumber         uint64
+	TransactionHash     *common.Hash
+	TransactionPosition *uint64
+}
+
+// TraceAction is the action of a Trace, its fields depend on the trace type
+type TraceAction struct {
+	// call, staticcall, delegatecall or callcode, for call traces
+	CallType string
+	From     common.Address
+	To       *common.Address
+	Value    *big.Int
+	Gas      uint64
+	Input    []byte
+	// Creation code, for create traces
+	Init []byte
+	// Self-destructed contract, beneficiary and transferred balance, for suicide traces
+	Address       *common.Address
+	RefundAddress *common.Address
+	Balance       *big.Int
+	// Beneficiary and reward type, for reward traces
+	Author     *common.Address
+	RewardType string
+}
+
+// TraceResult is the result of a successful Trace
+type TraceResult struct {
+	GasUsed uint64
+	Output  []byte
+	// Created contract, for create traces
+	Address *common.Address
+	Code    []byte
+}
+
+// traceJSON is the internal type used for JSON unmarshaling
+type traceJSON struct {
+	Type                string           `json:"type"`
+	Action              traceActionJSON  `json:"action"`
+	Result              *traceResultJSON `json:"result"`
+	Error               string           `json:"error,omitempty"`
+	TraceAddress        []int            `json:"traceAddress"`
+	Subtraces           int              `json:"subtraces"`
+	BlockHash           common.Hash      `json:"blockHash"`
+	BlockNumber         uint64           `json:"blockNumber"`
+	TransactionHash     *common.Hash     `json:"transactionHash"`
+	TransactionPosition *uint64          `json:"transactionPosition"`
+}
+
+type traceActionJSON struct {
+	CallType      string          `json:"callType,omitempty"`
+	From          common.Address  `json:"from"`
+	To            *common.Address `json:"to,omitempty"`
+	Value         *hexutil.Big    `json:"value,omitempty"`
+	Gas           hexutil.Uint64  `json:"gas"`
+	Input         hexutil.Bytes   `json:"input,omitempty"`
+	Init          hexutil.Bytes   `json:"init,omitempty"`
+	Address       *common.Address `json:"address,omitempty"`
+	RefundAddress *common.Address `json:"refundAddress,omitempty"`
+	Balance       *hexutil.Big    `json:"balance,omitempty"`
+	Author        *common.Address `json:"author,omitempty"`
+	RewardType    string          `json:"rewardType,omitempty"`
+}
+
+type traceResultJSON struct {
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Output  hexutil.Bytes   `json:"output,omitempty"`
+	Address *common.Address `json:"address,omitempty"`
+	Code    hexutil.Bytes   `json:"code,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (t *Trace) UnmarshalJSON(data []byte) error {
+	var trace traceJSON
+	if err := json.Unmarshal(data, &trace); err != nil {
+		return err
+	}
+	t.Type = trace.Type
+	t.Action = TraceAction{
+		CallType:      trace.Action.CallType,
+		From:          trace.Action.From,
+		To:            trace.Action.To,
+		Value:         (*big.Int)(trace.Action.Value),
+		Gas:           uint64(trace.Action.Gas),
+		Input:         trace.Action.Input,
+		Init:          trace.Action.Init,
+		Address:       trace.Action.Address,
+		RefundAddress: trace.Action.RefundAddress,
+		Balance:       (*big.Int)(trace.Action.Balance),
+		Author:        trace.Action.Author,
+		RewardType:    trace.Action.RewardType,
+	}
+	if trace.Result != nil {
+		t.Result = &TraceResult{
+			GasUsed: uint64(trace.Result.GasUsed),
+			Output:  trace.Result.Output,
+			Address: trace.Result.Address,
+			Code:    trace.Result.Code,
+		}
+	}
+	t.Error = trace.Error
+	t.TraceAddress = trace.TraceAddress
+	t.Subtraces = trace.Subtraces
+	t.BlockHash = trace.BlockHash
+	t.BlockNumber = trace.BlockNumber
+	t.TransactionHash = trace.TransactionHash
+	t.TransactionPosition = trace.TransactionPosition
+	return nil
+}
@@ -2,5 +2,6 @@
 // transfer events.
 //
 // It generates optimized eth_getLogs queries and can filter by direction (send,
-// receive, both) for one or more accounts.
+// receive, both) for one or more accounts. FilterEvents applies the same query
+// merging to any event ABI, filtered by indexed argument values.
 package eventfilter
@@ -1,9 +1,10 @@
 package eventfilter
 
 import (
+	"bytes"
+	"cmp"
 	"context"
-	"errors"
-	"sync"
+	"slices"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -15,48 +16,84 @@ type FilterClient interface {
 	FilterLogs(context.Context, ethereum.FilterQuery) ([]types.Log, error)
 }
 
+// FilterTransfers runs the queries of config with DefaultRangeLimits and
+// returns the parsed events, ordered by block number and log index.
 func FilterTransfers(ctx context.Context, client FilterClient, config TransferQueryConfig) ([]eventlog.Event, error) {
-	queries := config.ToFilterQueries()
-
-	queryEventsCh := make(chan []eventlog.Event, len(queries))
-	queryErrorsCh := make(chan error, len(queries))
-
-	wg := sync.WaitGroup{}
-	for _, query := range queries {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			logs, err := client.FilterLogs(ctx, query)
-			if err != nil {
-				queryErrorsCh <- err
-				return
-			}
-			queryEvents := make([]eventlog.Event, 0)
-			for _, log := range logs {
-				event := eventlog.ParseLog(log)
-				queryEvents = append(queryEvents, event...)
-			}
-			queryEventsCh <- queryEvents
-		}()
-	}
-	wg.Wait()
+	return FilterTransfersWithLimits(ctx, client, config, DefaultRangeLimits)
+}
 
-	close(queryEventsCh)
-	close(queryErrorsCh)
+// FilterTransfersWithLimits is FilterTransfers with the range limits of a provider.
+func FilterTransfersWithLimits(ctx context.Context, client FilterClient, config TransferQueryConfig, limits RangeLimits) ([]eventlog.Event, error) {
+	return filterEvents(ctx, client, config.ToFilterQueries(), limits, eventlog.DefaultRegistry)
+}
 
-	queryErrors := make([]error, 0, len(queries))
-	for queryError := range queryErrorsCh {
-		if queryError != nil {
-			queryErrors = append(queryErrors, queryError)
-		}
+// FilterApprovals runs the queries of config with DefaultRangeLimits and
+// returns the parsed events, ordered by block number and log index.
+func FilterApprovals(ctx context.Context, client FilterClient, config ApprovalQueryConfig) ([]eventlog.Event, error) {
+	return FilterApprovalsWithLimits(ctx, client, config, DefaultRangeLimits)
+}
+
+// FilterApprovalsWithLimits is FilterApprovals with the range limits of a provider.
+func FilterApprovalsWithLimits(ctx context.Context, client FilterClient, config ApprovalQueryConfig, limits RangeLimits) ([]eventlog.Event, error) {
+	return filterEvents(ctx, client, config.ToFilterQueries(), limits, eventlog.DefaultRegistry)
+}
+
+// FilterEvents runs the queries of config with DefaultRangeLimits and returns
+// the events parsed by config.Registry, ordered by block number and log index.
+func FilterEvents(ctx context.Context, client FilterClient, config EventQueryConfig) ([]eventlog.Event, error) {
+	return FilterEventsWithLimits(ctx, client, config, DefaultRangeLimits)
+}
+
+// FilterEventsWithLimits is FilterEvents with the range limits of a provider.
+func FilterEventsWithLimits(ctx context.Context, client FilterClient, config EventQueryConfig, limits RangeLimits) ([]eventlog.Event, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
 	}
-	if len(queryErrors) > 0 {
-		return nil, errors.Join(queryErrors...)
+	return filterEvents(ctx, client, config.ToFilterQueries(), limits, config.registries()...)
+}
+
+// Runs all queries concurrently over sub-ranges and parses the returned logs.
+func filterEvents(ctx context.Context, client FilterClient, queries []ethereum.FilterQuery, limits RangeLimits, registries ...*eventlog.Registry) ([]eventlog.Event, error) {
+	logs, err := filterLogs(ctx, client, queries, limits)
+	if err != nil {
+		return nil, err
 	}
+	return parseLogs(logs, registries...), nil
+}
+
+// Orders logs and parses them with the first registry giving events. Logs
+// returned by several queries (e.g. the send and receive queries of a
+// self-transfer) are parsed once.
+func parseLogs(logs []types.Log, registries ...*eventlog.Registry) []eventlog.Event {
+	sortLogs(logs)
+	logs = slices.CompactFunc(logs, sameLog)
 
 	events := make([]eventlog.Event, 0)
-	for queryEvents := range queryEventsCh {
-		events = append(events, queryEvents...)
+	for _, log := range logs {
+		for _, registry := range registries {
+			if parsed := registry.ParseLog(log); len(parsed) > 0 {
+				events = append(events, parsed...)
+				break
+			}
+		}
+	}
+	return events
+}
+
+// Orders logs by block number and log index.
+func sortLogs(logs []types.Log) {
+	slices.SortStableFunc(logs, compareLogs)
+}
+
+func compareLogs(a, b types.Log) int {
+	if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+		return c
 	}
-	return events, nil
+	return cmp.Compare(a.Index, b.Index)
+}
+
+// Reports whether two logs are the same log returned twice.
+func sameLog(a, b types.Log) bool {
+	return a.BlockNumber == b.BlockNumber && a.BlockHash == b.BlockHash && a.TxHash == b.TxHash && a.Index == b.Index &&
+		a.Address == b.Address && slices.Equal(a.Topics, b.Topics) && bytes.Equal(a.Data, b.Data)
 }
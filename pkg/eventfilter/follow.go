@@ -0,0 +1,394 @@
+package eventfilter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+const (
+	DefaultFollowPollInterval = 12 * time.Second
+	DefaultConfirmationDepth  = 12
+	DefaultFinalizationDepth  = 64
+)
+
+var (
+	ErrClientNotProvided         = errors.New("client not provided")
+	ErrInvalidFollowPollInterval = errors.New("poll interval must be positive")
+)
+
+// HeadClient is needed to follow the head of the chain.
+type HeadClient interface {
+	FilterClient
+	// Called with nil for the latest header, and with rpc.SafeBlockNumber and
+	// rpc.FinalizedBlockNumber for the finality tags.
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type Finality string
+
+const (
+	// Within the confirmation depth, can still be reorged out
+	FinalityPending Finality = "pending"
+	// Past the confirmation depth or the chain's safe block
+	FinalitySafe Finality = "safe"
+	// At or before the chain's finalized block
+	FinalityFinalized Finality = "finalized"
+)
+
+type FollowEventType string
+
+const (
+	// A log was seen for the first time
+	FollowEventTypeNew FollowEventType = "new"
+	// A log previously reported is no longer part of the chain
+	FollowEventTypeRetracted FollowEventType = "retracted"
+	// The finality of a log previously reported changed
+	FollowEventTypeFinalityChanged FollowEventType = "finality_changed"
+	// A poll failed, the blocks will be processed again at the next poll
+	FollowEventTypeError FollowEventType = "error"
+)
+
+type FollowEvent struct {
+	Type FollowEventType
+	Log  types.Log
+	// Events parsed from Log
+	Events   []eventlog.Event
+	Finality Finality
+	// Head at which the event was observed
+	Head uint64
+	// Set for FollowEventTypeError
+	Err error
+}
+
+type FollowConfig struct {
+	// Transfers followed. FromBlock is the first block followed, the head at
+	// start if nil. ToBlock is ignored.
+	Transfers TransferQueryConfig
+	// Blocks from the head in which logs are re-verified at every poll,
+	// defaults to DefaultConfirmationDepth
+	ConfirmationDepth uint64
+	// Blocks from the head after which logs are considered finalized, if the
+	// chain doesn't support the "finalized" block tag. Defaults to
+	// DefaultFinalizationDepth.
+	FinalizationDepth uint64
+	// Limits of the eth_getLogs queries, defaults to DefaultRangeLimits
+	RangeLimits *RangeLimits
+	// Defaults to DefaultFollowPollInterval
+	PollInterval time.Duration
+}
+
+func (c *FollowConfig) Validate() error {
+	if c.PollInterval < 0 {
+		return ErrInvalidFollowPollInterval
+	}
+	return nil
+}
+
+func (c FollowConfig) withDefaults() FollowConfig {
+	if c.ConfirmationDepth == 0 {
+		c.ConfirmationDepth = DefaultConfirmationDepth
+	}
+	if c.FinalizationDepth == 0 {
+		c.FinalizationDepth = DefaultFinalizationDepth
+	}
+	if c.RangeLimits == nil {
+		c.RangeLimits = &DefaultRangeLimits
+	}
+	if c.PollInterval == 0 {
+		c.PollInterval = DefaultFollowPollInterval
+	}
+	return c
+}
+
+// Identifies a log within a block, a log re-included in another block after a
+// reorg is a different log.
+type logKey struct {
+	blockHash common.Hash
+	index     uint
+}
+
+type trackedLog struct {
+	log      types.Log
+	events   []eventlog.Event
+	finality Finality
+}
+
+// Follower follows the head of the chain and reports the transfer logs of new
+// blocks, retracting the ones reorged out (thread-safe for concurrent access).
+type Follower struct {
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	eventCh chan FollowEvent
+	wg      sync.WaitGroup
+
+	client HeadClient
+	config FollowConfig
+
+	// Only accessed from the run goroutine
+	initialized bool
+	startBlock  uint64 // First block followed
+	nextBlock   uint64 // First block not processed yet
+	lastHead    uint64
+	tracked     map[logKey]*trackedLog // Reported logs that are not finalized yet
+	blockHashes map[uint64]common.Hash // Hash of the blocks of the tracked logs
+}
+
+func NewFollower(client HeadClient, config FollowConfig) (*Follower, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrClientNotProvided
+	}
+	return &Follower{
+		client: client,
+		config: config.withDefaults(),
+	}, nil
+}
+
+// Start starts following the head in the background.
+// Events are sent on the returned channel, which is closed when the follower is stopped.
+func (f *Follower) Start(ctx context.Context) <-chan FollowEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cancel != nil {
+		return f.eventCh
+	}
+
+	f.eventCh = make(chan FollowEvent)
+	f.initialized = false
+	f.lastHead = 0
+	f.tracked = make(map[logKey]*trackedLog)
+	f.blockHashes = make(map[uint64]common.Hash)
+
+	childCtx, cancel := context.WithCancel(ctx)
+	f.cancel = cancel
+
+	f.wg.Add(1)
+	go f.run(childCtx, f.eventCh)
+
+	return f.eventCh
+}
+
+// Stop stops the follower and waits for the background goroutine to finish.
+func (f *Follower) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.cancel == nil {
+		return
+	}
+
+	f.cancel()
+	f.wg.Wait()
+	f.cancel = nil
+}
+
+func (f *Follower) run(ctx context.Context, eventCh chan FollowEvent) {
+	defer f.wg.Done()
+	defer close(eventCh)
+
+	ticker := time.NewTicker(f.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := f.poll(ctx, eventCh); err != nil && ctx.Err() == nil {
+			f.emit(ctx, eventCh, FollowEvent{Type: FollowEventTypeError, Head: f.lastHead, Err: err})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *Follower) emit(ctx context.Context, eventCh chan FollowEvent, event FollowEvent) {
+	select {
+	case eventCh <- event:
+	case <-ctx.Done():
+	}
+}
+
+// Queries the new blocks along with the ones within the confirmation depth,
+// and reconciles the result with the logs reported so far.
+func (f *Follower) poll(ctx context.Context, eventCh chan FollowEvent) error {
+	head, err := f.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	headNumber := head.Number.Uint64()
+	// Lagging node (e.g. behind a load balancer)
+	if headNumber < f.lastHead {
+		return nil
+	}
+	if !f.initialized {
+		f.startBlock = headNumber
+		if f.config.Transfers.FromBlock != nil {
+			f.startBlock = f.config.Transfers.FromBlock.Uint64()
+		}
+		f.nextBlock = f.startBlock
+		f.initialized = true
+	}
+
+	// New blocks, and the re-verified window: the last blocks up to the head
+	windowStart := depthBlock(headNumber+1, f.config.ConfirmationDepth)
+	fromBlock := max(f.startBlock, min(f.nextBlock, windowStart))
+	if fromBlock > headNumber {
+		return nil
+	}
+	// Re-verify the tracked logs below the window: a reorg deeper than the
+	// confirmation depth extends the query down to the first replaced block
+	reorgedBlock, err := f.firstReorgedBlock(ctx, fromBlock)
+	if err != nil {
+		return err
+	}
+	fromBlock = min(fromBlock, reorgedBlock)
+
+	queryConfig := f.config.Transfers
+	queryConfig.FromBlock = new(big.Int).SetUint64(fromBlock)
+	queryConfig.ToBlock = new(big.Int).SetUint64(headNumber)
+	logs, err := filterLogs(ctx, f.client, queryConfig.ToFilterQueries(), *f.config.RangeLimits)
+	if err != nil {
+		return err
+	}
+	sortLogs(logs)
+
+	safe, finalized := f.finalityBlocks(ctx, headNumber)
+
+	// Retract the logs of the window that are gone (reorged out, or now reported
+	// with another block hash or as removed)
+	current := make(map[logKey]bool, len(logs))
+	for _, log := range logs {
+		if !log.Removed {
+			current[logKey{log.BlockHash, log.Index}] = true
+		}
+	}
+	var retracted []*trackedLog
+	for key, tracked := range f.tracked {
+		if tracked.log.BlockNumber >= fromBlock && !current[key] {
+			retracted = append(retracted, tracked)
+			delete(f.tracked, key)
+		}
+	}
+	// Newest first, in reverse order of reporting
+	slices.SortFunc(retracted, func(a, b *trackedLog) int {
+		return -compareLogs(a.log, b.log)
+	})
+	for _, tracked := range retracted {
+		f.emit(ctx, eventCh, FollowEvent{Type: FollowEventTypeRetracted, Log: tracked.log, Events: tracked.events, Finality: tracked.finality, Head: headNumber})
+	}
+
+	// Report the new logs
+	for _, log := range logs {
+		key := logKey{log.BlockHash, log.Index}
+		if log.Removed || f.tracked[key] != nil {
+			continue
+		}
+		tracked := &trackedLog{
+			log:      log,
+			events:   eventlog.ParseLog(log),
+			finality: finalityOf(log.BlockNumber, safe, finalized),
+		}
+		f.tracked[key] = tracked
+		f.emit(ctx, eventCh, FollowEvent{Type: FollowEventTypeNew, Log: log, Events: tracked.events, Finality: tracked.finality, Head: headNumber})
+	}
+
+	// Report finality changes of the logs reported before, and stop tracking
+	// the finalized ones
+	var changed []*trackedLog
+	for key, tracked := range f.tracked {
+		finality := finalityOf(tracked.log.BlockNumber, safe, finalized)
+		if finality != tracked.finality {
+			tracked.finality = finality
+			changed = append(changed, tracked)
+		}
+		if finality == FinalityFinalized {
+			delete(f.tracked, key)
+		}
+	}
+	slices.SortFunc(changed, func(a, b *trackedLog) int {
+		return compareLogs(a.log, b.log)
+	})
+	for _, tracked := range changed {
+		f.emit(ctx, eventCh, FollowEvent{Type: FollowEventTypeFinalityChanged, Log: tracked.log, Events: tracked.events, Finality: tracked.finality, Head: headNumber})
+	}
+
+	f.blockHashes = make(map[uint64]common.Hash, len(f.tracked))
+	for _, tracked := range f.tracked {
+		f.blockHashes[tracked.log.BlockNumber] = tracked.log.BlockHash
+	}
+	f.nextBlock = headNumber + 1
+	f.lastHead = headNumber
+	return nil
+}
+
+// Returns the first block of the tracked logs before the given block whose hash
+// changed, or before if none did.
+func (f *Follower) firstReorgedBlock(ctx context.Context, before uint64) (uint64, error) {
+	blockNumbers := make([]uint64, 0, len(f.blockHashes))
+	for blockNumber := range f.blockHashes {
+		if blockNumber < before {
+			blockNumbers = append(blockNumbers, blockNumber)
+		}
+	}
+	slices.Sort(blockNumbers)
+	for _, blockNumber := range blockNumbers {
+		header, err := f.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blockNumber))
+		if errors.Is(err, ethereum.NotFound) {
+			return blockNumber, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		if header.Hash() != f.blockHashes[blockNumber] {
+			return blockNumber, nil
+		}
+	}
+	return before, nil
+}
+
+// Returns the safe and finalized block numbers, from the chain's block tags
+// when supported, or from the configured depths.
+func (f *Follower) finalityBlocks(ctx context.Context, head uint64) (uint64, uint64) {
+	safe := depthBlock(head, f.config.ConfirmationDepth)
+	finalized := depthBlock(head, f.config.FinalizationDepth)
+	if header, err := f.client.HeaderByNumber(ctx, big.NewInt(int64(gethrpc.SafeBlockNumber))); err == nil && header != nil {
+		safe = max(safe, header.Number.Uint64())
+	}
+	if header, err := f.client.HeaderByNumber(ctx, big.NewInt(int64(gethrpc.FinalizedBlockNumber))); err == nil && header != nil {
+		finalized = header.Number.Uint64()
+	}
+	return max(safe, finalized), finalized
+}
+
+// Block depth blocks behind head, genesis if the chain is shorter.
+func depthBlock(head uint64, depth uint64) uint64 {
+	if head < depth {
+		return 0
+	}
+	return head - depth
+}
+
+func finalityOf(blockNumber uint64, safe uint64, finalized uint64) Finality {
+	switch {
+	case blockNumber <= finalized:
+		return FinalityFinalized
+	case blockNumber <= safe:
+		return FinalitySafe
+	}
+	return FinalityPending
+}
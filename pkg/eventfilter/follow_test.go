@@ -0,0 +1,305 @@
+package eventfilter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+)
+
+// Chain of blocks identified by (number, fork), with transfer logs.
+type fakeHeadChain struct {
+	mu        sync.Mutex
+	forks     []byte // Fork of each block
+	logs      map[uint64][]types.Log
+	safe      *uint64
+	finalized *uint64
+}
+
+func newFakeHeadChain(length int) *fakeHeadChain {
+	return &fakeHeadChain{
+		forks: make([]byte, length),
+		logs:  make(map[uint64][]types.Log),
+	}
+}
+
+func blockHeader(number uint64, fork byte) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Extra: []byte{fork}}
+}
+
+func blockHash(number uint64, fork byte) common.Hash {
+	return blockHeader(number, fork).Hash()
+}
+
+// Returns the fork of the block with the given hash.
+func forkOf(number uint64, hash common.Hash) byte {
+	for fork := range 256 {
+		if blockHash(number, byte(fork)) == hash {
+			return byte(fork)
+		}
+	}
+	panic("unknown block hash")
+}
+
+func (c *fakeHeadChain) head() uint64 {
+	return uint64(len(c.forks) - 1)
+}
+
+// Replaces the blocks from number on with count blocks of another fork.
+func (c *fakeHeadChain) reorg(number uint64, count int, fork byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.forks = c.forks[:number]
+	for block := range c.logs {
+		if block >= number {
+			delete(c.logs, block)
+		}
+	}
+	for range count {
+		c.forks = append(c.forks, fork)
+	}
+}
+
+func (c *fakeHeadChain) extend(count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for range count {
+		c.forks = append(c.forks, 0)
+	}
+}
+
+func (c *fakeHeadChain) addTransfer(block uint64, to common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	log := transferLog(block, uint(len(c.logs[block])), common.HexToAddress("0xbbbb"), to)
+	log.BlockHash = blockHash(block, c.forks[block])
+	c.logs[block] = append(c.logs[block], log)
+}
+
+func (c *fakeHeadChain) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var n uint64
+	switch {
+	case number == nil:
+		n = c.head()
+	case number.Int64() == int64(gethrpc.SafeBlockNumber) && c.safe != nil:
+		n = *c.safe
+	case number.Int64() == int64(gethrpc.FinalizedBlockNumber) && c.finalized != nil:
+		n = *c.finalized
+	case number.Sign() < 0:
+		return nil, errors.New("block tag not supported")
+	default:
+		n = number.Uint64()
+	}
+	if n > c.head() {
+		return nil, ethereum.NotFound
+	}
+	return blockHeader(n, c.forks[n]), nil
+}
+
+func (c *fakeHeadChain) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var ret []types.Log
+	for block := query.FromBlock.Uint64(); block <= query.ToBlock.Uint64() && block <= c.head(); block++ {
+		for _, log := range c.logs[block] {
+			if matchTopics(log, query.Topics) {
+				ret = append(ret, log)
+			}
+		}
+	}
+	return ret, nil
+}
+
+type followedLog struct {
+	eventType FollowEventType
+	block     uint64
+	fork      byte
+	finality  Finality
+}
+
+// Collects the events of the next poll, up to the given count.
+func nextFollowEvents(t *testing.T, eventCh <-chan FollowEvent, count int) []followedLog {
+	t.Helper()
+	var ret []followedLog
+	for len(ret) < count {
+		select {
+		case event := <-eventCh:
+			require.NotEqual(t, FollowEventTypeError, event.Type, event.Err)
+			require.Len(t, event.Events, 1)
+			_, ok := event.Events[0].Unpacked.(erc20.Erc20Transfer)
+			require.True(t, ok)
+			ret = append(ret, followedLog{
+				eventType: event.Type,
+				block:     event.Log.BlockNumber,
+				fork:      forkOf(event.Log.BlockNumber, event.Log.BlockHash),
+				finality:  event.Finality,
+			})
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for events, got %v", ret)
+		}
+	}
+	return ret
+}
+
+func TestFollower_ReorgAndFinality(t *testing.T) {
+	alice := common.HexToAddress("0xaaaa")
+	chain := newFakeHeadChain(101)
+	chain.addTransfer(80, alice)
+	chain.addTransfer(95, alice)
+	chain.addTransfer(100, alice)
+
+	f, err := NewFollower(chain, FollowConfig{
+		Transfers: TransferQueryConfig{
+			FromBlock:     big.NewInt(90),
+			Accounts:      []common.Address{alice},
+			TransferTypes: []TransferType{TransferTypeERC20},
+			Direction:     Receive,
+		},
+		ConfirmationDepth: 5,
+		FinalizationDepth: 10,
+		PollInterval:      10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	eventCh := f.Start(context.Background())
+	defer f.Stop()
+
+	// Logs from FromBlock on, 95 is past the confirmation depth
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeNew, 95, 0, FinalitySafe},
+		{FollowEventTypeNew, 100, 0, FinalityPending},
+	}, nextFollowEvents(t, eventCh, 2))
+
+	// Block 100 is replaced, the transfer moves to block 101
+	chain.reorg(99, 4, 1)
+	chain.addTransfer(101, alice)
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeRetracted, 100, 0, FinalityPending},
+		{FollowEventTypeNew, 101, 1, FinalityPending},
+	}, nextFollowEvents(t, eventCh, 2))
+
+	// Finalized once 10 blocks deep
+	chain.extend(8) // Head 110
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeFinalityChanged, 95, 0, FinalityFinalized},
+		{FollowEventTypeFinalityChanged, 101, 1, FinalitySafe},
+	}, nextFollowEvents(t, eventCh, 2))
+}
+
+func TestFollower_ReorgDeeperThanConfirmationDepth(t *testing.T) {
+	alice := common.HexToAddress("0xaaaa")
+	chain := newFakeHeadChain(101)
+	chain.addTransfer(100, alice)
+
+	f, err := NewFollower(chain, FollowConfig{
+		Transfers: TransferQueryConfig{
+			FromBlock:     big.NewInt(95),
+			Accounts:      []common.Address{alice},
+			TransferTypes: []TransferType{TransferTypeERC20},
+			Direction:     Receive,
+		},
+		ConfirmationDepth: 2,
+		FinalizationDepth: 20,
+		PollInterval:      10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	eventCh := f.Start(context.Background())
+	defer f.Stop()
+
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeNew, 100, 0, FinalityPending},
+	}, nextFollowEvents(t, eventCh, 1))
+
+	// Block 100 leaves the re-verified window
+	chain.extend(5) // Head 105
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeFinalityChanged, 100, 0, FinalitySafe},
+	}, nextFollowEvents(t, eventCh, 1))
+
+	// Blocks from 99 on are replaced, the transfer is re-included in the new block 100
+	chain.reorg(99, 8, 1) // Head 106
+	chain.addTransfer(100, alice)
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeRetracted, 100, 0, FinalitySafe},
+		{FollowEventTypeNew, 100, 1, FinalitySafe},
+	}, nextFollowEvents(t, eventCh, 2))
+
+	// Blocks from 100 on are replaced without the transfer
+	chain.reorg(100, 7, 2) // Head 106
+	chain.extend(1)
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeRetracted, 100, 1, FinalitySafe},
+	}, nextFollowEvents(t, eventCh, 1))
+}
+
+func TestFollower_FinalityTags(t *testing.T) {
+	alice := common.HexToAddress("0xaaaa")
+	chain := newFakeHeadChain(101)
+	chain.addTransfer(98, alice)
+	chain.addTransfer(100, alice)
+	safe, finalized := uint64(99), uint64(98)
+	chain.safe, chain.finalized = &safe, &finalized
+
+	f, err := NewFollower(chain, FollowConfig{
+		Transfers: TransferQueryConfig{
+			FromBlock:     big.NewInt(90),
+			Accounts:      []common.Address{alice},
+			TransferTypes: []TransferType{TransferTypeERC20},
+			Direction:     Receive,
+		},
+		ConfirmationDepth: 5,
+		PollInterval:      10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	eventCh := f.Start(context.Background())
+	defer f.Stop()
+
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeNew, 98, 0, FinalityFinalized},
+		{FollowEventTypeNew, 100, 0, FinalityPending},
+	}, nextFollowEvents(t, eventCh, 2))
+}
+
+func TestFollower_StartsAtHead(t *testing.T) {
+	alice := common.HexToAddress("0xaaaa")
+	chain := newFakeHeadChain(101)
+	chain.addTransfer(99, alice)
+
+	f, err := NewFollower(chain, FollowConfig{
+		Transfers: TransferQueryConfig{
+			Accounts:      []common.Address{alice},
+			TransferTypes: []TransferType{TransferTypeERC20},
+			Direction:     Receive,
+		},
+		PollInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	eventCh := f.Start(context.Background())
+	defer f.Stop()
+
+	chain.extend(1)
+	chain.addTransfer(101, alice)
+	assert.Equal(t, []followedLog{
+		{FollowEventTypeNew, 101, 0, FinalityPending},
+	}, nextFollowEvents(t, eventCh, 1))
+}
+
+func TestNewFollower_Validation(t *testing.T) {
+	_, err := NewFollower(nil, FollowConfig{})
+	assert.ErrorIs(t, err, ErrClientNotProvided)
+
+	_, err = NewFollower(newFakeHeadChain(1), FollowConfig{PollInterval: -time.Second})
+	assert.ErrorIs(t, err, ErrInvalidFollowPollInterval)
+}
@@ -0,0 +1,100 @@
+package history
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+)
+
+const (
+	DefaultPollInterval = 12 * time.Second
+	DefaultChunkSize    = 100000
+)
+
+var (
+	ErrClientNotProvided      = errors.New("client not provided")
+	ErrCursorStoreNotProvided = errors.New("cursor store not provided")
+	ErrAccountsNotProvided    = errors.New("at least one account is required")
+	ErrInvalidPollInterval    = errors.New("poll interval must be positive")
+)
+
+type Config struct {
+	ChainID uint64
+	// Accounts whose transfers are synced, in both directions
+	Accounts []common.Address
+	// Token standards synced, all of them if empty
+	TransferTypes []eventfilter.TransferType
+	// Optional restriction of the token contracts synced, all of them if empty
+	ContractAddresses []common.Address
+	// Oldest block of the history
+	StartBlock uint64
+	// Blocks behind the head left unsynced, to stay clear of reorgs
+	Confirmations uint64
+
+	// Blocks synced per step, defaults to DefaultChunkSize
+	ChunkSize uint64
+	// Limits of the eth_getLogs queries of a step, defaults to eventfilter.DefaultRangeLimits
+	RangeLimits *eventfilter.RangeLimits
+	// Defaults to DefaultPollInterval
+	PollInterval time.Duration
+}
+
+func (c *Config) Validate() error {
+	if len(c.Accounts) == 0 {
+		return ErrAccountsNotProvided
+	}
+	if c.PollInterval < 0 {
+		return ErrInvalidPollInterval
+	}
+	return nil
+}
+
+// CursorKey identifies the synced history of the config: chain, accounts,
+// transfer types and contracts, regardless of their order.
+func (c *Config) CursorKey() string {
+	var parts []string
+	for _, account := range c.Accounts {
+		parts = append(parts, "a"+strings.ToLower(account.Hex()))
+	}
+	for _, transferType := range c.transferTypes() {
+		parts = append(parts, "t"+string(transferType))
+	}
+	for _, contract := range c.ContractAddresses {
+		parts = append(parts, "c"+strings.ToLower(contract.Hex()))
+	}
+	slices.Sort(parts)
+	parts = slices.Compact(parts)
+	return fmt.Sprintf("%d-%x", c.ChainID, crypto.Keccak256([]byte(strings.Join(parts, ","))))
+}
+
+func (c Config) withDefaults() Config {
+	if c.PollInterval == 0 {
+		c.PollInterval = DefaultPollInterval
+	}
+	if c.ChunkSize == 0 {
+		c.ChunkSize = DefaultChunkSize
+	}
+	if c.RangeLimits == nil {
+		c.RangeLimits = &eventfilter.DefaultRangeLimits
+	}
+	c.TransferTypes = c.transferTypes()
+	return c
+}
+
+func (c *Config) transferTypes() []eventfilter.TransferType {
+	if len(c.TransferTypes) == 0 {
+		return []eventfilter.TransferType{
+			eventfilter.TransferTypeERC20,
+			eventfilter.TransferTypeERC721,
+			eventfilter.TransferTypeERC1155,
+		}
+	}
+	return c.TransferTypes
+}
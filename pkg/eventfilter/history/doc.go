@@ -0,0 +1,9 @@
+// Package history builds the transfer history of a set of accounts on top of
+// pkg/eventfilter, and keeps it up to date.
+//
+// A Syncer starts at the head of the chain, then alternates between following
+// new blocks and backfilling older ones down to a start block, in chunks of
+// blocks. The synced range is persisted through a pluggable CursorStore after
+// each chunk, so a restarted syncer resumes where it stopped. Transfers and
+// progress are reported as events on a channel.
+package history
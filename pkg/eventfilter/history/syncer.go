@@ -0,0 +1,190 @@
+package history
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+)
+
+// Syncer builds and follows the transfer history of a set of accounts (thread-safe for concurrent access).
+type Syncer struct {
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	eventCh chan Event
+	wg      sync.WaitGroup
+
+	client Client
+	store  CursorStore
+	config Config
+	key    string
+}
+
+func New(client Client, store CursorStore, config Config) (*Syncer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrClientNotProvided
+	}
+	if store == nil {
+		return nil, ErrCursorStoreNotProvided
+	}
+
+	return &Syncer{
+		client: client,
+		store:  store,
+		config: config.withDefaults(),
+		key:    config.CursorKey(),
+	}, nil
+}
+
+// Start starts syncing in the background, resuming from the stored cursor.
+// Events are sent on the returned channel, which is closed when the syncer is stopped.
+//
+// The cursor is stored once the event of a step has been received, so a range
+// is delivered again if the process stops in between: consumers should
+// tolerate duplicates (e.g. by deduplicating on transaction hash and log index).
+func (s *Syncer) Start(ctx context.Context) <-chan Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		return s.eventCh
+	}
+
+	s.eventCh = make(chan Event)
+
+	childCtx, cancel := context.WithCancel(ctx)
+	s.cancel = cancel
+
+	s.wg.Add(1)
+	go s.run(childCtx, s.eventCh)
+
+	return s.eventCh
+}
+
+// Stop stops the syncer and waits for the background goroutine to finish.
+func (s *Syncer) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	s.wg.Wait()
+	s.cancel = nil
+}
+
+func (s *Syncer) run(ctx context.Context, eventCh chan Event) {
+	defer s.wg.Done()
+	defer close(eventCh)
+
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx, eventCh); err != nil && ctx.Err() == nil {
+			s.emit(ctx, eventCh, Event{Type: EventTypeError, Err: err})
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Syncer) emit(ctx context.Context, eventCh chan Event, event Event) bool {
+	select {
+	case eventCh <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Runs steps until the history is synced from the start block up to the head.
+func (s *Syncer) sync(ctx context.Context, eventCh chan Event) error {
+	for {
+		event, next, ok, err := s.step(ctx)
+		if err != nil || !ok {
+			return err
+		}
+		if !s.emit(ctx, eventCh, event) {
+			return ctx.Err()
+		}
+		if err := s.store.SetCursor(s.key, next); err != nil {
+			return err
+		}
+	}
+}
+
+// Syncs the next chunk: new blocks first, then older ones. Returns false when
+// there is nothing left to sync.
+func (s *Syncer) step(ctx context.Context) (Event, Cursor, bool, error) {
+	latest, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return Event{}, Cursor{}, false, err
+	}
+	if latest < s.config.Confirmations || latest-s.config.Confirmations < s.config.StartBlock {
+		return Event{}, Cursor{}, false, nil
+	}
+	head := latest - s.config.Confirmations
+
+	cursor, ok, err := s.store.GetCursor(s.key)
+	if err != nil {
+		return Event{}, Cursor{}, false, err
+	}
+
+	event := Event{Type: EventTypeRangeSynced}
+	var next Cursor
+	switch {
+	case !ok:
+		// Start at the head, the most recent transfers matter most
+		event.FromBlock, event.ToBlock = s.chunkEndingAt(head), head
+		next = Cursor{FromBlock: event.FromBlock, ToBlock: event.ToBlock}
+	case cursor.ToBlock < head:
+		event.FromBlock, event.ToBlock = cursor.ToBlock+1, min(head, cursor.ToBlock+s.config.ChunkSize)
+		next = Cursor{FromBlock: cursor.FromBlock, ToBlock: event.ToBlock}
+	case cursor.FromBlock > s.config.StartBlock:
+		event.FromBlock, event.ToBlock = s.chunkEndingAt(cursor.FromBlock-1), cursor.FromBlock-1
+		event.Backfill = true
+		next = Cursor{FromBlock: event.FromBlock, ToBlock: cursor.ToBlock}
+	default:
+		return Event{}, Cursor{}, false, nil
+	}
+
+	event.Transfers, err = eventfilter.FilterTransfersWithLimits(ctx, s.client, eventfilter.TransferQueryConfig{
+		FromBlock:         new(big.Int).SetUint64(event.FromBlock),
+		ToBlock:           new(big.Int).SetUint64(event.ToBlock),
+		ContractAddresses: s.config.ContractAddresses,
+		Accounts:          s.config.Accounts,
+		TransferTypes:     s.config.TransferTypes,
+		Direction:         eventfilter.Both,
+	}, *s.config.RangeLimits)
+	if err != nil {
+		return Event{}, Cursor{}, false, err
+	}
+
+	event.Progress = Progress{
+		FromBlock:  next.FromBlock,
+		ToBlock:    next.ToBlock,
+		StartBlock: s.config.StartBlock,
+		Head:       head,
+	}
+	return event, next, true, nil
+}
+
+// First block of the chunk ending at toBlock, not before the start block.
+func (s *Syncer) chunkEndingAt(toBlock uint64) uint64 {
+	if toBlock-s.config.StartBlock < s.config.ChunkSize {
+		return s.config.StartBlock
+	}
+	return toBlock - s.config.ChunkSize + 1
+}
@@ -0,0 +1,283 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+var (
+	alice = common.HexToAddress("0xa11ce00000000000000000000000000000000001")
+	bob   = common.HexToAddress("0xb0b0000000000000000000000000000000000002")
+	token = common.HexToAddress("0x1000000000000000000000000000000000000001")
+)
+
+type fakeClient struct {
+	mu      sync.Mutex
+	head    uint64
+	logs    []types.Log
+	failErr error
+}
+
+func (c *fakeClient) BlockNumber(ctx context.Context) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.head, nil
+}
+
+func (c *fakeClient) setHead(head uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head = head
+}
+
+func (c *fakeClient) setFailure(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failErr = err
+}
+
+func (c *fakeClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failErr != nil {
+		return nil, c.failErr
+	}
+	var ret []types.Log
+	for _, log := range c.logs {
+		if log.BlockNumber < query.FromBlock.Uint64() || log.BlockNumber > query.ToBlock.Uint64() {
+			continue
+		}
+		match := true
+		for i, set := range query.Topics {
+			if len(set) > 0 && (i >= len(log.Topics) || !slices.Contains(set, log.Topics[i])) {
+				match = false
+			}
+		}
+		if match {
+			ret = append(ret, log)
+		}
+	}
+	return ret, nil
+}
+
+func transferLog(block uint64, from, to common.Address) types.Log {
+	return types.Log{
+		Address:     token,
+		Topics:      []common.Hash{eventlog.ERC20TransferID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:        common.LeftPadBytes(big.NewInt(1).Bytes(), 32),
+		BlockNumber: block,
+	}
+}
+
+func transferBlocks(t *testing.T, events []eventlog.Event) []uint64 {
+	t.Helper()
+	var ret []uint64
+	for _, event := range events {
+		transfer, ok := event.Unpacked.(erc20.Erc20Transfer)
+		require.True(t, ok)
+		ret = append(ret, transfer.Raw.BlockNumber)
+	}
+	return ret
+}
+
+func nextEvent(t *testing.T, eventCh <-chan Event) Event {
+	t.Helper()
+	select {
+	case event, ok := <-eventCh:
+		require.True(t, ok, "event channel closed")
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for event")
+		return Event{}
+	}
+}
+
+func testConfig() Config {
+	return Config{
+		ChainID:       1,
+		Accounts:      []common.Address{alice},
+		TransferTypes: []eventfilter.TransferType{eventfilter.TransferTypeERC20},
+		ChunkSize:     100,
+		PollInterval:  10 * time.Millisecond,
+	}
+}
+
+func TestSyncer_BackfillAndFollow(t *testing.T) {
+	client := &fakeClient{
+		head: 250,
+		logs: []types.Log{
+			transferLog(10, bob, alice),
+			transferLog(120, alice, bob),
+			transferLog(200, bob, alice),
+			transferLog(280, alice, bob),
+		},
+	}
+	store := NewMemoryCursorStore()
+	config := testConfig()
+	s, err := New(client, store, config)
+	require.NoError(t, err)
+
+	eventCh := s.Start(context.Background())
+	defer s.Stop()
+
+	// Starts at the head
+	event := nextEvent(t, eventCh)
+	assert.Equal(t, EventTypeRangeSynced, event.Type)
+	assert.Equal(t, [2]uint64{151, 250}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.False(t, event.Backfill)
+	assert.Equal(t, []uint64{200}, transferBlocks(t, event.Transfers))
+	assert.Equal(t, Progress{FromBlock: 151, ToBlock: 250, StartBlock: 0, Head: 250}, event.Progress)
+	assert.InDelta(t, 100.0/251.0, event.Progress.Fraction(), 1e-9)
+
+	// Then backfills
+	event = nextEvent(t, eventCh)
+	assert.Equal(t, [2]uint64{51, 150}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.True(t, event.Backfill)
+	assert.Equal(t, []uint64{120}, transferBlocks(t, event.Transfers))
+
+	event = nextEvent(t, eventCh)
+	assert.Equal(t, [2]uint64{0, 50}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.True(t, event.Backfill)
+	assert.Equal(t, []uint64{10}, transferBlocks(t, event.Transfers))
+	assert.True(t, event.Progress.BackfillDone())
+	assert.Equal(t, 1.0, event.Progress.Fraction())
+
+	// And follows the head
+	client.setHead(300)
+	event = nextEvent(t, eventCh)
+	assert.Equal(t, [2]uint64{251, 300}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.False(t, event.Backfill)
+	assert.Equal(t, []uint64{280}, transferBlocks(t, event.Transfers))
+
+	s.Stop()
+	cursor, ok, err := store.GetCursor(config.CursorKey())
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, Cursor{FromBlock: 0, ToBlock: 300}, cursor)
+}
+
+func TestSyncer_ResumeFollowsHeadBeforeBackfill(t *testing.T) {
+	client := &fakeClient{head: 250}
+	store := NewMemoryCursorStore()
+	config := testConfig()
+	config.StartBlock = 50
+	require.NoError(t, store.SetCursor(config.CursorKey(), Cursor{FromBlock: 100, ToBlock: 200}))
+
+	s, err := New(client, store, config)
+	require.NoError(t, err)
+	eventCh := s.Start(context.Background())
+	defer s.Stop()
+
+	event := nextEvent(t, eventCh)
+	assert.Equal(t, [2]uint64{201, 250}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.False(t, event.Backfill)
+
+	event = nextEvent(t, eventCh)
+	assert.Equal(t, [2]uint64{50, 99}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.True(t, event.Backfill)
+	assert.True(t, event.Progress.BackfillDone())
+}
+
+func TestSyncer_Confirmations(t *testing.T) {
+	client := &fakeClient{head: 250}
+	config := testConfig()
+	config.Confirmations = 10
+
+	s, err := New(client, NewMemoryCursorStore(), config)
+	require.NoError(t, err)
+	eventCh := s.Start(context.Background())
+	defer s.Stop()
+
+	event := nextEvent(t, eventCh)
+	assert.Equal(t, [2]uint64{141, 240}, [2]uint64{event.FromBlock, event.ToBlock})
+	assert.Equal(t, uint64(240), event.Progress.Head)
+}
+
+func TestSyncer_ErrorRetried(t *testing.T) {
+	errProvider := errors.New("provider down")
+	client := &fakeClient{head: 50, failErr: errProvider}
+	store := NewMemoryCursorStore()
+	config := testConfig()
+
+	s, err := New(client, store, config)
+	require.NoError(t, err)
+	eventCh := s.Start(context.Background())
+	defer s.Stop()
+
+	event := nextEvent(t, eventCh)
+	assert.Equal(t, EventTypeError, event.Type)
+	assert.ErrorIs(t, event.Err, errProvider)
+	_, ok, err := store.GetCursor(config.CursorKey())
+	require.NoError(t, err)
+	assert.False(t, ok, "cursor not stored for a failed step")
+
+	client.setFailure(nil)
+	for {
+		event = nextEvent(t, eventCh)
+		if event.Type == EventTypeRangeSynced {
+			break
+		}
+	}
+	assert.Equal(t, [2]uint64{0, 50}, [2]uint64{event.FromBlock, event.ToBlock})
+}
+
+func TestSyncer_StartStop(t *testing.T) {
+	s, err := New(&fakeClient{}, NewMemoryCursorStore(), testConfig())
+	require.NoError(t, err)
+
+	eventCh := s.Start(context.Background())
+	assert.Equal(t, eventCh, s.Start(context.Background()))
+	s.Stop()
+	s.Stop()
+
+	_, ok := <-eventCh
+	assert.False(t, ok)
+}
+
+func TestNew_Validation(t *testing.T) {
+	_, err := New(&fakeClient{}, NewMemoryCursorStore(), Config{})
+	assert.ErrorIs(t, err, ErrAccountsNotProvided)
+
+	_, err = New(nil, NewMemoryCursorStore(), testConfig())
+	assert.ErrorIs(t, err, ErrClientNotProvided)
+
+	_, err = New(&fakeClient{}, nil, testConfig())
+	assert.ErrorIs(t, err, ErrCursorStoreNotProvided)
+
+	config := testConfig()
+	config.PollInterval = -time.Second
+	_, err = New(&fakeClient{}, NewMemoryCursorStore(), config)
+	assert.ErrorIs(t, err, ErrInvalidPollInterval)
+}
+
+func TestConfig_CursorKey(t *testing.T) {
+	a := Config{ChainID: 1, Accounts: []common.Address{alice, bob}}
+	b := Config{ChainID: 1, Accounts: []common.Address{bob, alice, bob}}
+	assert.Equal(t, a.CursorKey(), b.CursorKey(), "order and duplicates don't matter")
+
+	b.ChainID = 10
+	assert.NotEqual(t, a.CursorKey(), b.CursorKey())
+
+	c := Config{ChainID: 1, Accounts: []common.Address{alice, bob}, TransferTypes: []eventfilter.TransferType{eventfilter.TransferTypeERC20}}
+	assert.NotEqual(t, a.CursorKey(), c.CursorKey())
+
+	d := Config{ChainID: 1, Accounts: []common.Address{alice, bob}, TransferTypes: []eventfilter.TransferType{
+		eventfilter.TransferTypeERC1155, eventfilter.TransferTypeERC721, eventfilter.TransferTypeERC20,
+	}}
+	assert.Equal(t, a.CursorKey(), d.CursorKey(), "empty transfer types means all of them")
+}
@@ -0,0 +1,104 @@
+package history
+
+import (
+	"context"
+	"sync"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+// Client needed to sync the transfer history.
+type Client interface {
+	eventfilter.FilterClient
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Cursor is the contiguous block range synced so far.
+type Cursor struct {
+	FromBlock uint64
+	ToBlock   uint64
+}
+
+// CursorStore persists the cursor of each synced history, see Config.CursorKey.
+type CursorStore interface {
+	// GetCursor returns false if the history was never synced.
+	GetCursor(key string) (Cursor, bool, error)
+	SetCursor(key string, cursor Cursor) error
+}
+
+type EventType string
+
+const (
+	// A block range was synced, with the transfers found in it
+	EventTypeRangeSynced EventType = "range_synced"
+	// A step failed, it is retried at the next poll
+	EventTypeError EventType = "error"
+)
+
+type Progress struct {
+	// Synced range
+	FromBlock uint64
+	ToBlock   uint64
+	// Range to sync: Config.StartBlock up to the head minus the confirmations
+	StartBlock uint64
+	Head       uint64
+}
+
+// BackfillDone reports whether the history is synced down to the start block.
+func (p Progress) BackfillDone() bool {
+	return p.FromBlock <= p.StartBlock
+}
+
+// Fraction returns the synced share of the range to sync, between 0 and 1.
+func (p Progress) Fraction() float64 {
+	if p.Head < p.StartBlock {
+		return 1
+	}
+	from, to := max(p.FromBlock, p.StartBlock), min(p.ToBlock, p.Head)
+	if to < from {
+		return 0
+	}
+	return float64(to-from+1) / float64(p.Head-p.StartBlock+1)
+}
+
+type Event struct {
+	Type EventType
+	// Range synced by the step
+	FromBlock uint64
+	ToBlock   uint64
+	// Whether the range is older than the ranges synced before (backfill), or
+	// newer (head following)
+	Backfill bool
+	// Transfers in the range, ordered by block number and log index
+	Transfers []eventlog.Event
+	Progress  Progress
+	// Set for EventTypeError
+	Err error
+}
+
+// In-memory CursorStore, histories resume within the process lifetime only.
+type MemoryCursorStore struct {
+	mu      sync.RWMutex
+	cursors map[string]Cursor
+}
+
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{
+		cursors: make(map[string]Cursor),
+	}
+}
+
+func (s *MemoryCursorStore) GetCursor(key string) (Cursor, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cursor, ok := s.cursors[key]
+	return cursor, ok, nil
+}
+
+func (s *MemoryCursorStore) SetCursor(key string, cursor Cursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[key] = cursor
+	return nil
+}
@@ -0,0 +1,170 @@
+package eventfilter
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+// Number of topics of a standard Transfer(address,address,uint256) log: the
+// ERC721 token ID is indexed, the ERC20 value isn't.
+const (
+	erc20TransferTopics  = 3
+	erc721TransferTopics = 4
+)
+
+// StandardResolver reports the token standard of contracts, to tell ERC20 and
+// ERC721 Transfer logs apart when their topics don't follow the standard.
+type StandardResolver interface {
+	// Returns TransferTypeERC20 or TransferTypeERC721 for the contracts whose
+	// standard is known, the others are omitted.
+	ResolveStandards(ctx context.Context, contracts []common.Address) (map[common.Address]TransferType, error)
+}
+
+// StaticStandards is a StandardResolver over known contracts, e.g. the tokens
+// of a token list.
+type StaticStandards map[common.Address]TransferType
+
+func (s StaticStandards) ResolveStandards(ctx context.Context, contracts []common.Address) (map[common.Address]TransferType, error) {
+	ret := make(map[common.Address]TransferType, len(contracts))
+	for _, contract := range contracts {
+		if standard, ok := s[contract]; ok {
+			ret[contract] = standard
+		}
+	}
+	return ret, nil
+}
+
+// Identifies a log within the chain.
+type logID struct {
+	txHash common.Hash
+	index  uint
+}
+
+// NormalizeTransfers returns a single transfer event per log, in the order of
+// events:
+//   - Transfer events of the same log (same transaction hash and log index),
+//     e.g. returned by both the send and receive queries of a self-transfer,
+//     are deduplicated.
+//   - Transfer(address,address,uint256) logs are ERC721 transfers if the
+//     contract is resolved as ERC721 by resolver, ERC20 transfers if resolved as
+//     ERC20, and otherwise ERC721 transfers with 4 topics and ERC20 transfers
+//     with 3 topics. Events parsed as the other standard are converted (the
+//     ERC20 value being the ERC721 token ID).
+//
+// resolver is optional. If it fails, the standards are decided from the
+// topics. Events other than transfers are returned unchanged.
+func NormalizeTransfers(ctx context.Context, events []eventlog.Event, resolver StandardResolver) []eventlog.Event {
+	var standards map[common.Address]TransferType
+	if resolver != nil {
+		contracts := make([]common.Address, 0)
+		seen := make(map[common.Address]bool)
+		for _, event := range events {
+			log, ok := transferEventLog(event)
+			if ok && event.ContractKey != eventlog.ERC1155 && !seen[log.Address] {
+				seen[log.Address] = true
+				contracts = append(contracts, log.Address)
+			}
+		}
+		if len(contracts) > 0 {
+			standards, _ = resolver.ResolveStandards(ctx, contracts)
+		}
+	}
+
+	ret := make([]eventlog.Event, 0, len(events))
+	seen := make(map[logID]bool)
+	for _, event := range events {
+		log, ok := transferEventLog(event)
+		if !ok {
+			ret = append(ret, event)
+			continue
+		}
+		id := logID{log.TxHash, log.Index}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if event.ContractKey == eventlog.ERC1155 {
+			ret = append(ret, event)
+			continue
+		}
+
+		standard, ok := standards[log.Address]
+		if !ok {
+			standard = TransferTypeERC20
+			if len(log.Topics) == erc721TransferTopics {
+				standard = TransferTypeERC721
+			} else if len(log.Topics) != erc20TransferTopics && event.ContractKey == eventlog.ERC721 {
+				// Non-standard topics, keep the parsed standard
+				standard = TransferTypeERC721
+			}
+		}
+		ret = append(ret, asTransferStandard(event, standard))
+	}
+	return ret
+}
+
+// Returns the log of a transfer event.
+func transferEventLog(event eventlog.Event) (types.Log, bool) {
+	switch transfer := event.Unpacked.(type) {
+	case erc20.Erc20Transfer:
+		return transfer.Raw, true
+	case erc721.Erc721Transfer:
+		return transfer.Raw, true
+	case erc1155.Erc1155TransferSingle:
+		return transfer.Raw, true
+	case erc1155.Erc1155TransferBatch:
+		return transfer.Raw, true
+	}
+	return types.Log{}, false
+}
+
+// Converts an ERC20 or ERC721 transfer event to the given standard.
+func asTransferStandard(event eventlog.Event, standard TransferType) eventlog.Event {
+	switch transfer := event.Unpacked.(type) {
+	case erc20.Erc20Transfer:
+		if standard != TransferTypeERC721 {
+			return event
+		}
+		return transferEvent(eventlog.ERC721, eventlog.ERC721Transfer, erc721.Erc721MetaData, erc721.Erc721Transfer{
+			From:    transfer.From,
+			To:      transfer.To,
+			TokenId: transfer.Value,
+			Raw:     transfer.Raw,
+		})
+	case erc721.Erc721Transfer:
+		if standard != TransferTypeERC20 {
+			return event
+		}
+		return transferEvent(eventlog.ERC20, eventlog.ERC20Transfer, erc20.Erc20MetaData, erc20.Erc20Transfer{
+			From:  transfer.From,
+			To:    transfer.To,
+			Value: transfer.TokenId,
+			Raw:   transfer.Raw,
+		})
+	}
+	return event
+}
+
+// Builds the event of a Transfer log parsed as the standard of meta.
+func transferEvent(contractKey eventlog.ContractKey, eventKey eventlog.EventKey, meta *bind.MetaData, unpacked any) eventlog.Event {
+	contractABI, err := meta.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	event := contractABI.Events["Transfer"]
+	return eventlog.Event{
+		ContractKey: contractKey,
+		ContractABI: contractABI,
+		EventKey:    eventKey,
+		ABIEvent:    &event,
+		Unpacked:    unpacked,
+	}
+}
@@ -0,0 +1,153 @@
+package eventfilter
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+	mock_multicall "github.com/status-im/go-wallet-sdk/pkg/multicall/mock"
+)
+
+func txLog(log types.Log, txHash string) types.Log {
+	log.TxHash = common.HexToHash(txHash)
+	return log
+}
+
+// Transfer log with the token ID indexed.
+func erc721TransferLog(block uint64, index uint, from, to common.Address, tokenID int64) types.Log {
+	log := transferLog(block, index, from, to)
+	log.Topics = append(log.Topics, common.BigToHash(big.NewInt(tokenID)))
+	log.Data = nil
+	return log
+}
+
+func parseAll(logs ...types.Log) []eventlog.Event {
+	var ret []eventlog.Event
+	for _, log := range logs {
+		ret = append(ret, eventlog.ParseLog(log)...)
+	}
+	return ret
+}
+
+func TestNormalizeTransfers_DedupesByLog(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	self := txLog(transferLog(10, 0, account, account), "0x01")
+	nft := txLog(erc721TransferLog(10, 1, other, account, 7), "0x02")
+
+	events := NormalizeTransfers(context.Background(), parseAll(self, self, nft, nft), nil)
+	require.Len(t, events, 2)
+
+	transfer, ok := events[0].Unpacked.(erc20.Erc20Transfer)
+	require.True(t, ok)
+	assert.Equal(t, self, transfer.Raw)
+	assert.Equal(t, eventlog.ERC20Transfer, events[0].EventKey)
+
+	nftTransfer, ok := events[1].Unpacked.(erc721.Erc721Transfer)
+	require.True(t, ok)
+	assert.Equal(t, big.NewInt(7), nftTransfer.TokenId)
+	assert.Equal(t, eventlog.ERC721Transfer, events[1].EventKey)
+}
+
+func TestNormalizeTransfers_Resolver(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	// Legacy ERC721 contract emitting the token ID unindexed
+	legacy := txLog(transferLog(10, 0, other, account), "0x01")
+	legacy.Address = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	token := txLog(transferLog(10, 1, other, account), "0x02")
+
+	events := NormalizeTransfers(context.Background(), parseAll(legacy, token), StaticStandards{
+		legacy.Address: TransferTypeERC721,
+	})
+	require.Len(t, events, 2)
+
+	nftTransfer, ok := events[0].Unpacked.(erc721.Erc721Transfer)
+	require.True(t, ok, "got %T", events[0].Unpacked)
+	assert.Equal(t, eventlog.ERC721, events[0].ContractKey)
+	assert.Equal(t, eventlog.ERC721Transfer, events[0].EventKey)
+	assert.Equal(t, "Transfer", events[0].ABIEvent.Name)
+	assert.Equal(t, big.NewInt(1), nftTransfer.TokenId)
+	assert.Equal(t, legacy, nftTransfer.Raw)
+
+	_, ok = events[1].Unpacked.(erc20.Erc20Transfer)
+	assert.True(t, ok, "unresolved contracts use the topic count")
+}
+
+func TestFilterTransfers_SelfTransferOnce(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	client := &fakeLogsClient{
+		logs: []types.Log{txLog(transferLog(10, 0, account, account), "0x01")},
+	}
+
+	events, err := FilterTransfers(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(20),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Both,
+	})
+	require.NoError(t, err)
+	// Returned by both the send and receive queries
+	require.Len(t, client.queries, 2)
+	assert.Len(t, events, 1)
+}
+
+func TestERC165StandardResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	nft := common.HexToAddress("0x3000000000000000000000000000000000000001")
+	token := common.HexToAddress("0x3000000000000000000000000000000000000002")
+	legacy := common.HexToAddress("0x3000000000000000000000000000000000000003")
+	multiToken := common.HexToAddress("0x3000000000000000000000000000000000000004")
+	// Fallback returning true for any call
+	fallback := common.HexToAddress("0x3000000000000000000000000000000000000005")
+
+	supported := map[common.Address][][4]byte{
+		nft:        {multicall.ERC165InterfaceID, multicall.ERC721InterfaceID},
+		token:      {multicall.ERC165InterfaceID},
+		multiToken: {multicall.ERC165InterfaceID, multicall.ERC1155InterfaceID},
+		fallback:   {multicall.ERC165InterfaceID, multicall.ERC721InterfaceID, multicall.InvalidInterfaceID},
+	}
+	caller := mock_multicall.NewMockCaller(ctrl)
+	caller.EXPECT().ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).DoAndReturn(
+		func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+			results := make([]multicall3.IMulticall3Result, len(calls))
+			for i, call := range calls {
+				interfaces, ok := supported[call.Target]
+				if !ok {
+					// No supportsInterface function
+					continue
+				}
+				var interfaceID [4]byte
+				copy(interfaceID[:], call.CallData[4:8])
+				ret := big.NewInt(0)
+				for _, id := range interfaces {
+					if id == interfaceID {
+						ret = big.NewInt(1)
+					}
+				}
+				results[i] = multicall3.IMulticall3Result{Success: true, ReturnData: common.BigToHash(ret).Bytes()}
+			}
+			return big.NewInt(1), [32]byte{}, results, nil
+		})
+
+	resolver := NewERC165StandardResolver(caller, 100)
+	standards, err := resolver.ResolveStandards(context.Background(), []common.Address{nft, token, legacy, multiToken, fallback})
+	require.NoError(t, err)
+	// ERC165 doesn't tell ERC20 contracts apart, only ERC721 ones are resolved
+	assert.Equal(t, map[common.Address]TransferType{
+		nft: TransferTypeERC721,
+	}, standards)
+}
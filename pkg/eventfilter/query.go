@@ -2,6 +2,7 @@ package eventfilter
 
 import (
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -32,6 +33,11 @@ type TransferQueryConfig struct {
 	Accounts          []common.Address
 	TransferTypes     []TransferType
 	Direction         Direction
+	// Wrapped native token contracts (e.g. weth.WrappedNativeAddresses[chainID])
+	// whose Deposit and Withdrawal events of the accounts are also queried
+	// with TransferTypeERC20: wrapping and unwrapping don't emit a Transfer.
+	// Limited to ContractAddresses when set.
+	WrappedNative []common.Address
 }
 
 func (c *TransferQueryConfig) ToFilterQueries() []ethereum.FilterQuery {
@@ -65,14 +71,59 @@ func (c *TransferQueryConfig) ToFilterQueries() []ethereum.FilterQuery {
 		topicsList = buildBothTopicsList(addressTopics, c.TransferTypes)
 	}
 
-	queries := make([]ethereum.FilterQuery, 0, len(topicsList))
+	queries := make([]ethereum.FilterQuery, 0, len(topicsList)+1)
 	for _, topics := range topicsList {
 		queries = append(queries, buildFilterQuery(c.FromBlock, c.ToBlock, c.ContractAddresses, topics))
 	}
 
+	// Wraps and unwraps, in a separate query limited to the wrapped native
+	// contracts since other contracts use the same signatures
+	hasERC20, _, _ := unpackTransferTypes(c.TransferTypes)
+	if wrappedNative := c.wrappedNativeContracts(); hasERC20 && len(wrappedNative) > 0 {
+		if topics := buildWrappedNativeTopics(addressTopics, c.Direction); topics != nil {
+			queries = append(queries, buildFilterQuery(c.FromBlock, c.ToBlock, wrappedNative, topics))
+		}
+	}
+
 	return queries
 }
 
+// Returns the wrapped native contracts to query, within ContractAddresses when
+// set.
+func (c *TransferQueryConfig) wrappedNativeContracts() []common.Address {
+	if len(c.ContractAddresses) == 0 {
+		return c.WrappedNative
+	}
+	var ret []common.Address
+	for _, address := range c.WrappedNative {
+		if slices.Contains(c.ContractAddresses, address) {
+			ret = append(ret, address)
+		}
+	}
+	return ret
+}
+
+// Returns the topics of the Deposit(dst) and Withdrawal(src) events of
+// wrapped native contracts, both indexing the account at topic1: a deposit
+// receives the wrapped token, a withdrawal sends it.
+func buildWrappedNativeTopics(addressTopics []common.Hash, direction Direction) topics {
+	var signatures []common.Hash
+	switch direction {
+	case Send:
+		signatures = []common.Hash{eventlog.WETHWithdrawalID}
+	case Receive:
+		signatures = []common.Hash{eventlog.WETHDepositID}
+	case Both:
+		signatures = []common.Hash{eventlog.WETHDepositID, eventlog.WETHWithdrawalID}
+	default:
+		return nil
+	}
+	return topics{
+		signatures,    // Match Deposit and/or Withdrawal event signatures
+		addressTopics, // Match any of our addresses in 'dst'/'src' field
+	}
+}
+
 func buildFilterQuery(fromBlock *big.Int, toBlock *big.Int, contractAddresses []common.Address, topics topics) ethereum.FilterQuery {
 	query := ethereum.FilterQuery{
 		FromBlock: fromBlock,
@@ -158,8 +209,6 @@ func buildReceiveTopicsList(addressTopics []common.Hash, transferTypes []Transfe
 }
 
 func buildBothTopicsList(addressTopics []common.Hash, transferTypes []TransferType) []topics {
-	var topicsList []topics
-
 	// Both direction: optimized with merging where possible
 	// - ERC20/ERC721 Send: [eventSignature, address] (2 topics)
 	// - Merged ERC20/ERC721 Receive + ERC1155 Send: [eventSignature, {}, address] (3 topics)
@@ -167,40 +216,68 @@ func buildBothTopicsList(addressTopics []common.Hash, transferTypes []TransferTy
 
 	hasERC20, hasERC721, hasERC1155 := unpackTransferTypes(transferTypes)
 
-	// ERC20/ERC721 Send query
+	var topicsList []topics
 	if hasERC20 || hasERC721 {
-		topicsList = append(topicsList, topics{
-			{eventlog.ERC20TransferID}, // Match Transfer event signature (same for ERC20 and ERC721)
-			addressTopics,              // Match any of our addresses in 'from' field
-		})
+		topicsList = append(topicsList,
+			topics{
+				{eventlog.ERC20TransferID}, // Match Transfer event signature (same for ERC20 and ERC721)
+				addressTopics,              // Match any of our addresses in 'from' field
+			},
+			topics{
+				{eventlog.ERC20TransferID}, // Match Transfer event signature (same for ERC20 and ERC721)
+				{},                         // Any 'from' address
+				addressTopics,              // Match any of our addresses in 'to' field
+			},
+		)
+	}
+	if hasERC1155 {
+		topicsList = append(topicsList,
+			topics{
+				{eventlog.ERC1155TransferSingleID, eventlog.ERC1155TransferBatchID}, // Match either TransferSingle OR TransferBatch
+				{},            // Any operator
+				addressTopics, // Match any of our addresses in 'from' field
+			},
+			topics{
+				{eventlog.ERC1155TransferSingleID, eventlog.ERC1155TransferBatchID}, // Match either TransferSingle OR TransferBatch
+				{},            // Any operator
+				{},            // Any 'from' address
+				addressTopics, // Match any of our addresses in 'to' field
+			},
+		)
 	}
 
-	// Merged ERC20/ERC721 Receive + ERC1155 Send query (only if we have both)
-	{
-		var eventSignatures []common.Hash
-		if hasERC20 || hasERC721 {
-			eventSignatures = append(eventSignatures, eventlog.ERC20TransferID) // Transfer event signature (same for ERC20 and ERC721)
-		}
-		if hasERC1155 {
-			eventSignatures = append(eventSignatures, eventlog.ERC1155TransferSingleID, eventlog.ERC1155TransferBatchID)
-		}
+	// The ERC20/ERC721 Receive and ERC1155 Send queries only differ by their
+	// event signatures
+	return mergeTopicsList(topicsList)
+}
 
-		topicsList = append(topicsList, topics{
-			eventSignatures, // Match any of the event signatures
-			{},              // Any 'from' address (or operator for ERC1155)
-			addressTopics,   // Match any of our addresses in 'to' field
+// Merges the queries matching the same topics after the event signature into
+// a single query matching any of their signatures. Queries keep the position of
+// their first occurrence.
+func mergeTopicsList(topicsList []topics) []topics {
+	var ret []topics
+	for _, t := range topicsList {
+		t = trimTopics(t)
+		i := slices.IndexFunc(ret, func(merged topics) bool {
+			return len(merged) == len(t) && slices.EqualFunc(merged[1:], t[1:], slices.Equal)
 		})
+		if i < 0 {
+			ret = append(ret, append(topics{slices.Clone(t[0])}, t[1:]...))
+			continue
+		}
+		for _, signature := range t[0] {
+			if !slices.Contains(ret[i][0], signature) {
+				ret[i][0] = append(ret[i][0], signature)
+			}
+		}
 	}
+	return ret
+}
 
-	// ERC1155 Receive query
-	if hasERC1155 {
-		topicsList = append(topicsList, topics{
-			{eventlog.ERC1155TransferSingleID, eventlog.ERC1155TransferBatchID}, // Match either TransferSingle OR TransferBatch
-			{},            // Any operator
-			{},            // Any 'from' address
-			addressTopics, // Match any of our addresses in 'to' field
-		})
+// Removes the trailing positions matching any topic.
+func trimTopics(t topics) topics {
+	for len(t) > 1 && len(t[len(t)-1]) == 0 {
+		t = t[:len(t)-1]
 	}
-
-	return topicsList
+	return t
 }
@@ -0,0 +1,63 @@
+package eventfilter
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+type ApprovalQueryConfig struct {
+	FromBlock         *big.Int
+	ToBlock           *big.Int
+	ContractAddresses []common.Address
+	// Token owners
+	Accounts []common.Address
+	// ERC20: Approval, ERC721: Approval and ApprovalForAll, ERC1155: ApprovalForAll
+	TransferTypes []TransferType
+}
+
+func (c *ApprovalQueryConfig) ToFilterQueries() []ethereum.FilterQuery {
+	// FilterQuery should match Approval/ApprovalForAll events of the given types,
+	// with any of the given addresses in the owner field
+
+	// Transfer types need to be specified
+	if len(c.TransferTypes) == 0 {
+		return nil
+	}
+
+	// If both are specified, FromBlock must not be greater than ToBlock
+	if c.FromBlock != nil && c.ToBlock != nil && c.FromBlock.Cmp(c.ToBlock) > 0 {
+		return nil
+	}
+
+	// Convert addresses to topic format (32-byte padded)
+	var addressTopics []common.Hash
+	for _, addr := range c.Accounts {
+		addressTopics = append(addressTopics, common.BytesToHash(addr.Bytes()))
+	}
+
+	// The owner is the first indexed argument for all approval events, so a
+	// single query is enough:
+	// - ERC20 Approval: [eventSignature, owner, spender]
+	// - ERC721 Approval: [eventSignature, owner, approved, tokenId]
+	// - ERC721/ERC1155 ApprovalForAll: [eventSignature, owner, operator]
+	hasERC20, hasERC721, hasERC1155 := unpackTransferTypes(c.TransferTypes)
+
+	var eventSignatures []common.Hash
+	if hasERC20 || hasERC721 {
+		eventSignatures = append(eventSignatures, eventlog.ERC20ApprovalID) // Approval event signature (same for ERC20 and ERC721)
+	}
+	if hasERC721 || hasERC1155 {
+		eventSignatures = append(eventSignatures, eventlog.ERC721ApprovalForAllID) // ApprovalForAll event signature (same for ERC721 and ERC1155)
+	}
+
+	return []ethereum.FilterQuery{
+		buildFilterQuery(c.FromBlock, c.ToBlock, c.ContractAddresses, topics{
+			eventSignatures, // Match any of the event signatures
+			addressTopics,   // Match any of our addresses in 'owner' field
+		}),
+	}
+}
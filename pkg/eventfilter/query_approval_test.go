@@ -0,0 +1,85 @@
+package eventfilter
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+func TestApprovalQueryConfig_ToFilterQueries(t *testing.T) {
+	testAddr1 := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	testAddr2 := common.HexToAddress("0x9876543210987654321098765432109876543210")
+	addressTopics := []common.Hash{
+		common.BytesToHash(testAddr1.Bytes()),
+		common.BytesToHash(testAddr2.Bytes()),
+	}
+	contract := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	tests := []struct {
+		name               string
+		transferTypes      []TransferType
+		expectedSignatures []common.Hash
+	}{
+		{
+			name:               "ERC20",
+			transferTypes:      []TransferType{TransferTypeERC20},
+			expectedSignatures: []common.Hash{eventlog.ERC20ApprovalID},
+		},
+		{
+			name:               "ERC721",
+			transferTypes:      []TransferType{TransferTypeERC721},
+			expectedSignatures: []common.Hash{eventlog.ERC721ApprovalID, eventlog.ERC721ApprovalForAllID},
+		},
+		{
+			name:               "ERC1155",
+			transferTypes:      []TransferType{TransferTypeERC1155},
+			expectedSignatures: []common.Hash{eventlog.ERC1155ApprovalForAllID},
+		},
+		{
+			name:               "All types",
+			transferTypes:      []TransferType{TransferTypeERC20, TransferTypeERC721, TransferTypeERC1155},
+			expectedSignatures: []common.Hash{eventlog.ERC20ApprovalID, eventlog.ERC721ApprovalForAllID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := ApprovalQueryConfig{
+				FromBlock:         big.NewInt(100),
+				ToBlock:           big.NewInt(200),
+				ContractAddresses: []common.Address{contract},
+				Accounts:          []common.Address{testAddr1, testAddr2},
+				TransferTypes:     tt.transferTypes,
+			}
+			queries := config.ToFilterQueries()
+			require.Len(t, queries, 1)
+
+			query := queries[0]
+			assert.Equal(t, big.NewInt(100), query.FromBlock)
+			assert.Equal(t, big.NewInt(200), query.ToBlock)
+			assert.Equal(t, []common.Address{contract}, query.Addresses)
+			require.Len(t, query.Topics, 2)
+			assert.Equal(t, tt.expectedSignatures, query.Topics[0])
+			assert.Equal(t, addressTopics, query.Topics[1])
+		})
+	}
+}
+
+func TestApprovalQueryConfig_ToFilterQueries_Invalid(t *testing.T) {
+	config := ApprovalQueryConfig{
+		Accounts: []common.Address{common.HexToAddress("0x1")},
+	}
+	assert.Nil(t, config.ToFilterQueries())
+
+	config = ApprovalQueryConfig{
+		FromBlock:     big.NewInt(200),
+		ToBlock:       big.NewInt(100),
+		TransferTypes: []TransferType{TransferTypeERC20},
+	}
+	assert.Nil(t, config.ToFilterQueries())
+}
@@ -0,0 +1,121 @@
+package eventfilter
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+var (
+	ErrNoEvents           = errors.New("at least one event is required")
+	ErrAnonymousEvent     = errors.New("anonymous events can't be filtered by signature")
+	ErrTooManyIndexedArgs = errors.New("more indexed argument filters than indexed arguments")
+	ErrInvalidBlockRange  = errors.New("from block must not be greater than to block")
+)
+
+// EventsContractKey is the contract key of the events parsed from the ABIs of
+// EventQueryConfig.Events, e.g. "eventsstaked" for Staked.
+const EventsContractKey eventlog.ContractKey = "events"
+
+// EventFilter matches the logs of an event.
+type EventFilter struct {
+	Event abi.Event
+	// Values of the indexed arguments, by position among the indexed
+	// arguments: a log matches if each of its indexed arguments is one of the
+	// values of its position (OR-set). Empty or missing positions match any
+	// value. Values have the Go type of the argument (common.Address,
+	// *big.Int, bool, common.Hash, ...), see abi.MakeTopics.
+	IndexedArgs [][]any
+}
+
+type EventQueryConfig struct {
+	FromBlock         *big.Int
+	ToBlock           *big.Int
+	ContractAddresses []common.Address
+	Events            []EventFilter
+	// Parses the returned logs. Logs of events not registered in it are
+	// dropped. When nil, logs are parsed by eventlog.DefaultRegistry, and
+	// otherwise from the ABIs of Events with EventsContractKey.
+	Registry *eventlog.Registry
+}
+
+func (c *EventQueryConfig) Validate() error {
+	if len(c.Events) == 0 {
+		return ErrNoEvents
+	}
+	if c.FromBlock != nil && c.ToBlock != nil && c.FromBlock.Cmp(c.ToBlock) > 0 {
+		return ErrInvalidBlockRange
+	}
+	for _, filter := range c.Events {
+		if _, err := filter.topics(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ToFilterQueries returns the queries of the events, or nil if the config is
+// invalid. Events matching the same indexed argument values, e.g. events
+// without filters, are merged into a single query.
+func (c *EventQueryConfig) ToFilterQueries() []ethereum.FilterQuery {
+	if c.Validate() != nil {
+		return nil
+	}
+
+	topicsList := make([]topics, 0, len(c.Events))
+	for _, filter := range c.Events {
+		t, _ := filter.topics()
+		topicsList = append(topicsList, t)
+	}
+	topicsList = mergeTopicsList(topicsList)
+
+	queries := make([]ethereum.FilterQuery, 0, len(topicsList))
+	for _, topics := range topicsList {
+		queries = append(queries, buildFilterQuery(c.FromBlock, c.ToBlock, c.ContractAddresses, topics))
+	}
+	return queries
+}
+
+// Returns the registries parsing the logs, in order of precedence.
+func (c *EventQueryConfig) registries() []*eventlog.Registry {
+	if c.Registry != nil {
+		return []*eventlog.Registry{c.Registry}
+	}
+	eventsABI := abi.ABI{Events: make(map[string]abi.Event, len(c.Events))}
+	for _, filter := range c.Events {
+		eventsABI.Events[filter.Event.ID.Hex()] = filter.Event
+	}
+	registry := eventlog.NewRegistry()
+	if err := registry.Register(eventlog.Registration{ContractKey: EventsContractKey, ABI: &eventsABI}); err != nil {
+		return []*eventlog.Registry{eventlog.DefaultRegistry}
+	}
+	return []*eventlog.Registry{eventlog.DefaultRegistry, registry}
+}
+
+// Returns the topics matching the logs of the filter:
+// [eventSignature, values of the 1st indexed argument, ...].
+func (f *EventFilter) topics() (topics, error) {
+	if f.Event.Anonymous {
+		return nil, ErrAnonymousEvent
+	}
+	var indexed abi.Arguments
+	for _, arg := range f.Event.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		}
+	}
+	if len(f.IndexedArgs) > len(indexed) {
+		return nil, ErrTooManyIndexedArgs
+	}
+
+	argTopics, err := abi.MakeTopics(f.IndexedArgs...)
+	if err != nil {
+		return nil, err
+	}
+	return trimTopics(append(topics{{f.Event.ID}}, argTopics...)), nil
+}
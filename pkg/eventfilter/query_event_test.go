@@ -0,0 +1,188 @@
+package eventfilter
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+const stakingABI = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"user","type":"address"},{"indexed":true,"name":"poolId","type":"uint256"},{"indexed":false,"name":"amount","type":"uint256"}],"name":"Staked","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"user","type":"address"},{"indexed":true,"name":"poolId","type":"uint256"},{"indexed":false,"name":"amount","type":"uint256"}],"name":"Unstaked","type":"event"},
+	{"anonymous":false,"inputs":[{"indexed":true,"name":"poolId","type":"uint256"}],"name":"PoolClosed","type":"event"},
+	{"anonymous":true,"inputs":[{"indexed":true,"name":"user","type":"address"}],"name":"Ping","type":"event"}
+]`
+
+func loadStakingABI(t *testing.T) *abi.ABI {
+	t.Helper()
+	contractABI, err := abi.JSON(strings.NewReader(stakingABI))
+	require.NoError(t, err)
+	return &contractABI
+}
+
+func TestEventQueryConfig_ToFilterQueries(t *testing.T) {
+	contractABI := loadStakingABI(t)
+	staked, unstaked, closed := contractABI.Events["Staked"], contractABI.Events["Unstaked"], contractABI.Events["PoolClosed"]
+	user1 := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	user2 := common.HexToAddress("0x9876543210987654321098765432109876543210")
+	userTopics := []common.Hash{common.BytesToHash(user1.Bytes()), common.BytesToHash(user2.Bytes())}
+	contract := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	config := EventQueryConfig{
+		FromBlock:         big.NewInt(100),
+		ToBlock:           big.NewInt(200),
+		ContractAddresses: []common.Address{contract},
+		Events: []EventFilter{
+			// Same users for both: merged
+			{Event: staked, IndexedArgs: [][]any{{user1, user2}}},
+			{Event: unstaked, IndexedArgs: [][]any{{user1, user2}, {}}},
+			// Pools 1 or 2
+			{Event: closed, IndexedArgs: [][]any{{big.NewInt(1), big.NewInt(2)}}},
+		},
+	}
+	require.NoError(t, config.Validate())
+
+	queries := config.ToFilterQueries()
+	require.Len(t, queries, 2)
+	for _, query := range queries {
+		assert.Equal(t, big.NewInt(100), query.FromBlock)
+		assert.Equal(t, big.NewInt(200), query.ToBlock)
+		assert.Equal(t, []common.Address{contract}, query.Addresses)
+	}
+	assert.Equal(t, [][]common.Hash{{staked.ID, unstaked.ID}, userTopics}, queries[0].Topics)
+	assert.Equal(t, [][]common.Hash{{closed.ID}, {common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(2))}}, queries[1].Topics)
+
+	// Events without filters need a single query
+	config.Events = []EventFilter{{Event: staked}, {Event: unstaked}, {Event: closed}}
+	queries = config.ToFilterQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, [][]common.Hash{{staked.ID, unstaked.ID, closed.ID}}, queries[0].Topics)
+
+	// A filter on the second indexed argument only
+	config.Events = []EventFilter{{Event: staked, IndexedArgs: [][]any{nil, {big.NewInt(7)}}}}
+	queries = config.ToFilterQueries()
+	require.Len(t, queries, 1)
+	assert.Equal(t, [][]common.Hash{{staked.ID}, nil, {common.BigToHash(big.NewInt(7))}}, queries[0].Topics)
+}
+
+func TestEventQueryConfig_Validate(t *testing.T) {
+	contractABI := loadStakingABI(t)
+	staked := contractABI.Events["Staked"]
+
+	tests := []struct {
+		name   string
+		config EventQueryConfig
+		err    error
+	}{
+		{"no events", EventQueryConfig{}, ErrNoEvents},
+		{"anonymous", EventQueryConfig{Events: []EventFilter{{Event: contractABI.Events["Ping"]}}}, ErrAnonymousEvent},
+		{"too many indexed args", EventQueryConfig{Events: []EventFilter{{Event: staked, IndexedArgs: [][]any{{}, {}, {}}}}}, ErrTooManyIndexedArgs},
+		{"block range", EventQueryConfig{FromBlock: big.NewInt(2), ToBlock: big.NewInt(1), Events: []EventFilter{{Event: staked}}}, ErrInvalidBlockRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.config.Validate(), tt.err)
+			assert.Nil(t, tt.config.ToFilterQueries())
+		})
+	}
+
+	// Values that can't be topics
+	config := EventQueryConfig{Events: []EventFilter{{Event: staked, IndexedArgs: [][]any{{struct{}{}}}}}}
+	assert.Error(t, config.Validate())
+}
+
+func TestFilterEvents_Registry(t *testing.T) {
+	contractABI := loadStakingABI(t)
+	staked, closed := contractABI.Events["Staked"], contractABI.Events["PoolClosed"]
+	user := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+
+	stakedLog := func(block uint64, user common.Address, amount int64) types.Log {
+		data, err := staked.Inputs.NonIndexed().Pack(big.NewInt(amount))
+		require.NoError(t, err)
+		return types.Log{
+			BlockNumber: block,
+			Topics:      []common.Hash{staked.ID, common.BytesToHash(user.Bytes()), common.BigToHash(big.NewInt(1))},
+			Data:        data,
+		}
+	}
+	client := &fakeLogsClient{
+		logs: []types.Log{
+			stakedLog(12, user, 20),
+			stakedLog(10, user, 10),
+			stakedLog(11, other, 30),
+			{BlockNumber: 13, Topics: []common.Hash{closed.ID, common.BigToHash(big.NewInt(1))}},
+		},
+	}
+
+	registry := eventlog.NewRegistry()
+	require.NoError(t, registry.Register(eventlog.Registration{ContractKey: "staking", ABI: contractABI}))
+
+	events, err := FilterEvents(context.Background(), client, EventQueryConfig{
+		FromBlock: big.NewInt(0),
+		ToBlock:   big.NewInt(20),
+		Events: []EventFilter{
+			{Event: staked, IndexedArgs: [][]any{{user}}},
+			{Event: closed},
+		},
+		Registry: registry,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+	require.Len(t, client.queries, 2)
+
+	var amounts []int64
+	for _, event := range events[:2] {
+		assert.Equal(t, eventlog.EventKey("stakingstaked"), event.EventKey)
+		data := event.Unpacked.(eventlog.ABIEventData)
+		assert.Equal(t, user, data.Fields["user"])
+		amounts = append(amounts, data.Fields["amount"].(*big.Int).Int64())
+	}
+	// Ordered by block
+	assert.Equal(t, []int64{10, 20}, amounts)
+	assert.Equal(t, eventlog.EventKey("stakingpoolclosed"), events[2].EventKey)
+
+	// Not registered in the default registry, parsed from the event ABIs
+	events, err = FilterEvents(context.Background(), client, EventQueryConfig{
+		FromBlock: big.NewInt(0),
+		ToBlock:   big.NewInt(20),
+		Events:    []EventFilter{{Event: staked}, {Event: closed}},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, EventsContractKey, events[0].ContractKey)
+	assert.Equal(t, eventlog.EventKey("eventsstaked"), events[0].EventKey)
+	assert.Equal(t, big.NewInt(10), events[0].Unpacked.(eventlog.ABIEventData).Fields["amount"])
+	assert.Equal(t, eventlog.EventKey("eventspoolclosed"), events[3].EventKey)
+
+	// Standard events are still parsed by the default registry
+	erc20ABI, err := erc20.Erc20MetaData.GetAbi()
+	require.NoError(t, err)
+	transfer := erc20ABI.Events["Transfer"]
+	client.logs = append(client.logs, types.Log{
+		BlockNumber: 14,
+		Topics:      []common.Hash{transfer.ID, common.BytesToHash(user.Bytes()), common.BytesToHash(other.Bytes())},
+		Data:        common.BigToHash(big.NewInt(5)).Bytes(),
+	})
+	events, err = FilterEvents(context.Background(), client, EventQueryConfig{
+		FromBlock: big.NewInt(14),
+		ToBlock:   big.NewInt(14),
+		Events:    []EventFilter{{Event: transfer}},
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, eventlog.ERC20, events[0].ContractKey)
+
+	_, err = FilterEvents(context.Background(), client, EventQueryConfig{})
+	assert.ErrorIs(t, err, ErrNoEvents)
+}
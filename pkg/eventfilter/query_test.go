@@ -6,6 +6,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
 )
 
 func TestToFilterQueries_QueryCount(t *testing.T) {
@@ -380,3 +383,56 @@ func TestToFilterQueries_QueryStructure(t *testing.T) {
 		assert.Len(t, query3.Topics, 4)
 	})
 }
+
+func TestToFilterQueries_WrappedNative(t *testing.T) {
+	account := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	wrapped := common.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	accountTopics := []common.Hash{common.BytesToHash(account.Bytes())}
+
+	tests := []struct {
+		name       string
+		direction  Direction
+		signatures []common.Hash
+	}{
+		{"send", Send, []common.Hash{eventlog.WETHWithdrawalID}},
+		{"receive", Receive, []common.Hash{eventlog.WETHDepositID}},
+		{"both", Both, []common.Hash{eventlog.WETHDepositID, eventlog.WETHWithdrawalID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := TransferQueryConfig{
+				Accounts:      []common.Address{account},
+				TransferTypes: []TransferType{TransferTypeERC20},
+				Direction:     tt.direction,
+				WrappedNative: []common.Address{wrapped},
+			}
+			withoutWrapped := config
+			withoutWrapped.WrappedNative = nil
+
+			queries := config.ToFilterQueries()
+			require.Len(t, queries, len(withoutWrapped.ToFilterQueries())+1)
+			query := queries[len(queries)-1]
+			assert.Equal(t, []common.Address{wrapped}, query.Addresses)
+			assert.Equal(t, [][]common.Hash{tt.signatures, accountTopics}, query.Topics)
+		})
+	}
+
+	// Only with ERC20 transfers, and within the contract addresses
+	config := TransferQueryConfig{
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC721},
+		Direction:     Both,
+		WrappedNative: []common.Address{wrapped},
+	}
+	assert.Len(t, config.ToFilterQueries(), 2)
+
+	config.TransferTypes = []TransferType{TransferTypeERC20}
+	config.ContractAddresses = []common.Address{token}
+	assert.Len(t, config.ToFilterQueries(), 2)
+
+	config.ContractAddresses = []common.Address{token, wrapped}
+	queries := config.ToFilterQueries()
+	require.Len(t, queries, 3)
+	assert.Equal(t, []common.Address{wrapped}, queries[2].Addresses)
+}
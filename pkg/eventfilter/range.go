@@ -0,0 +1,290 @@
+package eventfilter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// RangeLimits bounds the eth_getLogs queries sent for a block range.
+type RangeLimits struct {
+	// Maximum number of blocks per query, 0 for no limit. Longer ranges are
+	// split into consecutive sub-ranges up front.
+	MaxBlockRange uint64
+	// Maximum number of queries in flight, 0 for no limit
+	Concurrency int
+	// Reports whether a query failed because its range or result set was too
+	// large, in which case the range is bisected and retried. Defaults to
+	// IsRangeTooLargeError.
+	IsRangeError func(error) bool
+}
+
+var (
+	// DefaultRangeLimits fits the most restrictive of the common providers.
+	DefaultRangeLimits = RangeLimits{MaxBlockRange: 10000, Concurrency: 4}
+	// Infura rejects queries returning more than 10000 results.
+	InfuraRangeLimits = RangeLimits{MaxBlockRange: 10000, Concurrency: 4}
+	// Alchemy accepts any range returning up to 10000 results, or 2000 blocks
+	// with any number of results.
+	AlchemyRangeLimits = RangeLimits{MaxBlockRange: 2000, Concurrency: 4}
+	// QuickNode limits queries to 10000 blocks.
+	QuickNodeRangeLimits = RangeLimits{MaxBlockRange: 10000, Concurrency: 4}
+)
+
+// RangeLimitsForURL returns the limits of the provider of an RPC URL, or
+// DefaultRangeLimits for unknown providers.
+func RangeLimitsForURL(rpcURL string) RangeLimits {
+	u, err := url.Parse(rpcURL)
+	if err != nil {
+		return DefaultRangeLimits
+	}
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case strings.HasSuffix(host, "infura.io"):
+		return InfuraRangeLimits
+	case strings.HasSuffix(host, "alchemy.com"):
+		return AlchemyRangeLimits
+	case strings.HasSuffix(host, "quiknode.pro"):
+		return QuickNodeRangeLimits
+	}
+	return DefaultRangeLimits
+}
+
+// Error messages of providers rejecting a range or a result set as too large
+var rangeErrorMessages = []string{
+	"query returned more than",   // geth, Infura
+	"log response size exceeded", // Alchemy
+	"block range too large",
+	"block range is too large",
+	"block range is too wide",
+	"exceed maximum block range",
+	"exceeds maximum block range",
+	"is limited to a", // QuickNode: "eth_getLogs is limited to a 10,000 range"
+	"range limit exceeded",
+	"too many blocks",
+	"query exceeds max results",
+}
+
+// IsRangeTooLargeError reports whether err is a provider rejecting an
+// eth_getLogs query because of its block range or number of results.
+func IsRangeTooLargeError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, m := range rangeErrorMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// Optional FilterClient method, used to resolve a nil ToBlock to the head so
+// the range can be split.
+type blockNumberClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Runs queries over sub-ranges of their block range, bisecting the ranges
+// rejected as too large, and delivers the result of every sub-range.
+type rangeFilter struct {
+	client FilterClient
+	limits RangeLimits
+	sem    chan struct{}
+	// Called with the result of each sub-range that is not bisected, holding
+	// the concurrency slot of the query until it returns
+	deliver func(ctx context.Context, query ethereum.FilterQuery, logs []types.Log, err error)
+
+	wg sync.WaitGroup
+}
+
+func newRangeFilter(client FilterClient, limits RangeLimits, deliver func(context.Context, ethereum.FilterQuery, []types.Log, error)) *rangeFilter {
+	f := &rangeFilter{
+		client:  client,
+		limits:  limits,
+		deliver: deliver,
+	}
+	if limits.Concurrency > 0 {
+		f.sem = make(chan struct{}, limits.Concurrency)
+	}
+	return f
+}
+
+// Runs the queries over sub-ranges of at most limits.MaxBlockRange blocks,
+// and waits for all results to be delivered. Returns an error only if the
+// queries couldn't be split, in which case none is run.
+func (f *rangeFilter) run(ctx context.Context, queries []ethereum.FilterQuery) error {
+	var split []ethereum.FilterQuery
+	var head *uint64
+	for _, query := range queries {
+		from, to, ok := queryRange(query)
+		if !ok && isOpenEnded(query) {
+			// Split the range up to the current head, if the client can tell it
+			if head == nil {
+				if bnClient, isBNClient := f.client.(blockNumberClient); isBNClient {
+					number, err := bnClient.BlockNumber(ctx)
+					if err != nil {
+						return err
+					}
+					head = &number
+				}
+			}
+			if head != nil {
+				from, to, ok = 0, *head, true
+				if query.FromBlock != nil {
+					from = query.FromBlock.Uint64()
+				}
+			}
+		}
+		if !ok {
+			split = append(split, query)
+			continue
+		}
+		if from > to {
+			continue
+		}
+		for _, r := range splitRange(from, to, f.limits.MaxBlockRange) {
+			split = append(split, withRange(query, r[0], r[1]))
+		}
+	}
+
+	for _, query := range split {
+		f.start(ctx, query)
+	}
+	f.wg.Wait()
+	return nil
+}
+
+// Runs the queries and returns all logs, or the first error, stopping the
+// other queries.
+func filterLogs(ctx context.Context, client FilterClient, queries []ethereum.FilterQuery, limits RangeLimits) ([]types.Log, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu      sync.Mutex
+		allLogs []types.Log
+		logsErr error
+	)
+	f := newRangeFilter(client, limits, func(ctx context.Context, query ethereum.FilterQuery, logs []types.Log, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if logsErr == nil {
+				logsErr = err
+				cancel()
+			}
+			return
+		}
+		allLogs = append(allLogs, logs...)
+	})
+	if err := f.run(ctx, queries); err != nil {
+		return nil, err
+	}
+
+	if logsErr != nil {
+		return nil, logsErr
+	}
+	return allLogs, nil
+}
+
+func (f *rangeFilter) start(ctx context.Context, query ethereum.FilterQuery) {
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		if !f.acquire(ctx) {
+			f.deliver(ctx, query, nil, ctx.Err())
+			return
+		}
+		logs, err := f.client.FilterLogs(ctx, query)
+		if err != nil && f.isRangeError(err) {
+			if from, to, ok := queryRange(query); ok && from < to {
+				f.release()
+				mid := from + (to-from)/2
+				f.start(ctx, withRange(query, from, mid))
+				f.start(ctx, withRange(query, mid+1, to))
+				return
+			}
+		}
+		f.deliver(ctx, query, logs, err)
+		f.release()
+	}()
+}
+
+// Waits for a concurrency slot, reports false if ctx is done first.
+func (f *rangeFilter) acquire(ctx context.Context) bool {
+	if f.sem == nil {
+		return true
+	}
+	select {
+	case f.sem <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (f *rangeFilter) release() {
+	if f.sem != nil {
+		<-f.sem
+	}
+}
+
+func (f *rangeFilter) isRangeError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if f.limits.IsRangeError != nil {
+		return f.limits.IsRangeError(err)
+	}
+	return IsRangeTooLargeError(err)
+}
+
+// Reports whether a query runs from a block number up to the latest block.
+func isOpenEnded(query ethereum.FilterQuery) bool {
+	return query.BlockHash == nil && query.ToBlock == nil && (query.FromBlock == nil || query.FromBlock.Sign() >= 0)
+}
+
+// Returns the block range of a query, if both ends are block numbers.
+func queryRange(query ethereum.FilterQuery) (uint64, uint64, bool) {
+	if query.BlockHash != nil || query.ToBlock == nil || query.ToBlock.Sign() < 0 {
+		return 0, 0, false
+	}
+	from := uint64(0)
+	if query.FromBlock != nil {
+		if query.FromBlock.Sign() < 0 {
+			return 0, 0, false
+		}
+		from = query.FromBlock.Uint64()
+	}
+	return from, query.ToBlock.Uint64(), true
+}
+
+func withRange(query ethereum.FilterQuery, from uint64, to uint64) ethereum.FilterQuery {
+	query.FromBlock = new(big.Int).SetUint64(from)
+	query.ToBlock = new(big.Int).SetUint64(to)
+	return query
+}
+
+// Splits [from, to] into consecutive ranges of at most maxRange blocks.
+func splitRange(from uint64, to uint64, maxRange uint64) [][2]uint64 {
+	if maxRange == 0 || to-from < maxRange {
+		return [][2]uint64{{from, to}}
+	}
+	var ret [][2]uint64
+	for start := from; start <= to; start += maxRange {
+		end := min(start+maxRange-1, to)
+		ret = append(ret, [2]uint64{start, end})
+		if end == to {
+			break
+		}
+	}
+	return ret
+}
@@ -0,0 +1,310 @@
+package eventfilter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+// Serves logs by block range and topics, like a provider with a result limit.
+type fakeLogsClient struct {
+	logs       []types.Log
+	maxResults int
+	head       uint64
+
+	mu          sync.Mutex
+	queries     []ethereum.FilterQuery
+	inFlight    int
+	maxInFlight int
+	release     chan struct{}
+}
+
+func (c *fakeLogsClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	c.mu.Lock()
+	c.queries = append(c.queries, query)
+	c.inFlight++
+	c.maxInFlight = max(c.maxInFlight, c.inFlight)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.inFlight--
+		c.mu.Unlock()
+	}()
+	if c.release != nil {
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	from, to := uint64(0), c.head
+	if query.FromBlock != nil {
+		from = query.FromBlock.Uint64()
+	}
+	if query.ToBlock != nil {
+		to = query.ToBlock.Uint64()
+	}
+	var ret []types.Log
+	for _, log := range c.logs {
+		if log.BlockNumber < from || log.BlockNumber > to || !matchTopics(log, query.Topics) {
+			continue
+		}
+		ret = append(ret, log)
+	}
+	if c.maxResults > 0 && len(ret) > c.maxResults {
+		return nil, fmt.Errorf("query returned more than %d results", c.maxResults)
+	}
+	// Newest first, so that ordering is left to the filter
+	slices.Reverse(ret)
+	return ret, nil
+}
+
+func (c *fakeLogsClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func matchTopics(log types.Log, topics [][]common.Hash) bool {
+	for i, set := range topics {
+		if len(set) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) || !slices.Contains(set, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func transferLog(block uint64, index uint, from, to common.Address) types.Log {
+	return types.Log{
+		Address:     common.HexToAddress("0x1000000000000000000000000000000000000001"),
+		Topics:      []common.Hash{eventlog.ERC20TransferID, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+		Data:        common.LeftPadBytes(big.NewInt(1).Bytes(), 32),
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+func transferBlocks(t *testing.T, events []eventlog.Event) [][2]uint64 {
+	t.Helper()
+	ret := make([][2]uint64, 0, len(events))
+	for _, event := range events {
+		transfer, ok := event.Unpacked.(erc20.Erc20Transfer)
+		require.True(t, ok)
+		ret = append(ret, [2]uint64{transfer.Raw.BlockNumber, uint64(transfer.Raw.Index)})
+	}
+	return ret
+}
+
+func TestSplitRange(t *testing.T) {
+	assert.Equal(t, [][2]uint64{{5, 10}}, splitRange(5, 10, 0))
+	assert.Equal(t, [][2]uint64{{5, 10}}, splitRange(5, 10, 6))
+	assert.Equal(t, [][2]uint64{{5, 9}, {10, 10}}, splitRange(5, 10, 5))
+	assert.Equal(t, [][2]uint64{{0, 9999}, {10000, 19999}, {20000, 25000}}, splitRange(0, 25000, 10000))
+	assert.Equal(t, [][2]uint64{{7, 7}}, splitRange(7, 7, 1))
+}
+
+func TestFilterTransfers_SplitsRange(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	client := &fakeLogsClient{
+		logs: []types.Log{
+			transferLog(100, 0, account, other),
+			transferLog(15000, 3, other, account),
+			transferLog(15000, 1, account, other),
+			transferLog(25000, 0, other, account),
+		},
+	}
+
+	events, err := FilterTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(25000),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Both,
+	}, RangeLimits{MaxBlockRange: 10000})
+	require.NoError(t, err)
+
+	// Ordered by block and log index across the send and receive queries
+	assert.Equal(t, [][2]uint64{{100, 0}, {15000, 1}, {15000, 3}, {25000, 0}}, transferBlocks(t, events))
+
+	// 2 queries (send, receive) x 3 sub-ranges
+	require.Len(t, client.queries, 6)
+	for _, query := range client.queries {
+		assert.LessOrEqual(t, query.ToBlock.Uint64()-query.FromBlock.Uint64(), uint64(9999))
+	}
+}
+
+func TestFilterTransfers_BisectsTooManyResults(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	client := &fakeLogsClient{maxResults: 2}
+	for block := uint64(1); block <= 9; block++ {
+		client.logs = append(client.logs, transferLog(block, 0, other, account))
+	}
+
+	events, err := FilterTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(1),
+		ToBlock:       big.NewInt(9),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{Concurrency: 2})
+	require.NoError(t, err)
+
+	blocks := transferBlocks(t, events)
+	require.Len(t, blocks, 9)
+	for i, block := range blocks {
+		assert.Equal(t, uint64(i+1), block[0])
+	}
+	assert.Greater(t, len(client.queries), 1)
+}
+
+func TestFilterTransfers_SingleBlockTooLarge(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	client := &fakeLogsClient{
+		maxResults: 1,
+		logs: []types.Log{
+			transferLog(5, 0, other, account),
+			transferLog(5, 1, other, account),
+		},
+	}
+
+	_, err := FilterTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(1),
+		ToBlock:       big.NewInt(8),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{})
+	require.Error(t, err)
+	assert.True(t, IsRangeTooLargeError(err))
+}
+
+func TestFilterTransfers_OpenEndedRange(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	client := &fakeLogsClient{
+		head: 2500,
+		logs: []types.Log{
+			transferLog(1200, 0, other, account),
+			transferLog(2500, 0, other, account),
+		},
+	}
+
+	events, err := FilterTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(1000),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{MaxBlockRange: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{1200, 0}, {2500, 0}}, transferBlocks(t, events))
+
+	require.Len(t, client.queries, 2)
+	assert.ElementsMatch(t, []uint64{1999, 2500}, []uint64{client.queries[0].ToBlock.Uint64(), client.queries[1].ToBlock.Uint64()})
+}
+
+func TestFilterTransfers_Concurrency(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	client := &fakeLogsClient{release: make(chan struct{})}
+
+	done := make(chan error)
+	go func() {
+		_, err := FilterTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+			FromBlock:     big.NewInt(0),
+			ToBlock:       big.NewInt(99),
+			Accounts:      []common.Address{account},
+			TransferTypes: []TransferType{TransferTypeERC20},
+			Direction:     Both,
+		}, RangeLimits{MaxBlockRange: 10, Concurrency: 3})
+		done <- err
+	}()
+
+	// 2 queries x 10 sub-ranges, released one at a time
+	for range 20 {
+		client.release <- struct{}{}
+	}
+	require.NoError(t, <-done)
+	assert.Len(t, client.queries, 20)
+	assert.LessOrEqual(t, client.maxInFlight, 3)
+}
+
+func TestFilterTransfers_ErrorStopsQueries(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	errProvider := errors.New("internal error")
+	var calls int
+	var mu sync.Mutex
+	client := &errorAfterClient{
+		fakeLogsClient: &fakeLogsClient{},
+		fail: func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return calls == 2
+		},
+		err: errProvider,
+	}
+
+	_, err := FilterTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(999),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{MaxBlockRange: 10, Concurrency: 1})
+	assert.ErrorIs(t, err, errProvider)
+	// Not bisected, and the remaining sub-ranges are not queried
+	assert.Less(t, calls, 100)
+}
+
+type errorAfterClient struct {
+	*fakeLogsClient
+	fail func() bool
+	err  error
+}
+
+func (c *errorAfterClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if c.fail() {
+		return nil, c.err
+	}
+	return c.fakeLogsClient.FilterLogs(ctx, query)
+}
+
+func TestIsRangeTooLargeError(t *testing.T) {
+	for _, msg := range []string{
+		"query returned more than 10000 results",
+		"Log response size exceeded. You can make eth_getLogs requests with up to a 2K block range",
+		"eth_getLogs is limited to a 10,000 range",
+		"block range is too wide",
+		"exceed maximum block range: 5000",
+	} {
+		assert.True(t, IsRangeTooLargeError(errors.New(msg)), msg)
+	}
+	assert.False(t, IsRangeTooLargeError(nil))
+	assert.False(t, IsRangeTooLargeError(errors.New("429 Too Many Requests")))
+	assert.False(t, IsRangeTooLargeError(context.Canceled))
+}
+
+func TestRangeLimitsForURL(t *testing.T) {
+	assert.Equal(t, InfuraRangeLimits, RangeLimitsForURL("https://mainnet.infura.io/v3/key"))
+	assert.Equal(t, AlchemyRangeLimits, RangeLimitsForURL("https://eth-mainnet.g.alchemy.com/v2/key"))
+	assert.Equal(t, QuickNodeRangeLimits, RangeLimitsForURL("https://example.quiknode.pro/key/"))
+	assert.Equal(t, DefaultRangeLimits, RangeLimitsForURL("http://localhost:8545"))
+	assert.Equal(t, DefaultRangeLimits, RangeLimitsForURL("://bad"))
+}
@@ -0,0 +1,79 @@
+package eventfilter
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// ERC165StandardResolver resolves the standard of contracts with ERC165
+// supportsInterface calls, batched through Multicall3: contracts supporting
+// ERC721 are ERC721. Other contracts are left unresolved, including ERC165
+// contracts not supporting ERC721 (e.g. ERC1155) and contracts reporting
+// support for the invalid interface 0xffffffff, which don't implement ERC165.
+type ERC165StandardResolver struct {
+	caller    multicall.Caller
+	batchSize int
+}
+
+func NewERC165StandardResolver(caller multicall.Caller, batchSize int) *ERC165StandardResolver {
+	return &ERC165StandardResolver{
+		caller:    caller,
+		batchSize: batchSize,
+	}
+}
+
+// ResolveStandards returns an error only if none of the contracts could be
+// read.
+func (r *ERC165StandardResolver) ResolveStandards(ctx context.Context, contracts []common.Address) (map[common.Address]TransferType, error) {
+	ret := make(map[common.Address]TransferType, len(contracts))
+	if len(contracts) == 0 {
+		return ret, nil
+	}
+
+	jobs := make([]multicall.Job, 0, len(contracts))
+	for _, contract := range contracts {
+		jobs = append(jobs, multicall.Job{
+			Calls: []multicall3.IMulticall3Call{
+				multicall.BuildSupportsInterfaceCall(contract, multicall.ERC165InterfaceID),
+				multicall.BuildSupportsInterfaceCall(contract, multicall.InvalidInterfaceID),
+				multicall.BuildSupportsInterfaceCall(contract, multicall.ERC721InterfaceID),
+			},
+			CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+				return result, nil
+			},
+		})
+	}
+	results := multicall.RunSync(ctx, jobs, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), r.caller, r.batchSize)
+
+	var errs []error
+	read := false
+	for i, contract := range contracts {
+		result := results[i]
+		if result.Err == nil && len(result.Results) != 3 {
+			result.Err = errors.New("unexpected number of call results")
+		}
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+			continue
+		}
+		read = true
+		erc165Result, _ := result.Results[0].Value.(multicall3.IMulticall3Result)
+		invalidResult, _ := result.Results[1].Value.(multicall3.IMulticall3Result)
+		erc721Result, _ := result.Results[2].Value.(multicall3.IMulticall3Result)
+		supportsERC165, _ := multicall.ProcessSupportsInterfaceResults(erc165Result, invalidResult)
+		supportsERC721, _ := multicall.ProcessSupportsInterfaceResult(erc721Result)
+		if supportsERC165 && supportsERC721 {
+			ret[contract] = TransferTypeERC721
+		}
+	}
+	if !read {
+		return nil, errors.Join(errs...)
+	}
+	return ret, nil
+}
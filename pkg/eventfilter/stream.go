@@ -0,0 +1,147 @@
+package eventfilter
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+// RangeResult is the result of the query of a sub-range.
+type RangeResult struct {
+	// Sub-range query, FromBlock and ToBlock are the block range covered
+	Query ethereum.FilterQuery
+	// Parsed events, ordered by block number and log index
+	Events []eventlog.Event
+	// Set if the query of the sub-range failed, the other sub-ranges are
+	// still queried
+	Err error
+}
+
+// StreamTransfers runs the queries of config with DefaultRangeLimits and
+// sends the parsed events of each sub-range on the returned channel as they
+// arrive, see StreamTransfersWithLimits.
+func StreamTransfers(ctx context.Context, client FilterClient, config TransferQueryConfig) <-chan RangeResult {
+	return StreamTransfersWithLimits(ctx, client, config, DefaultRangeLimits)
+}
+
+// StreamTransfersWithLimits is StreamTransfers with the range limits of a provider.
+// A RangeResult is sent for each sub-range, in the order the queries complete:
+// events are only ordered within a sub-range. Logs already sent in another
+// sub-range (e.g. a self-transfer matched by both the send and the receive
+// query of Direction Both) are not sent again. A query keeps its concurrency
+// slot until its result is received, so at most limits.Concurrency results
+// are held in memory while the receiver is busy.
+// The channel is closed when all results have been sent, or once ctx is done.
+func StreamTransfersWithLimits(ctx context.Context, client FilterClient, config TransferQueryConfig, limits RangeLimits) <-chan RangeResult {
+	return streamEvents(ctx, client, config.ToFilterQueries(), limits)
+}
+
+// Identifies a log across sub-ranges.
+type streamLogKey struct {
+	blockNumber uint64
+	blockHash   common.Hash
+	index       uint
+}
+
+// Drops the logs already sent by another query. Only the logs matching
+// several queries are remembered, until every query matching them returned
+// them, so memory is bounded by the overlapping logs in flight rather than by
+// the whole range (thread-safe for concurrent access).
+type overlapFilter struct {
+	queries []ethereum.FilterQuery
+	mu      sync.Mutex
+	pending map[streamLogKey]int // Number of queries yet to return the log
+}
+
+func newOverlapFilter(queries []ethereum.FilterQuery) *overlapFilter {
+	return &overlapFilter{
+		queries: queries,
+		pending: make(map[streamLogKey]int),
+	}
+}
+
+func (f *overlapFilter) unseen(logs []types.Log) []types.Log {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ret := make([]types.Log, 0, len(logs))
+	for _, log := range logs {
+		key := streamLogKey{log.BlockNumber, log.BlockHash, log.Index}
+		if remaining, ok := f.pending[key]; ok {
+			if remaining <= 1 {
+				delete(f.pending, key)
+			} else {
+				f.pending[key] = remaining - 1
+			}
+			continue
+		}
+		if n := f.matchingQueries(log); n > 1 {
+			f.pending[key] = n - 1
+		}
+		ret = append(ret, log)
+	}
+	return ret
+}
+
+// Returns the number of queries matching the log, regardless of their range.
+func (f *overlapFilter) matchingQueries(log types.Log) int {
+	n := 0
+	for _, query := range f.queries {
+		if matchesQuery(log, query) {
+			n++
+		}
+	}
+	return n
+}
+
+// Reports whether the addresses and topics of the query match the log.
+func matchesQuery(log types.Log, query ethereum.FilterQuery) bool {
+	if len(query.Addresses) > 0 && !slices.Contains(query.Addresses, log.Address) {
+		return false
+	}
+	if len(query.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, topics := range query.Topics {
+		if len(topics) > 0 && !slices.Contains(topics, log.Topics[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func streamEvents(ctx context.Context, client FilterClient, queries []ethereum.FilterQuery, limits RangeLimits) <-chan RangeResult {
+	resultsCh := make(chan RangeResult)
+
+	send := func(ctx context.Context, result RangeResult) {
+		select {
+		case resultsCh <- result:
+		case <-ctx.Done():
+		}
+	}
+
+	overlaps := newOverlapFilter(queries)
+	go func() {
+		defer close(resultsCh)
+		f := newRangeFilter(client, limits, func(ctx context.Context, query ethereum.FilterQuery, logs []types.Log, err error) {
+			if err != nil {
+				// Not reported once the receiver has given up
+				if ctx.Err() == nil {
+					send(ctx, RangeResult{Query: query, Err: err})
+				}
+				return
+			}
+			send(ctx, RangeResult{Query: query, Events: parseLogs(overlaps.unseen(logs), eventlog.DefaultRegistry)})
+		})
+		if err := f.run(ctx, queries); err != nil {
+			send(ctx, RangeResult{Err: err})
+		}
+	}()
+
+	return resultsCh
+}
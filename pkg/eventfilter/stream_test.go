@@ -0,0 +1,183 @@
+package eventfilter
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamTransfers_ResultPerRange(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	client := &fakeLogsClient{
+		logs: []types.Log{
+			transferLog(5, 1, other, account),
+			transferLog(5, 0, other, account),
+			transferLog(15, 0, other, account),
+			transferLog(25, 0, account, other),
+		},
+	}
+
+	resultsCh := StreamTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(29),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{MaxBlockRange: 10, Concurrency: 2})
+
+	blocks := make(map[uint64][][2]uint64)
+	for result := range resultsCh {
+		require.NoError(t, result.Err)
+		blocks[result.Query.FromBlock.Uint64()] = transferBlocks(t, result.Events)
+	}
+	assert.Equal(t, map[uint64][][2]uint64{
+		0:  {{5, 0}, {5, 1}},
+		10: {{15, 0}},
+		20: {},
+	}, blocks)
+}
+
+func TestStreamTransfers_DedupAcrossRanges(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	client := &fakeLogsClient{
+		logs: []types.Log{
+			transferLog(5, 0, other, account),
+			// Self-transfer, matched by both the send and the receive query
+			transferLog(15, 0, account, account),
+			transferLog(25, 0, account, other),
+		},
+	}
+
+	resultsCh := StreamTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(29),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Both,
+	}, RangeLimits{MaxBlockRange: 10, Concurrency: 2})
+
+	var blocks [][2]uint64
+	for result := range resultsCh {
+		require.NoError(t, result.Err)
+		blocks = append(blocks, transferBlocks(t, result.Events)...)
+	}
+	assert.ElementsMatch(t, [][2]uint64{{5, 0}, {15, 0}, {25, 0}}, blocks)
+
+	// Same events as FilterTransfers
+	events, err := FilterTransfers(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(29),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Both,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][2]uint64{{5, 0}, {15, 0}, {25, 0}}, transferBlocks(t, events))
+}
+
+func TestOverlapFilter(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	config := TransferQueryConfig{
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Both,
+	}
+	received := transferLog(5, 0, other, account)
+	self := transferLog(15, 0, account, account)
+
+	f := newOverlapFilter(config.ToFilterQueries())
+	// Logs matching a single query aren't remembered
+	assert.Equal(t, []types.Log{received, self}, f.unseen([]types.Log{received, self}))
+	assert.Len(t, f.pending, 1)
+
+	// Forgotten once the other query returned it
+	assert.Empty(t, f.unseen([]types.Log{self}))
+	assert.Empty(t, f.pending)
+}
+
+func TestStreamTransfers_ErrorPerRange(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	other := common.HexToAddress("0xbbbb")
+	errProvider := errors.New("internal error")
+	var calls int
+	var mu sync.Mutex
+	client := &errorAfterClient{
+		fakeLogsClient: &fakeLogsClient{
+			logs: []types.Log{
+				transferLog(5, 0, other, account),
+				transferLog(15, 0, other, account),
+				transferLog(25, 0, other, account),
+			},
+		},
+		fail: func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			calls++
+			return calls == 2
+		},
+		err: errProvider,
+	}
+
+	resultsCh := StreamTransfersWithLimits(context.Background(), client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(29),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{MaxBlockRange: 10, Concurrency: 1})
+
+	var failed, succeeded int
+	for result := range resultsCh {
+		if result.Err != nil {
+			assert.ErrorIs(t, result.Err, errProvider)
+			assert.NotNil(t, result.Query.FromBlock)
+			failed++
+			continue
+		}
+		assert.Len(t, result.Events, 1)
+		succeeded++
+	}
+	// The other ranges are still queried
+	assert.Equal(t, 1, failed)
+	assert.Equal(t, 2, succeeded)
+}
+
+func TestStreamTransfers_Backpressure(t *testing.T) {
+	account := common.HexToAddress("0xaaaa")
+	client := &fakeLogsClient{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resultsCh := StreamTransfersWithLimits(ctx, client, TransferQueryConfig{
+		FromBlock:     big.NewInt(0),
+		ToBlock:       big.NewInt(99),
+		Accounts:      []common.Address{account},
+		TransferTypes: []TransferType{TransferTypeERC20},
+		Direction:     Receive,
+	}, RangeLimits{MaxBlockRange: 10, Concurrency: 1})
+
+	<-resultsCh
+	time.Sleep(50 * time.Millisecond)
+	// The next query waits for its result to be received before another one starts
+	client.mu.Lock()
+	assert.Len(t, client.queries, 2)
+	client.mu.Unlock()
+
+	// Stops querying once ctx is cancelled
+	cancel()
+	for range resultsCh {
+	}
+	client.mu.Lock()
+	assert.Less(t, len(client.queries), 10)
+	client.mu.Unlock()
+}
@@ -13,10 +13,6 @@ var (
 	errEventSignatureMismatch = errors.New("event signature mismatch")
 )
 
-type logParser func(log types.Log) *Event
-
-var parsersMap map[ContractKey]logParser = make(map[ContractKey]logParser)
-
 func getABI(meta *bind.MetaData) *abi.ABI {
 	abi, err := meta.GetAbi()
 	if err != nil {
@@ -1,5 +1,5 @@
-// Package eventlog detects and parses standard ERC20, ERC721 and ERC1155 events
-// from raw Ethereum logs.
+// Package eventlog detects and parses standard ERC20, ERC721, ERC1155 and
+// wrapped native token (WETH) events from raw Ethereum logs.
 //
 // Parsed events provide type-safe access to decoded fields via the Event.Unpacked
 // payload.
@@ -20,10 +20,6 @@ var ERC1155TransferBatchID common.Hash = common.HexToHash("0x4a39dc06d4c0dbc64b7
 var ERC1155ApprovalForAllID common.Hash = common.HexToHash("0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31")
 var ERC1155URIID common.Hash = common.HexToHash("0x6bb7ff708619ba0610cba295a58592e0451dee2622938c8755667688daf3529b")
 
-func init() {
-	parsersMap[ERC1155] = ParseLogERC1155
-}
-
 func ParseLogERC1155(log types.Log) *Event {
 	abi := getABI(erc1155.Erc1155MetaData)
 	event, _ := abi.EventByID(log.Topics[0])
@@ -16,10 +16,6 @@ const (
 var ERC20ApprovalID common.Hash = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")
 var ERC20TransferID common.Hash = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
 
-func init() {
-	parsersMap[ERC20] = ParseLogERC20
-}
-
 func ParseLogERC20(log types.Log) *Event {
 	abi := getABI(erc20.Erc20MetaData)
 	event, _ := abi.EventByID(log.Topics[0])
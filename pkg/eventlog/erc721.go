@@ -18,10 +18,6 @@ var ERC721ApprovalID common.Hash = common.HexToHash("0x8c5be1e5ebec7d5bd14f71427
 var ERC721ApprovalForAllID common.Hash = common.HexToHash("0x17307eab39ab6107e8899845ad3d59bd9653f200f220920489ca2b5937696c31")
 var ERC721TransferID common.Hash = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
 
-func init() {
-	parsersMap[ERC721] = ParseLogERC721
-}
-
 func ParseLogERC721(log types.Log) *Event {
 	abi := getABI(erc721.Erc721MetaData)
 	event, _ := abi.EventByID(log.Topics[0])
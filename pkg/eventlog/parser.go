@@ -16,16 +16,7 @@ type Event struct {
 	Unpacked    any
 }
 
+// ParseLog parses log with DefaultRegistry.
 func ParseLog(log types.Log) []Event {
-	ret := make([]Event, 0)
-
-	for _, parser := range parsersMap {
-		event := parser(log)
-		if event == nil {
-			continue
-		}
-		ret = append(ret, *event)
-
-	}
-	return ret
+	return DefaultRegistry.ParseLog(log)
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/weth"
 	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
 	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
 
@@ -46,6 +47,12 @@ var erc1155TransferSingleTxReceiptJSON string
 //go:embed testdata/erc1155_uri_tx_receipt.json
 var erc1155UriTxReceiptJSON string
 
+//go:embed testdata/weth_deposit_tx_receipt.json
+var wethDepositTxReceiptJSON string
+
+//go:embed testdata/weth_withdrawal_tx_receipt.json
+var wethWithdrawalTxReceiptJSON string
+
 // Helper function to load and parse a transaction receipt from JSON file
 func loadTransactionReceipt(receiptJSON string) (*ethclient.Receipt, error) {
 	var receipt ethclient.Receipt
@@ -389,6 +396,63 @@ func TestParseLog_ERC1155URI(t *testing.T) {
 	assert.Equal(t, log, uri.Raw)
 }
 
+func TestParseLog_WETHDeposit(t *testing.T) {
+	receipt, err := loadTransactionReceipt(wethDepositTxReceiptJSON)
+	require.NoError(t, err)
+	require.Len(t, receipt.Logs, 1)
+
+	log := *receipt.Logs[0]
+	events := eventlog.ParseLog(log)
+
+	require.Len(t, events, 1)
+	event := events[0]
+
+	// Verify event structure
+	assert.Equal(t, eventlog.WETH, event.ContractKey)
+	assert.Equal(t, eventlog.WETHDeposit, event.EventKey)
+	assert.Equal(t, eventlog.WETHDepositID, event.ABIEvent.ID)
+	assert.NotNil(t, event.ContractABI)
+
+	// Verify unpacked data
+	deposit, ok := event.Unpacked.(weth.WethDeposit)
+	require.True(t, ok, "Expected weth.WethDeposit, got %T", event.Unpacked)
+
+	expectedDst := common.HexToAddress("0x47f21fccc72f6de655827740b9dc9277c89350a7")
+	expectedWad := big.NewInt(500000000000000000) // 0.5 ETH
+
+	assert.Equal(t, expectedDst, deposit.Dst)
+	assert.Equal(t, expectedWad, deposit.Wad)
+	assert.Equal(t, log, deposit.Raw)
+}
+
+func TestParseLog_WETHWithdrawal(t *testing.T) {
+	receipt, err := loadTransactionReceipt(wethWithdrawalTxReceiptJSON)
+	require.NoError(t, err)
+	require.Len(t, receipt.Logs, 1)
+
+	log := *receipt.Logs[0]
+	events := eventlog.ParseLog(log)
+
+	require.Len(t, events, 1)
+	event := events[0]
+
+	// Verify event structure
+	assert.Equal(t, eventlog.WETH, event.ContractKey)
+	assert.Equal(t, eventlog.WETHWithdrawal, event.EventKey)
+	assert.Equal(t, eventlog.WETHWithdrawalID, event.ABIEvent.ID)
+
+	// Verify unpacked data
+	withdrawal, ok := event.Unpacked.(weth.WethWithdrawal)
+	require.True(t, ok, "Expected weth.WethWithdrawal, got %T", event.Unpacked)
+
+	expectedSrc := common.HexToAddress("0x4945ce2d1b5bd904cac839b7fdabafd19cab982b")
+	expectedWad := big.NewInt(1000000000000000000) // 1 ETH
+
+	assert.Equal(t, expectedSrc, withdrawal.Src)
+	assert.Equal(t, expectedWad, withdrawal.Wad)
+	assert.Equal(t, log, withdrawal.Raw)
+}
+
 func TestParseLog_UnknownEvent(t *testing.T) {
 	// Create a log with an unknown event signature
 	log := types.Log{
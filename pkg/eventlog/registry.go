@@ -0,0 +1,269 @@
+package eventlog
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	ErrEmptyContractKey      = errors.New("contract key is required")
+	ErrContractKeyRegistered = errors.New("contract key already registered")
+	ErrNoParser              = errors.New("either an ABI or a parser is required")
+	ErrABIAndParser          = errors.New("ABI and parser are mutually exclusive")
+	ErrNoABIEvents           = errors.New("ABI has no non-anonymous event")
+)
+
+// Parser parses a log into an event, or returns nil if the log isn't one of
+// its events.
+type Parser func(log types.Log) *Event
+
+// ABIEventData is the Unpacked payload of the events parsed from a registered
+// ABI.
+type ABIEventData struct {
+	// Indexed and non-indexed arguments by name
+	Fields map[string]any
+	Raw    types.Log
+}
+
+// Registration describes the events of a contract for Registry.Register.
+type Registration struct {
+	ContractKey ContractKey
+	// Parses the non-anonymous events of the ABI into ABIEventData, with the
+	// EventKey made of the contract key and the lowercase event name (e.g.
+	// "mytokenminted"). Exclusive with Parser.
+	ABI *abi.ABI
+	// Custom parser, exclusive with ABI
+	Parser Parser
+	// Event signatures (topic0) handled by Parser. When empty, Parser is
+	// called for every log. Ignored with ABI.
+	Topics []common.Hash
+	// Only parses the logs of these contracts, the logs of any contract when
+	// empty
+	Addresses []common.Address
+}
+
+type registryEntry struct {
+	seq       uint64 // Registration order
+	key       ContractKey
+	parser    Parser
+	topics    []common.Hash
+	addresses map[common.Address]bool
+}
+
+// Registry dispatches logs to the parsers registered for their event
+// signature (thread-safe for concurrent access).
+type Registry struct {
+	mu      sync.RWMutex
+	entries []*registryEntry // In registration order
+	seq     uint64
+	// Rebuilt on every change, never modified in place
+	byTopic  map[common.Hash][]*registryEntry
+	anyTopic []*registryEntry
+}
+
+// DefaultRegistry is used by ParseLog. It holds the standard parsers, see
+// NewStandardRegistry.
+var DefaultRegistry = NewStandardRegistry()
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		byTopic: make(map[common.Hash][]*registryEntry),
+	}
+}
+
+// NewStandardRegistry returns a registry with the ERC20, ERC721, ERC1155 and
+// WETH parsers.
+func NewStandardRegistry() *Registry {
+	r := NewRegistry()
+	for _, reg := range []Registration{
+		{ContractKey: ERC20, Parser: ParseLogERC20, Topics: []common.Hash{ERC20ApprovalID, ERC20TransferID}},
+		{ContractKey: ERC721, Parser: ParseLogERC721, Topics: []common.Hash{ERC721ApprovalID, ERC721ApprovalForAllID, ERC721TransferID}},
+		{ContractKey: ERC1155, Parser: ParseLogERC1155, Topics: []common.Hash{ERC1155TransferSingleID, ERC1155TransferBatchID, ERC1155ApprovalForAllID, ERC1155URIID}},
+		{ContractKey: WETH, Parser: ParseLogWETH, Topics: []common.Hash{WETHDepositID, WETHWithdrawalID}},
+	} {
+		if err := r.Register(reg); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register adds the parser of a contract. A log matching several contracts
+// gives an event for each of them, in registration order.
+func (r *Registry) Register(reg Registration) error {
+	if reg.ContractKey == "" {
+		return ErrEmptyContractKey
+	}
+	entry := &registryEntry{
+		key:    reg.ContractKey,
+		parser: reg.Parser,
+		topics: reg.Topics,
+	}
+	switch {
+	case reg.ABI != nil && reg.Parser != nil:
+		return ErrABIAndParser
+	case reg.ABI != nil:
+		entry.parser, entry.topics = abiParser(reg.ContractKey, reg.ABI)
+		if len(entry.topics) == 0 {
+			return ErrNoABIEvents
+		}
+	case reg.Parser == nil:
+		return ErrNoParser
+	}
+	if len(reg.Addresses) > 0 {
+		entry.addresses = make(map[common.Address]bool, len(reg.Addresses))
+		for _, address := range reg.Addresses {
+			entry.addresses[address] = true
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if slices.ContainsFunc(r.entries, func(e *registryEntry) bool { return e.key == reg.ContractKey }) {
+		return ErrContractKeyRegistered
+	}
+	r.seq++
+	entry.seq = r.seq
+	r.entries = append(r.entries, entry)
+	r.reindex()
+	return nil
+}
+
+// Unregister removes the parser of a contract, and reports whether it was
+// registered.
+func (r *Registry) Unregister(key ContractKey) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(r.entries)
+	r.entries = slices.DeleteFunc(r.entries, func(e *registryEntry) bool { return e.key == key })
+	if len(r.entries) == n {
+		return false
+	}
+	r.reindex()
+	return true
+}
+
+// ContractKeys returns the registered contracts, in registration order.
+func (r *Registry) ContractKeys() []ContractKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ret := make([]ContractKey, 0, len(r.entries))
+	for _, entry := range r.entries {
+		ret = append(ret, entry.key)
+	}
+	return ret
+}
+
+// ParseLog returns the events parsed from log by the parsers registered for
+// its event signature and address, in registration order.
+func (r *Registry) ParseLog(log types.Log) []Event {
+	ret := make([]Event, 0)
+
+	r.mu.RLock()
+	var candidates []*registryEntry
+	if len(log.Topics) > 0 {
+		candidates = r.byTopic[log.Topics[0]]
+	}
+	anyTopic := r.anyTopic
+	r.mu.RUnlock()
+	if len(anyTopic) > 0 {
+		candidates = mergeEntries(candidates, anyTopic)
+	}
+
+	for _, entry := range candidates {
+		if entry.addresses != nil && !entry.addresses[log.Address] {
+			continue
+		}
+		event := entry.parser(log)
+		if event == nil {
+			continue
+		}
+		ret = append(ret, *event)
+	}
+	return ret
+}
+
+// Rebuilds the topic index, must be called with mu held.
+func (r *Registry) reindex() {
+	byTopic := make(map[common.Hash][]*registryEntry)
+	var anyTopic []*registryEntry
+	for _, entry := range r.entries {
+		if len(entry.topics) == 0 {
+			anyTopic = append(anyTopic, entry)
+			continue
+		}
+		for _, topic := range entry.topics {
+			if !slices.Contains(byTopic[topic], entry) {
+				byTopic[topic] = append(byTopic[topic], entry)
+			}
+		}
+	}
+	r.byTopic = byTopic
+	r.anyTopic = anyTopic
+}
+
+// Merges two lists of entries sorted in registration order. Each entry is in
+// at most one of them.
+func mergeEntries(a, b []*registryEntry) []*registryEntry {
+	if len(a) == 0 {
+		return b
+	}
+	ret := make([]*registryEntry, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0].seq < b[0].seq {
+			ret, a = append(ret, a[0]), a[1:]
+		} else {
+			ret, b = append(ret, b[0]), b[1:]
+		}
+	}
+	ret = append(ret, a...)
+	return append(ret, b...)
+}
+
+// Returns the parser of the non-anonymous events of an ABI, with their
+// signatures.
+func abiParser(key ContractKey, contractABI *abi.ABI) (Parser, []common.Hash) {
+	var topics []common.Hash
+	for _, event := range contractABI.Events {
+		if !event.Anonymous {
+			topics = append(topics, event.ID)
+		}
+	}
+	parser := func(log types.Log) *Event {
+		if len(log.Topics) == 0 {
+			return nil
+		}
+		event, err := contractABI.EventByID(log.Topics[0])
+		if err != nil || event.Anonymous {
+			return nil
+		}
+		fields := make(map[string]any)
+		if err := event.Inputs.NonIndexed().UnpackIntoMap(fields, log.Data); err != nil {
+			return nil
+		}
+		var indexed abi.Arguments
+		for _, arg := range event.Inputs {
+			if arg.Indexed {
+				indexed = append(indexed, arg)
+			}
+		}
+		if err := abi.ParseTopicsIntoMap(fields, indexed, log.Topics[1:]); err != nil {
+			return nil
+		}
+		return &Event{
+			ContractKey: key,
+			ContractABI: contractABI,
+			EventKey:    EventKey(string(key) + strings.ToLower(event.Name)),
+			ABIEvent:    event,
+			Unpacked:    ABIEventData{Fields: fields, Raw: log},
+		}
+	}
+	return parser, topics
+}
@@ -0,0 +1,136 @@
+package eventlog_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+const mintableABI = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"to","type":"address"},{"indexed":false,"name":"amount","type":"uint256"}],"name":"Minted","type":"event"}]`
+
+var (
+	minter = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	holder = common.HexToAddress("0x47f21fccc72f6de655827740b9dc9277c89350a7")
+)
+
+func loadABI(t *testing.T, definition string) *abi.ABI {
+	t.Helper()
+	contractABI, err := abi.JSON(strings.NewReader(definition))
+	require.NoError(t, err)
+	return &contractABI
+}
+
+func mintedLog(t *testing.T, contractABI *abi.ABI, address common.Address) types.Log {
+	t.Helper()
+	data, err := contractABI.Events["Minted"].Inputs.NonIndexed().Pack(big.NewInt(42))
+	require.NoError(t, err)
+	return types.Log{
+		Address: address,
+		Topics:  []common.Hash{contractABI.Events["Minted"].ID, common.BytesToHash(holder.Bytes())},
+		Data:    data,
+	}
+}
+
+func TestRegistry_ABI(t *testing.T) {
+	contractABI := loadABI(t, mintableABI)
+	registry := eventlog.NewRegistry()
+	require.NoError(t, registry.Register(eventlog.Registration{
+		ContractKey: "mytoken",
+		ABI:         contractABI,
+		Addresses:   []common.Address{minter},
+	}))
+
+	log := mintedLog(t, contractABI, minter)
+	events := registry.ParseLog(log)
+	require.Len(t, events, 1)
+	assert.Equal(t, eventlog.ContractKey("mytoken"), events[0].ContractKey)
+	assert.Equal(t, eventlog.EventKey("mytokenminted"), events[0].EventKey)
+	assert.Equal(t, "Minted", events[0].ABIEvent.Name)
+
+	data, ok := events[0].Unpacked.(eventlog.ABIEventData)
+	require.True(t, ok, "got %T", events[0].Unpacked)
+	assert.Equal(t, holder, data.Fields["to"])
+	assert.Equal(t, big.NewInt(42), data.Fields["amount"])
+	assert.Equal(t, log, data.Raw)
+
+	// Other contracts emitting the same event are ignored
+	assert.Empty(t, registry.ParseLog(mintedLog(t, contractABI, common.HexToAddress("0x2"))))
+
+	// Wrong number of topics
+	log.Topics = log.Topics[:1]
+	assert.Empty(t, registry.ParseLog(log))
+}
+
+func TestRegistry_Scoped(t *testing.T) {
+	contractABI := loadABI(t, mintableABI)
+	registry := eventlog.NewStandardRegistry()
+	require.NoError(t, registry.Register(eventlog.Registration{ContractKey: "mytoken", ABI: contractABI}))
+
+	log := mintedLog(t, contractABI, minter)
+	assert.Len(t, registry.ParseLog(log), 1)
+	// The default registry is unchanged
+	assert.Empty(t, eventlog.ParseLog(log))
+	assert.Equal(t, []eventlog.ContractKey{eventlog.ERC20, eventlog.ERC721, eventlog.ERC1155, eventlog.WETH, "mytoken"}, registry.ContractKeys())
+	assert.NotContains(t, eventlog.DefaultRegistry.ContractKeys(), eventlog.ContractKey("mytoken"))
+
+	assert.True(t, registry.Unregister("mytoken"))
+	assert.False(t, registry.Unregister("mytoken"))
+	assert.Empty(t, registry.ParseLog(log))
+}
+
+func TestRegistry_ParserLookupByTopic(t *testing.T) {
+	topic := common.HexToHash("0x01")
+	var topicCalls, anyCalls int
+	registry := eventlog.NewRegistry()
+	require.NoError(t, registry.Register(eventlog.Registration{
+		ContractKey: "any",
+		Parser: func(log types.Log) *eventlog.Event {
+			anyCalls++
+			return &eventlog.Event{ContractKey: "any"}
+		},
+	}))
+	require.NoError(t, registry.Register(eventlog.Registration{
+		ContractKey: "topic",
+		Topics:      []common.Hash{topic},
+		Parser: func(log types.Log) *eventlog.Event {
+			topicCalls++
+			return &eventlog.Event{ContractKey: "topic"}
+		},
+	}))
+
+	events := registry.ParseLog(types.Log{Topics: []common.Hash{topic}})
+	require.Len(t, events, 2)
+	// Registration order
+	assert.Equal(t, eventlog.ContractKey("any"), events[0].ContractKey)
+	assert.Equal(t, eventlog.ContractKey("topic"), events[1].ContractKey)
+
+	// Only the parsers of the signature are called
+	events = registry.ParseLog(types.Log{Topics: []common.Hash{common.HexToHash("0x02")}})
+	require.Len(t, events, 1)
+	assert.Equal(t, 1, topicCalls)
+	assert.Equal(t, 2, anyCalls)
+
+	// Logs without topics only go to the parsers of all logs
+	assert.Len(t, registry.ParseLog(types.Log{}), 1)
+	assert.Len(t, eventlog.ParseLog(types.Log{}), 0)
+}
+
+func TestRegistry_RegisterErrors(t *testing.T) {
+	contractABI := loadABI(t, mintableABI)
+	parser := func(log types.Log) *eventlog.Event { return nil }
+	registry := eventlog.NewStandardRegistry()
+
+	assert.ErrorIs(t, registry.Register(eventlog.Registration{ABI: contractABI}), eventlog.ErrEmptyContractKey)
+	assert.ErrorIs(t, registry.Register(eventlog.Registration{ContractKey: eventlog.ERC20, Parser: parser}), eventlog.ErrContractKeyRegistered)
+	assert.ErrorIs(t, registry.Register(eventlog.Registration{ContractKey: "mytoken"}), eventlog.ErrNoParser)
+	assert.ErrorIs(t, registry.Register(eventlog.Registration{ContractKey: "mytoken", ABI: contractABI, Parser: parser}), eventlog.ErrABIAndParser)
+	assert.ErrorIs(t, registry.Register(eventlog.Registration{ContractKey: "mytoken", ABI: loadABI(t, `[]`)}), eventlog.ErrNoABIEvents)
+}
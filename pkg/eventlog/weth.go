@@ -0,0 +1,59 @@
+package eventlog
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/weth"
+)
+
+const (
+	WETH           ContractKey = "weth"
+	WETHDeposit    EventKey    = "wethdeposit"
+	WETHWithdrawal EventKey    = "wethwithdrawal"
+)
+
+var WETHDepositID common.Hash = common.HexToHash("0xe1fffcc4923d04b559f4d29a8bfc6cda04eb5b0d3c460751c2402c5c5cc9109c")
+var WETHWithdrawalID common.Hash = common.HexToHash("0x7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65")
+
+// ParseLogWETH parses the Deposit and Withdrawal events of wrapped native
+// tokens (WETH9 and its forks). Other contracts use the same signatures, check
+// the log address to know whether the event wraps the native token.
+func ParseLogWETH(log types.Log) *Event {
+	abi := getABI(weth.WethMetaData)
+	event, _ := abi.EventByID(log.Topics[0])
+	if event == nil {
+		return nil
+	}
+
+	ret := &Event{
+		ContractKey: WETH,
+		ContractABI: abi,
+		ABIEvent:    event,
+	}
+
+	switch event.ID {
+	case WETHDepositID:
+		unpacked := new(weth.WethDeposit)
+		err := unpackLog(abi, unpacked, event.Name, log)
+		if err != nil {
+			return nil
+		}
+		unpacked.Raw = log
+		ret.Unpacked = *unpacked
+		ret.EventKey = WETHDeposit
+	case WETHWithdrawalID:
+		unpacked := new(weth.WethWithdrawal)
+		err := unpackLog(abi, unpacked, event.Name, log)
+		if err != nil {
+			return nil
+		}
+		unpacked.Raw = log
+		ret.Unpacked = *unpacked
+		ret.EventKey = WETHWithdrawal
+	default:
+		return nil
+	}
+
+	return ret
+}
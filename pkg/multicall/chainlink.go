@@ -0,0 +1,73 @@
+package multicall
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/chainlink"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+)
+
+// Values returned by the Chainlink AggregatorV3 function "latestRoundData()"
+type ChainlinkRoundData struct {
+	RoundID         *big.Int
+	Answer          *big.Int
+	StartedAt       *big.Int
+	UpdatedAt       *big.Int
+	AnsweredInRound *big.Int
+}
+
+// Call for Chainlink AggregatorV3 function "latestRoundData()"
+func BuildChainlinkLatestRoundDataCall(feedAddress common.Address) multicall3.IMulticall3Call {
+	return buildChainlinkCall(feedAddress, "latestRoundData")
+}
+
+// Call for Chainlink AggregatorV3 function "decimals()"
+func BuildChainlinkDecimalsCall(feedAddress common.Address) multicall3.IMulticall3Call {
+	return buildChainlinkCall(feedAddress, "decimals")
+}
+
+func ProcessChainlinkLatestRoundDataResult(result multicall3.IMulticall3Result) (ChainlinkRoundData, error) {
+	if err := ResultError(result); err != nil {
+		return ChainlinkRoundData{}, err
+	}
+
+	abi, err := chainlink.AggregatorV3MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	values, err := abi.Unpack("latestRoundData", result.ReturnData)
+	if err != nil || len(values) != 5 {
+		return ChainlinkRoundData{}, ErrInvalidReturnData
+	}
+	ret := ChainlinkRoundData{}
+	for i, field := range []**big.Int{&ret.RoundID, &ret.Answer, &ret.StartedAt, &ret.UpdatedAt, &ret.AnsweredInRound} {
+		value, ok := values[i].(*big.Int)
+		if !ok {
+			return ChainlinkRoundData{}, ErrInvalidReturnData
+		}
+		*field = value
+	}
+	return ret, nil
+}
+
+func buildChainlinkCall(feedAddress common.Address, method string, args ...any) multicall3.IMulticall3Call {
+	abi, err := chainlink.AggregatorV3MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack(method, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   feedAddress,
+		CallData: callData,
+	}
+
+	return call
+}
@@ -1,7 +1,6 @@
 package multicall
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -31,8 +30,90 @@ func BuildERC1155BalanceCall(accountAddress common.Address, tokenAddress common.
 }
 
 func ProcessERC1155BalanceResult(result multicall3.IMulticall3Result) (*big.Int, error) {
-	if result.Success {
-		return new(big.Int).SetBytes(result.ReturnData), nil
+	if err := ResultError(result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New(string(result.ReturnData))
+	return new(big.Int).SetBytes(result.ReturnData), nil
+}
+
+// Call for ERC1155 function "balanceOfBatch(accounts, ids)"
+func BuildERC1155BalanceOfBatchCall(accountAddresses []common.Address, tokenAddress common.Address, tokenIDs []*big.Int) multicall3.IMulticall3Call {
+	abi, err := erc1155.Erc1155MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack("balanceOfBatch", accountAddresses, tokenIDs)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
+}
+
+// Returns one balance per (account, id) pair of the call.
+func ProcessERC1155BalanceOfBatchResult(result multicall3.IMulticall3Result) ([]*big.Int, error) {
+	if err := ResultError(result); err != nil {
+		return nil, err
+	}
+
+	abi, err := erc1155.Erc1155MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	values, err := abi.Unpack("balanceOfBatch", result.ReturnData)
+	if err != nil || len(values) != 1 {
+		return nil, ErrInvalidReturnData
+	}
+	balances, ok := values[0].([]*big.Int)
+	if !ok {
+		return nil, ErrInvalidReturnData
+	}
+	return balances, nil
+}
+
+// Call for ERC1155 function "uri(id)"
+func BuildERC1155URICall(tokenAddress common.Address, tokenID *big.Int) multicall3.IMulticall3Call {
+	abi, err := erc1155.Erc1155MetadataURIMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack("uri", tokenID)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
+}
+
+// Call for ERC1155 function "isApprovedForAll(account, operator)"
+func BuildERC1155IsApprovedForAllCall(accountAddress common.Address, operatorAddress common.Address, tokenAddress common.Address) multicall3.IMulticall3Call {
+	abi, err := erc1155.Erc1155MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack("isApprovedForAll", accountAddress, operatorAddress)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
 }
@@ -0,0 +1,68 @@
+package multicall
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+)
+
+// ERC165 interface identifiers, see https://eips.ethereum.org/EIPS/eip-165
+var (
+	ERC165InterfaceID             = [4]byte{0x01, 0xff, 0xc9, 0xa7}
+	ERC721InterfaceID             = [4]byte{0x80, 0xac, 0x58, 0xcd}
+	ERC721MetadataInterfaceID     = [4]byte{0x5b, 0x5e, 0x13, 0x9f}
+	ERC721EnumerableInterfaceID   = [4]byte{0x78, 0x0e, 0x9d, 0x63}
+	ERC1155InterfaceID            = [4]byte{0xd9, 0xb6, 0x7a, 0x26}
+	ERC1155MetadataURIInterfaceID = [4]byte{0x0e, 0x89, 0x34, 0x1c}
+	// Must be reported as unsupported by ERC165 contracts
+	InvalidInterfaceID = [4]byte{0xff, 0xff, 0xff, 0xff}
+)
+
+// Call for ERC165 function "supportsInterface(interfaceId)"
+func BuildSupportsInterfaceCall(contractAddress common.Address, interfaceID [4]byte) multicall3.IMulticall3Call {
+	abi, err := erc721.Erc721MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack("supportsInterface", interfaceID)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   contractAddress,
+		CallData: callData,
+	}
+
+	return call
+}
+
+// Returns whether the contract reports support for the interface.
+// Contracts that don't implement ERC165 revert or return no data, which is
+// reported as "not supported" rather than as an error.
+// Contracts answering true to any call (e.g. from a fallback function) are not
+// detected, see ProcessSupportsInterfaceResults.
+func ProcessSupportsInterfaceResult(result multicall3.IMulticall3Result) (bool, error) {
+	if !result.Success || len(result.ReturnData) < 32 {
+		return false, nil
+	}
+	return ProcessBoolResult(result)
+}
+
+// Returns whether the contract supports the interface, from the results of
+// supportsInterface(interfaceID) and supportsInterface(InvalidInterfaceID).
+// As required by EIP-165, contracts reporting support for InvalidInterfaceID
+// don't implement ERC165 and are reported as not supporting the interface.
+func ProcessSupportsInterfaceResults(result multicall3.IMulticall3Result, invalidInterfaceResult multicall3.IMulticall3Result) (bool, error) {
+	supported, err := ProcessSupportsInterfaceResult(result)
+	if err != nil || !supported {
+		return false, err
+	}
+	supportsInvalid, err := ProcessSupportsInterfaceResult(invalidInterfaceResult)
+	if err != nil {
+		return false, err
+	}
+	return !supportsInvalid, nil
+}
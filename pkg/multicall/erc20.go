@@ -1,7 +1,6 @@
 package multicall
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -31,8 +30,67 @@ func BuildERC20BalanceCall(accountAddress common.Address, tokenAddress common.Ad
 }
 
 func ProcessERC20BalanceResult(result multicall3.IMulticall3Result) (*big.Int, error) {
-	if result.Success {
-		return new(big.Int).SetBytes(result.ReturnData), nil
+	if err := ResultError(result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New(string(result.ReturnData))
+	return new(big.Int).SetBytes(result.ReturnData), nil
+}
+
+// Call for ERC20 function "name()"
+func BuildERC20NameCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC20MetadataCall(tokenAddress, "name")
+}
+
+// Call for ERC20 function "symbol()"
+func BuildERC20SymbolCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC20MetadataCall(tokenAddress, "symbol")
+}
+
+// Call for ERC20 function "decimals()"
+func BuildERC20DecimalsCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC20MetadataCall(tokenAddress, "decimals")
+}
+
+// Call for ERC20 function "totalSupply()"
+func BuildERC20TotalSupplyCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC20MetadataCall(tokenAddress, "totalSupply")
+}
+
+func buildERC20MetadataCall(tokenAddress common.Address, method string) multicall3.IMulticall3Call {
+	abi, err := erc20.Erc20MetadataMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack(method)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
+}
+
+// Call for ERC20 function "allowance(owner, spender)"
+func BuildERC20AllowanceCall(ownerAddress common.Address, spenderAddress common.Address, tokenAddress common.Address) multicall3.IMulticall3Call {
+	abi, err := erc20.Erc20MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack("allowance", ownerAddress, spenderAddress)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
 }
@@ -1,7 +1,6 @@
 package multicall
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -31,8 +30,105 @@ func BuildERC721BalanceCall(accountAddress common.Address, tokenAddress common.A
 }
 
 func ProcessERC721BalanceResult(result multicall3.IMulticall3Result) (*big.Int, error) {
-	if result.Success {
-		return new(big.Int).SetBytes(result.ReturnData), nil
+	if err := ResultError(result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New(string(result.ReturnData))
+	return new(big.Int).SetBytes(result.ReturnData), nil
+}
+
+// Call for ERC721 function "name()"
+func BuildERC721NameCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC721MetadataCall(tokenAddress, "name")
+}
+
+// Call for ERC721 function "symbol()"
+func BuildERC721SymbolCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC721MetadataCall(tokenAddress, "symbol")
+}
+
+// Call for ERC721 function "tokenURI(tokenId)"
+func BuildERC721TokenURICall(tokenAddress common.Address, tokenID *big.Int) multicall3.IMulticall3Call {
+	return buildERC721MetadataCall(tokenAddress, "tokenURI", tokenID)
+}
+
+func buildERC721MetadataCall(tokenAddress common.Address, method string, args ...any) multicall3.IMulticall3Call {
+	abi, err := erc721.Erc721MetadataMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack(method, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
+}
+
+// Call for ERC721 function "ownerOf(tokenId)"
+func BuildERC721OwnerOfCall(tokenAddress common.Address, tokenID *big.Int) multicall3.IMulticall3Call {
+	return buildERC721Call(tokenAddress, "ownerOf", tokenID)
+}
+
+// Call for ERC721 function "getApproved(tokenId)"
+func BuildERC721GetApprovedCall(tokenAddress common.Address, tokenID *big.Int) multicall3.IMulticall3Call {
+	return buildERC721Call(tokenAddress, "getApproved", tokenID)
+}
+
+// Call for ERC721 function "isApprovedForAll(owner, operator)"
+func BuildERC721IsApprovedForAllCall(ownerAddress common.Address, operatorAddress common.Address, tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC721Call(tokenAddress, "isApprovedForAll", ownerAddress, operatorAddress)
+}
+
+func buildERC721Call(tokenAddress common.Address, method string, args ...any) multicall3.IMulticall3Call {
+	abi, err := erc721.Erc721MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack(method, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
+}
+
+// Call for ERC721Enumerable function "tokenOfOwnerByIndex(owner, index)"
+func BuildERC721TokenOfOwnerByIndexCall(ownerAddress common.Address, tokenAddress common.Address, index *big.Int) multicall3.IMulticall3Call {
+	return buildERC721EnumerableCall(tokenAddress, "tokenOfOwnerByIndex", ownerAddress, index)
+}
+
+// Call for ERC721Enumerable function "totalSupply()"
+func BuildERC721TotalSupplyCall(tokenAddress common.Address) multicall3.IMulticall3Call {
+	return buildERC721EnumerableCall(tokenAddress, "totalSupply")
+}
+
+func buildERC721EnumerableCall(tokenAddress common.Address, method string, args ...any) multicall3.IMulticall3Call {
+	abi, err := erc721.Erc721EnumerableMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+
+	callData, err := abi.Pack(method, args...)
+	if err != nil {
+		panic(err)
+	}
+
+	call := multicall3.IMulticall3Call{
+		Target:   tokenAddress,
+		CallData: callData,
+	}
+
+	return call
 }
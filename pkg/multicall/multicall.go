@@ -1,7 +1,6 @@
 package multicall
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -30,8 +29,8 @@ func BuildNativeBalanceCall(accountAddress common.Address, multicall3Address com
 }
 
 func ProcessNativeBalanceResult(result multicall3.IMulticall3Result) (*big.Int, error) {
-	if result.Success {
-		return new(big.Int).SetBytes(result.ReturnData), nil
+	if err := ResultError(result); err != nil {
+		return nil, err
 	}
-	return nil, errors.New(string(result.ReturnData))
+	return new(big.Int).SetBytes(result.ReturnData), nil
 }
@@ -0,0 +1,103 @@
+package multicall
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+)
+
+var (
+	ErrInvalidReturnData = errors.New("invalid return data")
+	ErrInvalidString     = errors.New("return data is not a valid string")
+	ErrValueOutOfRange   = errors.New("returned value out of range")
+)
+
+var stringArguments = func() abi.Arguments {
+	stringType, err := abi.NewType("string", "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return abi.Arguments{{Type: stringType}}
+}()
+
+// Parses a single ABI-encoded "string" return value.
+// Legacy tokens (e.g. MKR, SAI) return "bytes32" for name() and symbol(),
+// those are decoded as a zero-padded string.
+func ProcessStringResult(result multicall3.IMulticall3Result) (string, error) {
+	if err := ResultError(result); err != nil {
+		return "", err
+	}
+
+	data := result.ReturnData
+	if len(data) == 32 {
+		return parseBytes32String(data)
+	}
+
+	unpacked, err := stringArguments.Unpack(data)
+	if err != nil {
+		return "", errors.Join(ErrInvalidString, err)
+	}
+	value, ok := unpacked[0].(string)
+	if !ok || !utf8.ValidString(value) {
+		return "", ErrInvalidString
+	}
+	return value, nil
+}
+
+func parseBytes32String(data []byte) (string, error) {
+	trimmed := bytes.TrimRight(data, "\x00")
+	if bytes.IndexByte(trimmed, 0) >= 0 || !utf8.Valid(trimmed) {
+		return "", ErrInvalidString
+	}
+	return string(trimmed), nil
+}
+
+// Parses a single ABI-encoded "uint8" return value.
+// Some tokens declare decimals() as uint256, those are accepted as long as the value fits.
+func ProcessUint8Result(result multicall3.IMulticall3Result) (uint8, error) {
+	value, err := ProcessUint256Result(result)
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() || value.Uint64() > 255 {
+		return 0, ErrValueOutOfRange
+	}
+	return uint8(value.Uint64()), nil
+}
+
+// Parses a single ABI-encoded "uint256" return value.
+func ProcessUint256Result(result multicall3.IMulticall3Result) (*big.Int, error) {
+	if err := ResultError(result); err != nil {
+		return nil, err
+	}
+	if len(result.ReturnData) < 32 {
+		return nil, ErrInvalidReturnData
+	}
+	return new(big.Int).SetBytes(result.ReturnData[:32]), nil
+}
+
+// Parses a single ABI-encoded "bool" return value.
+func ProcessBoolResult(result multicall3.IMulticall3Result) (bool, error) {
+	value, err := ProcessUint256Result(result)
+	if err != nil {
+		return false, err
+	}
+	return value.Sign() != 0, nil
+}
+
+// Parses a single ABI-encoded "address" return value.
+func ProcessAddressResult(result multicall3.IMulticall3Result) (common.Address, error) {
+	if err := ResultError(result); err != nil {
+		return common.Address{}, err
+	}
+	if len(result.ReturnData) < 32 {
+		return common.Address{}, ErrInvalidReturnData
+	}
+	return common.BytesToAddress(result.ReturnData[12:32]), nil
+}
@@ -0,0 +1,165 @@
+package multicall_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/chainlink"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+func successResult(data []byte) multicall3.IMulticall3Result {
+	return multicall3.IMulticall3Result{Success: true, ReturnData: data}
+}
+
+func TestProcessStringResult(t *testing.T) {
+	t.Run("abi string", func(t *testing.T) {
+		data := packRevert(t, "name()", []string{"string"}, "Wrapped Ether")[4:]
+		value, err := multicall.ProcessStringResult(successResult(data))
+		require.NoError(t, err)
+		assert.Equal(t, "Wrapped Ether", value)
+	})
+
+	t.Run("bytes32 legacy token", func(t *testing.T) {
+		data := common.RightPadBytes([]byte("MKR"), 32)
+		value, err := multicall.ProcessStringResult(successResult(data))
+		require.NoError(t, err)
+		assert.Equal(t, "MKR", value)
+	})
+
+	t.Run("bytes32 with embedded zero", func(t *testing.T) {
+		data := common.RightPadBytes([]byte{'A', 0, 'B'}, 32)
+		_, err := multicall.ProcessStringResult(successResult(data))
+		assert.ErrorIs(t, err, multicall.ErrInvalidString)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		_, err := multicall.ProcessStringResult(successResult([]byte{1, 2, 3}))
+		assert.ErrorIs(t, err, multicall.ErrInvalidString)
+	})
+
+	t.Run("no data", func(t *testing.T) {
+		_, err := multicall.ProcessStringResult(successResult(nil))
+		assert.ErrorIs(t, err, multicall.ErrNoReturnData)
+	})
+
+	t.Run("reverted", func(t *testing.T) {
+		_, err := multicall.ProcessStringResult(multicall3.IMulticall3Result{Success: false})
+		var revertErr *multicall.RevertError
+		assert.ErrorAs(t, err, &revertErr)
+	})
+}
+
+func TestProcessUint8Result(t *testing.T) {
+	value, err := multicall.ProcessUint8Result(successResult(common.LeftPadBytes([]byte{18}, 32)))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(18), value)
+
+	_, err = multicall.ProcessUint8Result(successResult(common.LeftPadBytes([]byte{1, 0}, 32)))
+	assert.ErrorIs(t, err, multicall.ErrValueOutOfRange)
+
+	_, err = multicall.ProcessUint8Result(successResult([]byte{18}))
+	assert.ErrorIs(t, err, multicall.ErrInvalidReturnData)
+}
+
+func TestProcessUint256Result(t *testing.T) {
+	value, err := multicall.ProcessUint256Result(successResult(common.LeftPadBytes(big.NewInt(1000).Bytes(), 32)))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(1000), value)
+}
+
+func TestProcessBoolResult(t *testing.T) {
+	value, err := multicall.ProcessBoolResult(successResult(common.LeftPadBytes([]byte{1}, 32)))
+	require.NoError(t, err)
+	assert.True(t, value)
+
+	value, err = multicall.ProcessBoolResult(successResult(make([]byte, 32)))
+	require.NoError(t, err)
+	assert.False(t, value)
+}
+
+func TestProcessAddressResult(t *testing.T) {
+	address := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	value, err := multicall.ProcessAddressResult(successResult(common.LeftPadBytes(address.Bytes(), 32)))
+	require.NoError(t, err)
+	assert.Equal(t, address, value)
+}
+
+func TestProcessSupportsInterfaceResult(t *testing.T) {
+	supported, err := multicall.ProcessSupportsInterfaceResult(successResult(common.LeftPadBytes([]byte{1}, 32)))
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	// Contracts without ERC165 revert or return nothing
+	supported, err = multicall.ProcessSupportsInterfaceResult(multicall3.IMulticall3Result{Success: false})
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	supported, err = multicall.ProcessSupportsInterfaceResult(successResult(nil))
+	require.NoError(t, err)
+	assert.False(t, supported)
+}
+
+func TestProcessSupportsInterfaceResults(t *testing.T) {
+	yes := successResult(common.LeftPadBytes([]byte{1}, 32))
+	no := successResult(common.LeftPadBytes([]byte{0}, 32))
+
+	supported, err := multicall.ProcessSupportsInterfaceResults(yes, no)
+	require.NoError(t, err)
+	assert.True(t, supported)
+
+	supported, err = multicall.ProcessSupportsInterfaceResults(no, no)
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	// Fallback returning true for any interface
+	supported, err = multicall.ProcessSupportsInterfaceResults(yes, yes)
+	require.NoError(t, err)
+	assert.False(t, supported)
+
+	// Reverting on the invalid interface is not supporting it
+	supported, err = multicall.ProcessSupportsInterfaceResults(yes, multicall3.IMulticall3Result{Success: false})
+	require.NoError(t, err)
+	assert.True(t, supported)
+}
+
+func TestProcessERC1155BalanceOfBatchResult(t *testing.T) {
+	contractABI, err := erc1155.Erc1155MetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := contractABI.Methods["balanceOfBatch"].Outputs.Pack([]*big.Int{big.NewInt(3), big.NewInt(0)})
+	require.NoError(t, err)
+
+	balances, err := multicall.ProcessERC1155BalanceOfBatchResult(successResult(data))
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	assert.Equal(t, int64(3), balances[0].Int64())
+	assert.Zero(t, balances[1].Sign())
+
+	_, err = multicall.ProcessERC1155BalanceOfBatchResult(successResult([]byte{1, 2, 3}))
+	assert.ErrorIs(t, err, multicall.ErrInvalidReturnData)
+
+	_, err = multicall.ProcessERC1155BalanceOfBatchResult(multicall3.IMulticall3Result{Success: false})
+	assert.Error(t, err)
+}
+
+func TestProcessChainlinkLatestRoundDataResult(t *testing.T) {
+	contractABI, err := chainlink.AggregatorV3MetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := contractABI.Methods["latestRoundData"].Outputs.Pack(big.NewInt(7), big.NewInt(-250000000000), big.NewInt(1000), big.NewInt(1001), big.NewInt(7))
+	require.NoError(t, err)
+
+	roundData, err := multicall.ProcessChainlinkLatestRoundDataResult(successResult(data))
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), roundData.RoundID.Int64())
+	assert.Equal(t, int64(-250000000000), roundData.Answer.Int64())
+	assert.Equal(t, int64(1001), roundData.UpdatedAt.Int64())
+
+	_, err = multicall.ProcessChainlinkLatestRoundDataResult(successResult(common.LeftPadBytes([]byte{1}, 32)))
+	assert.ErrorIs(t, err, multicall.ErrInvalidReturnData)
+}
@@ -0,0 +1,158 @@
+package multicall
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+)
+
+// ErrNoReturnData is returned when a call succeeded but returned no data, which
+// usually means the target is not a contract (calls to EOAs always succeed).
+var ErrNoReturnData = errors.New("call returned no data, target may not be a contract")
+
+type RevertKind string
+
+const (
+	// Revert without data: require() without message, revert(), missing function without fallback
+	RevertKindEmpty RevertKind = "empty"
+	// Error(string), emitted by require(cond, "reason") and revert("reason")
+	RevertKindError RevertKind = "error"
+	// Panic(uint256), emitted by failed asserts, arithmetic overflows, etc.
+	RevertKindPanic RevertKind = "panic"
+	// Custom error matched against one of the supplied ABIs
+	RevertKindCustom RevertKind = "custom"
+	// Revert data that could not be decoded
+	RevertKindUnknown RevertKind = "unknown"
+)
+
+var (
+	errorSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// See https://docs.soliditylang.org/en/latest/control-structures.html#panic-via-assert-and-error-via-require
+var panicReasons = map[uint64]string{
+	0x00: "generic panic",
+	0x01: "assert(false)",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "enum overflow",
+	0x22: "invalid encoded storage byte array accessed",
+	0x31: "pop() on an empty array",
+	0x32: "out-of-bounds access of an array or bytesN",
+	0x41: "out of memory",
+	0x51: "uninitialized function",
+}
+
+// RevertError is the decoded form of the data returned by a failed call.
+type RevertError struct {
+	Kind RevertKind
+	// Raw revert data
+	Data []byte
+	// Set for RevertKindError
+	Reason string
+	// Set for RevertKindPanic
+	PanicCode *big.Int
+	// Set for RevertKindCustom
+	CustomError *abi.Error
+	CustomArgs  []any
+}
+
+func (e *RevertError) Error() string {
+	switch e.Kind {
+	case RevertKindEmpty:
+		return "execution reverted without data"
+	case RevertKindError:
+		return "execution reverted: " + e.Reason
+	case RevertKindPanic:
+		reason := "unknown panic code"
+		if r, ok := panicReasons[e.PanicCode.Uint64()]; ok && e.PanicCode.IsUint64() {
+			reason = r
+		}
+		return fmt.Sprintf("execution reverted: panic 0x%x (%s)", e.PanicCode, reason)
+	case RevertKindCustom:
+		args := make([]string, 0, len(e.CustomArgs))
+		for _, arg := range e.CustomArgs {
+			args = append(args, fmt.Sprintf("%v", arg))
+		}
+		return fmt.Sprintf("execution reverted: %s(%s)", e.CustomError.Name, strings.Join(args, ", "))
+	default:
+		return "execution reverted: unrecognized revert data " + hexutil.Encode(e.Data)
+	}
+}
+
+// DecodeRevertData decodes the data returned by a reverted call.
+// Custom errors are resolved against the given ABIs, in order.
+func DecodeRevertData(data []byte, contractABIs ...*abi.ABI) *RevertError {
+	ret := &RevertError{
+		Kind: RevertKindUnknown,
+		Data: data,
+	}
+
+	if len(data) == 0 {
+		ret.Kind = RevertKindEmpty
+		return ret
+	}
+	if len(data) < 4 {
+		return ret
+	}
+
+	switch {
+	case bytes.Equal(data[:4], errorSelector):
+		if reason, err := abi.UnpackRevert(data); err == nil {
+			ret.Kind = RevertKindError
+			ret.Reason = reason
+		}
+		return ret
+	case bytes.Equal(data[:4], panicSelector):
+		if len(data) == 4+32 {
+			ret.Kind = RevertKindPanic
+			ret.PanicCode = new(big.Int).SetBytes(data[4:])
+		}
+		return ret
+	}
+
+	for _, contractABI := range contractABIs {
+		if contractABI == nil {
+			continue
+		}
+		for _, abiError := range contractABI.Errors {
+			if !bytes.Equal(data[:4], abiError.ID[:4]) {
+				continue
+			}
+			unpacked, err := abiError.Unpack(data)
+			if err != nil {
+				continue
+			}
+			args, _ := unpacked.([]any)
+			ret.Kind = RevertKindCustom
+			ret.CustomError = &abiError
+			ret.CustomArgs = args
+			return ret
+		}
+	}
+
+	return ret
+}
+
+// ResultError returns the error carried by a Multicall3 result, if any:
+//   - nil for successful calls that returned data
+//   - ErrNoReturnData for successful calls that returned nothing
+//   - a *RevertError for failed calls
+func ResultError(result multicall3.IMulticall3Result, contractABIs ...*abi.ABI) error {
+	if result.Success {
+		if len(result.ReturnData) == 0 {
+			return ErrNoReturnData
+		}
+		return nil
+	}
+	return DecodeRevertData(result.ReturnData, contractABIs...)
+}
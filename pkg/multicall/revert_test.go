@@ -0,0 +1,140 @@
+package multicall_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+const customErrorsABI = `[{"type":"error","name":"InsufficientBalance","inputs":[{"name":"available","type":"uint256"},{"name":"required","type":"uint256"}]}]`
+
+func packRevert(t *testing.T, signature string, types []string, values ...any) []byte {
+	args := make(abi.Arguments, 0, len(types))
+	for _, typeName := range types {
+		typ, err := abi.NewType(typeName, "", nil)
+		require.NoError(t, err)
+		args = append(args, abi.Argument{Type: typ})
+	}
+	packed, err := args.Pack(values...)
+	require.NoError(t, err)
+	return append(crypto.Keccak256([]byte(signature))[:4], packed...)
+}
+
+func TestDecodeRevertData(t *testing.T) {
+	customABI, err := abi.JSON(strings.NewReader(customErrorsABI))
+	require.NoError(t, err)
+
+	t.Run("empty", func(t *testing.T) {
+		revertErr := multicall.DecodeRevertData(nil)
+		assert.Equal(t, multicall.RevertKindEmpty, revertErr.Kind)
+		assert.Equal(t, "execution reverted without data", revertErr.Error())
+	})
+
+	t.Run("error string", func(t *testing.T) {
+		data := packRevert(t, "Error(string)", []string{"string"}, "ERC20: transfer amount exceeds balance")
+		revertErr := multicall.DecodeRevertData(data)
+		assert.Equal(t, multicall.RevertKindError, revertErr.Kind)
+		assert.Equal(t, "ERC20: transfer amount exceeds balance", revertErr.Reason)
+		assert.Equal(t, "execution reverted: ERC20: transfer amount exceeds balance", revertErr.Error())
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		data := packRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11))
+		revertErr := multicall.DecodeRevertData(data)
+		assert.Equal(t, multicall.RevertKindPanic, revertErr.Kind)
+		assert.Equal(t, big.NewInt(0x11), revertErr.PanicCode)
+		assert.Equal(t, "execution reverted: panic 0x11 (arithmetic underflow or overflow)", revertErr.Error())
+	})
+
+	t.Run("unknown panic code", func(t *testing.T) {
+		data := packRevert(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x99))
+		revertErr := multicall.DecodeRevertData(data)
+		assert.Equal(t, multicall.RevertKindPanic, revertErr.Kind)
+		assert.Contains(t, revertErr.Error(), "unknown panic code")
+	})
+
+	t.Run("custom error with ABI", func(t *testing.T) {
+		data := packRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(2))
+		revertErr := multicall.DecodeRevertData(data, &customABI)
+		assert.Equal(t, multicall.RevertKindCustom, revertErr.Kind)
+		require.NotNil(t, revertErr.CustomError)
+		assert.Equal(t, "InsufficientBalance", revertErr.CustomError.Name)
+		assert.Equal(t, []any{big.NewInt(1), big.NewInt(2)}, revertErr.CustomArgs)
+		assert.Equal(t, "execution reverted: InsufficientBalance(1, 2)", revertErr.Error())
+	})
+
+	t.Run("custom error without ABI", func(t *testing.T) {
+		data := packRevert(t, "InsufficientBalance(uint256,uint256)", []string{"uint256", "uint256"}, big.NewInt(1), big.NewInt(2))
+		revertErr := multicall.DecodeRevertData(data)
+		assert.Equal(t, multicall.RevertKindUnknown, revertErr.Kind)
+		assert.Contains(t, revertErr.Error(), "unrecognized revert data 0x")
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		revertErr := multicall.DecodeRevertData([]byte{0x01, 0x02})
+		assert.Equal(t, multicall.RevertKindUnknown, revertErr.Kind)
+		assert.Equal(t, "execution reverted: unrecognized revert data 0x0102", revertErr.Error())
+	})
+}
+
+func TestResultError(t *testing.T) {
+	assert.NoError(t, multicall.ResultError(multicall3.IMulticall3Result{Success: true, ReturnData: common.LeftPadBytes([]byte{1}, 32)}))
+	assert.ErrorIs(t, multicall.ResultError(multicall3.IMulticall3Result{Success: true}), multicall.ErrNoReturnData)
+
+	var revertErr *multicall.RevertError
+	err := multicall.ResultError(multicall3.IMulticall3Result{Success: false})
+	require.True(t, errors.As(err, &revertErr))
+	assert.Equal(t, multicall.RevertKindEmpty, revertErr.Kind)
+}
+
+func TestProcessBalanceResult_DecodesRevert(t *testing.T) {
+	data := packRevert(t, "Error(string)", []string{"string"}, "not supported")
+	result := multicall3.IMulticall3Result{Success: false, ReturnData: data}
+
+	processors := map[string]func(multicall3.IMulticall3Result) (*big.Int, error){
+		"native":  multicall.ProcessNativeBalanceResult,
+		"erc20":   multicall.ProcessERC20BalanceResult,
+		"erc721":  multicall.ProcessERC721BalanceResult,
+		"erc1155": multicall.ProcessERC1155BalanceResult,
+	}
+	for name, process := range processors {
+		t.Run(name, func(t *testing.T) {
+			balance, err := process(result)
+			assert.Nil(t, balance)
+			var revertErr *multicall.RevertError
+			require.True(t, errors.As(err, &revertErr))
+			assert.Equal(t, "not supported", revertErr.Reason)
+		})
+	}
+}
+
+func TestProcessBalanceResult_NoReturnData(t *testing.T) {
+	processors := map[string]func(multicall3.IMulticall3Result) (*big.Int, error){
+		"native":  multicall.ProcessNativeBalanceResult,
+		"erc20":   multicall.ProcessERC20BalanceResult,
+		"erc721":  multicall.ProcessERC721BalanceResult,
+		"erc1155": multicall.ProcessERC1155BalanceResult,
+	}
+	for name, process := range processors {
+		t.Run(name, func(t *testing.T) {
+			// Calls to accounts without code succeed without data
+			balance, err := process(multicall3.IMulticall3Result{Success: true})
+			assert.Nil(t, balance)
+			assert.ErrorIs(t, err, multicall.ErrNoReturnData)
+
+			balance, err = process(multicall3.IMulticall3Result{Success: true, ReturnData: common.LeftPadBytes([]byte{7}, 32)})
+			require.NoError(t, err)
+			assert.Equal(t, int64(7), balance.Int64())
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
 )
@@ -40,10 +41,26 @@ type Job struct {
 	CallResultFn func(multicall3.IMulticall3Result) (any, error)
 }
 
+// CallResultFn for jobs whose calls return different types: the raw result
+// is kept, to be decoded by position with RawResults.
+func RawCallResult(result multicall3.IMulticall3Result) (any, error) {
+	return result, nil
+}
+
+// Returns the raw results of a job run with RawCallResult, in call order.
+func RawResults(jobResult JobResult) []multicall3.IMulticall3Result {
+	ret := make([]multicall3.IMulticall3Result, 0, len(jobResult.Results))
+	for _, callResult := range jobResult.Results {
+		result, _ := callResult.Value.(multicall3.IMulticall3Result)
+		ret = append(ret, result)
+	}
+	return ret
+}
+
 // Collects all jobs and runs them in batches in a blocking manner.
 // Once finished, returns a JobResult for each job.
 // The output JobResult index matches the input Job index.
-func RunSync(ctx context.Context, jobs []Job, atBlock *big.Int, caller Caller, batchsize int) []JobResult {
+func RunSync(ctx context.Context, jobs []Job, atBlock gethrpc.BlockNumberOrHash, caller Caller, batchsize int) []JobResult {
 	resultsCh := RunAsync(ctx, jobs, atBlock, caller, batchsize)
 
 	results := make([]JobResult, len(jobs))
@@ -58,7 +75,7 @@ func RunSync(ctx context.Context, jobs []Job, atBlock *big.Int, caller Caller, b
 // Returns immediately with a channel where a single JobResult will be sent for each job.
 // The received JobResult index matches the input Job index.
 // The channel is closed when all results have been sent.
-func RunAsync(ctx context.Context, jobs []Job, atBlock *big.Int, caller Caller, batchsize int) <-chan JobsResult {
+func RunAsync(ctx context.Context, jobs []Job, atBlock gethrpc.BlockNumberOrHash, caller Caller, batchsize int) <-chan JobsResult {
 	resultsCh := make(chan JobsResult, len(jobs))
 
 	go func() {
@@ -74,7 +91,7 @@ func RunAsync(ctx context.Context, jobs []Job, atBlock *big.Int, caller Caller,
 // Collects all jobs and runs them in batches.
 // A single JobResult will be sent on each JobRunner's channel,
 // as soon as each individual job is finished.
-func ProcessJobs(ctx context.Context, jobs []Job, resultsCh chan<- JobsResult, atBlock *big.Int, caller Caller, batchsize int) {
+func ProcessJobs(ctx context.Context, jobs []Job, resultsCh chan<- JobsResult, atBlock gethrpc.BlockNumberOrHash, caller Caller, batchsize int) {
 	flatCalls := make([]multicall3.IMulticall3Call, 0, len(jobs))
 	for _, job := range jobs {
 		flatCalls = append(flatCalls, job.Calls...)
@@ -107,7 +124,7 @@ func ProcessJobs(ctx context.Context, jobs []Job, resultsCh chan<- JobsResult, a
 			return
 		}
 		// Report error to unprocessed jobs
-		for i := range jobs[lastProcessedJobIdx:] {
+		for i := lastProcessedJobIdx; i < len(jobs); i++ {
 			resultsCh <- JobsResult{
 				JobIdx: i,
 				JobResult: JobResult{
@@ -117,22 +134,23 @@ func ProcessJobs(ctx context.Context, jobs []Job, resultsCh chan<- JobsResult, a
 		}
 	}()
 
+	opts := callOpts(ctx, atBlock)
 	first := true
 	for chunk := range slices.Chunk(flatCalls, batchsize) {
 		var chunkResults []multicall3.IMulticall3Result
 		if first {
 			first = false
 			// First chunk, we need to get the block number and hash
-			blockNumber, blockHash, chunkResults, err = caller.ViewTryBlockAndAggregate(&bind.CallOpts{
-				Context:     ctx,
-				BlockNumber: atBlock,
-			}, requireSuccess, chunk)
+			blockNumber, blockHash, chunkResults, err = caller.ViewTryBlockAndAggregate(opts, requireSuccess, chunk)
+			if !opts.Pending && opts.BlockHash == (common.Hash{}) {
+				// Subsequent chunks, we use the block number from the first chunk
+				opts = &bind.CallOpts{
+					Context:     ctx,
+					BlockNumber: blockNumber,
+				}
+			}
 		} else {
-			// Subsequent chunks, we use the block number from the previous chunk
-			chunkResults, err = caller.ViewTryAggregate(&bind.CallOpts{
-				Context:     ctx,
-				BlockNumber: blockNumber,
-			}, requireSuccess, chunk)
+			chunkResults, err = caller.ViewTryAggregate(opts, requireSuccess, chunk)
 		}
 		if err != nil {
 			return
@@ -180,3 +198,31 @@ func ProcessJobs(ctx context.Context, jobs []Job, resultsCh chan<- JobsResult, a
 		}
 	}
 }
+
+// Converts the block to the call options of the Multicall3 bindings.
+// Block tags other than latest and pending are passed as negative block numbers,
+// which go-ethereum compatible clients convert back to tags.
+// Block hashes require a caller implementing bind.BlockHashContractCaller and
+// the pending state one implementing bind.PendingContractCaller.
+func callOpts(ctx context.Context, atBlock gethrpc.BlockNumberOrHash) *bind.CallOpts {
+	opts := &bind.CallOpts{
+		Context: ctx,
+	}
+	if blockHash, ok := atBlock.Hash(); ok {
+		opts.BlockHash = blockHash
+		return opts
+	}
+	blockNumber, ok := atBlock.Number()
+	if !ok {
+		// Zero value, latest block
+		return opts
+	}
+	switch blockNumber {
+	case gethrpc.LatestBlockNumber:
+	case gethrpc.PendingBlockNumber:
+		opts.Pending = true
+	default:
+		opts.BlockNumber = big.NewInt(blockNumber.Int64())
+	}
+	return opts
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
 	"github.com/status-im/go-wallet-sdk/pkg/multicall"
@@ -55,7 +56,7 @@ func TestRunSync_SingleJob_SingleChunk(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 10)
 
 	// Verify results
@@ -123,7 +124,7 @@ func TestRunSync_MultipleJobs(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, jobs, atBlock, mockCaller, 10)
 
 	// Verify results
@@ -219,7 +220,7 @@ func TestRunSync_Batching_MultipleChunks(t *testing.T) {
 
 	// Run the sync function with small batch size to force batching
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 2)
 
 	// Verify results
@@ -278,7 +279,7 @@ func TestRunSync_ErrorHandling_FirstChunk(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, jobs, atBlock, mockCaller, 10)
 
 	// Verify both jobs received the error
@@ -348,7 +349,7 @@ func TestRunSync_ErrorHandling_SubsequentChunk(t *testing.T) {
 
 	// Run the sync function with small batch size to force batching
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 2)
 
 	// Verify job received the error
@@ -395,7 +396,7 @@ func TestRunSync_ContextCancellation(t *testing.T) {
 	}
 
 	// Run the sync function
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 10)
 
 	// Verify job received the error
@@ -413,7 +414,7 @@ func TestRunSync_EmptyJobs(t *testing.T) {
 
 	// Run the sync function with empty jobs
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{}, atBlock, mockCaller, 10)
 
 	// Verify no results
@@ -437,7 +438,7 @@ func TestRunSync_EmptyJobCalls(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 10)
 
 	// Verify results
@@ -485,7 +486,7 @@ func TestRunSync_RequireSuccessFalse(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 10)
 
 	// Verify results (even with failed individual calls)
@@ -547,7 +548,7 @@ func TestProcessJobRunners_AsyncExecution(t *testing.T) {
 
 	// Run ProcessJobRunners
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	resultsCh := multicall.RunAsync(ctx, jobs, atBlock, mockCaller, 10)
 
 	// Verify results
@@ -611,7 +612,7 @@ func TestRunSync_NilCallResultFn(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 10)
 
 	// Verify results
@@ -669,7 +670,7 @@ func TestRunSync_DataTransformation(t *testing.T) {
 
 	// Run the sync function
 	ctx := context.Background()
-	atBlock := big.NewInt(12345)
+	atBlock := gethrpc.BlockNumberOrHashWithNumber(12345)
 	results := multicall.RunSync(ctx, []multicall.Job{job}, atBlock, mockCaller, 10)
 
 	// Verify results
@@ -699,3 +700,157 @@ func TestRunSync_DataTransformation(t *testing.T) {
 	assert.Equal(t, expectedBlockNumber, result.BlockNumber)
 	assert.Equal(t, common.Hash(expectedBlockHash), result.BlockHash)
 }
+
+func TestRunSync_BlockNumberOrHash(t *testing.T) {
+	blockHash := common.HexToHash("0x1234")
+
+	testCases := []struct {
+		name          string
+		atBlock       gethrpc.BlockNumberOrHash
+		expectedFirst bind.CallOpts
+		expectedNext  bind.CallOpts
+	}{
+		{
+			name:          "number",
+			atBlock:       gethrpc.BlockNumberOrHashWithNumber(100),
+			expectedFirst: bind.CallOpts{BlockNumber: big.NewInt(100)},
+			expectedNext:  bind.CallOpts{BlockNumber: big.NewInt(100)},
+		},
+		{
+			name:          "latest",
+			atBlock:       gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber),
+			expectedFirst: bind.CallOpts{},
+			expectedNext:  bind.CallOpts{BlockNumber: big.NewInt(100)},
+		},
+		{
+			name:          "zero value",
+			atBlock:       gethrpc.BlockNumberOrHash{},
+			expectedFirst: bind.CallOpts{},
+			expectedNext:  bind.CallOpts{BlockNumber: big.NewInt(100)},
+		},
+		{
+			name:          "safe",
+			atBlock:       gethrpc.BlockNumberOrHashWithNumber(gethrpc.SafeBlockNumber),
+			expectedFirst: bind.CallOpts{BlockNumber: big.NewInt(int64(gethrpc.SafeBlockNumber))},
+			expectedNext:  bind.CallOpts{BlockNumber: big.NewInt(100)},
+		},
+		{
+			name:          "finalized",
+			atBlock:       gethrpc.BlockNumberOrHashWithNumber(gethrpc.FinalizedBlockNumber),
+			expectedFirst: bind.CallOpts{BlockNumber: big.NewInt(int64(gethrpc.FinalizedBlockNumber))},
+			expectedNext:  bind.CallOpts{BlockNumber: big.NewInt(100)},
+		},
+		{
+			name:          "pending",
+			atBlock:       gethrpc.BlockNumberOrHashWithNumber(gethrpc.PendingBlockNumber),
+			expectedFirst: bind.CallOpts{Pending: true},
+			expectedNext:  bind.CallOpts{Pending: true},
+		},
+		{
+			name:          "hash",
+			atBlock:       gethrpc.BlockNumberOrHashWithHash(blockHash, true),
+			expectedFirst: bind.CallOpts{BlockHash: blockHash},
+			expectedNext:  bind.CallOpts{BlockHash: blockHash},
+		},
+	}
+
+	calls := []multicall3.IMulticall3Call{
+		{Target: common.HexToAddress("0x1"), CallData: []byte("call1")},
+		{Target: common.HexToAddress("0x2"), CallData: []byte("call2")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx := context.Background()
+			mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+			mockCaller.EXPECT().
+				ViewTryBlockAndAggregate(gomock.Any(), false, calls[:1]).
+				DoAndReturn(func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+					tc.expectedFirst.Context = ctx
+					assert.Equal(t, tc.expectedFirst, *opts)
+					return big.NewInt(100), [32]byte{1}, []multicall3.IMulticall3Result{{Success: true}}, nil
+				})
+			mockCaller.EXPECT().
+				ViewTryAggregate(gomock.Any(), false, calls[1:]).
+				DoAndReturn(func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) ([]multicall3.IMulticall3Result, error) {
+					tc.expectedNext.Context = ctx
+					assert.Equal(t, tc.expectedNext, *opts)
+					return []multicall3.IMulticall3Result{{Success: true}}, nil
+				})
+
+			job := multicall.Job{
+				Calls: calls,
+				CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+					return result, nil
+				},
+			}
+			results := multicall.RunSync(ctx, []multicall.Job{job}, tc.atBlock, mockCaller, 1)
+			assert.Len(t, results, 1)
+			assert.NoError(t, results[0].Err)
+		})
+	}
+}
+
+func TestRunAsync_ErrorReportsUnprocessedJobIndexes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+	expectedErr := errors.New("rpc error")
+
+	mockCaller.EXPECT().
+		ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).
+		Return(big.NewInt(100), [32]byte{1}, []multicall3.IMulticall3Result{{Success: true}}, nil)
+	mockCaller.EXPECT().
+		ViewTryAggregate(gomock.Any(), false, gomock.Any()).
+		Return(nil, expectedErr)
+
+	jobs := make([]multicall.Job, 3)
+	for i := range jobs {
+		jobs[i] = multicall.Job{
+			Calls: []multicall3.IMulticall3Call{{Target: common.BigToAddress(big.NewInt(int64(i + 1)))}},
+			CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+				return result, nil
+			},
+		}
+	}
+
+	errs := make(map[int]error)
+	for result := range multicall.RunAsync(context.Background(), jobs, gethrpc.BlockNumberOrHash{}, mockCaller, 1) {
+		_, seen := errs[result.JobIdx]
+		assert.False(t, seen, "job %d reported twice", result.JobIdx)
+		errs[result.JobIdx] = result.JobResult.Err
+	}
+
+	assert.Equal(t, map[int]error{0: nil, 1: expectedErr, 2: expectedErr}, errs)
+}
+
+func TestRunSync_RawResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	calls := []multicall3.IMulticall3Call{
+		{Target: common.HexToAddress("0x1"), CallData: []byte("call1")},
+		{Target: common.HexToAddress("0x2"), CallData: []byte("call2")},
+	}
+	expectedResults := []multicall3.IMulticall3Result{
+		{Success: true, ReturnData: []byte("result1")},
+		{Success: false},
+	}
+	mockCaller.EXPECT().
+		ViewTryBlockAndAggregate(gomock.Any(), false, calls).
+		Return(big.NewInt(12345), [32]byte{}, expectedResults, nil)
+
+	job := multicall.Job{Calls: calls, CallResultFn: multicall.RawCallResult}
+	results := multicall.RunSync(context.Background(), []multicall.Job{job}, gethrpc.BlockNumberOrHashWithNumber(12345), mockCaller, 10)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, expectedResults, multicall.RawResults(results[0]))
+}
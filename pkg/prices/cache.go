@@ -0,0 +1,112 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	ErrInvalidCacheConfig = errors.New("max staleness must be >= TTL")
+)
+
+type CacheConfig struct {
+	// Cached prices younger than TTL are returned without calling the provider
+	TTL time.Duration
+	// Cached prices up to MaxStaleness old are returned when the provider fails
+	MaxStaleness time.Duration
+}
+
+func (c *CacheConfig) Validate() error {
+	if c.MaxStaleness < c.TTL {
+		return ErrInvalidCacheConfig
+	}
+	return nil
+}
+
+// CachedProvider caches the prices of another provider.
+type CachedProvider struct {
+	provider PriceProvider
+	config   CacheConfig
+	now      func() time.Time
+
+	mu      sync.RWMutex
+	entries map[cacheKey]cacheEntry
+}
+
+type cacheKey struct {
+	tokenKey string
+	currency string
+}
+
+type cacheEntry struct {
+	price     Price
+	fetchedAt time.Time
+}
+
+func NewCachedProvider(provider PriceProvider, config CacheConfig) (*CachedProvider, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &CachedProvider{
+		provider: provider,
+		config:   config,
+		now:      time.Now,
+		entries:  make(map[cacheKey]cacheEntry),
+	}, nil
+}
+
+// Returns fresh cached prices, fetching the others from the provider.
+// If the provider fails, stale prices within MaxStaleness are returned instead;
+// the error is only returned when some token has no usable cached price.
+func (c *CachedProvider) GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]Price, error) {
+	currency = strings.ToLower(currency)
+	now := c.now()
+
+	ret := make(map[string]Price, len(tokens))
+	missing := make([]*types.Token, 0)
+	c.mu.RLock()
+	for _, token := range tokens {
+		entry, ok := c.entries[cacheKey{token.Key(), currency}]
+		if ok && now.Sub(entry.fetchedAt) < c.config.TTL {
+			ret[token.Key()] = entry.price
+			continue
+		}
+		missing = append(missing, token)
+	}
+	c.mu.RUnlock()
+	if len(missing) == 0 {
+		return ret, nil
+	}
+
+	prices, err := c.provider.GetPrices(ctx, missing, currency)
+	if err != nil {
+		complete := true
+		c.mu.RLock()
+		for _, token := range missing {
+			entry, ok := c.entries[cacheKey{token.Key(), currency}]
+			if ok && now.Sub(entry.fetchedAt) <= c.config.MaxStaleness {
+				ret[token.Key()] = entry.price
+				continue
+			}
+			complete = false
+		}
+		c.mu.RUnlock()
+		if !complete {
+			return ret, err
+		}
+		return ret, nil
+	}
+
+	c.mu.Lock()
+	for key, price := range prices {
+		c.entries[cacheKey{key, currency}] = cacheEntry{price: price, fetchedAt: now}
+		ret[key] = price
+	}
+	c.mu.Unlock()
+	return ret, nil
+}
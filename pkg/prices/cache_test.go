@@ -0,0 +1,87 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var errProvider = errors.New("provider failed")
+
+type countingProvider struct {
+	prices   map[string]Price
+	err      error
+	requests [][]string
+}
+
+func (p *countingProvider) GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]Price, error) {
+	keys := make([]string, 0, len(tokens))
+	ret := make(map[string]Price)
+	for _, token := range tokens {
+		keys = append(keys, token.Key())
+		if price, ok := p.prices[token.Key()]; ok {
+			ret[token.Key()] = price
+		}
+	}
+	p.requests = append(p.requests, keys)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return ret, nil
+}
+
+func TestCachedProvider(t *testing.T) {
+	eth := &types.Token{ChainID: 1, Symbol: "ETH"}
+	dai := &types.Token{ChainID: 1, Symbol: "DAI", Address: [20]byte{1}}
+	provider := &countingProvider{prices: map[string]Price{
+		eth.Key(): {Value: big.NewFloat(3000), Currency: "usd"},
+		dai.Key(): {Value: big.NewFloat(1), Currency: "usd"},
+	}}
+
+	cache, err := NewCachedProvider(provider, CacheConfig{TTL: time.Minute, MaxStaleness: time.Hour})
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	result, err := cache.GetPrices(ctx, []*types.Token{eth}, "usd")
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+
+	// ETH is fresh, only DAI is requested
+	now = now.Add(30 * time.Second)
+	result, err = cache.GetPrices(ctx, []*types.Token{eth, dai}, "USD")
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, [][]string{{eth.Key()}, {dai.Key()}}, provider.requests)
+
+	// Expired, the provider fails: stale prices are served
+	now = now.Add(10 * time.Minute)
+	provider.err = errProvider
+	result, err = cache.GetPrices(ctx, []*types.Token{eth, dai}, "usd")
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Len(t, provider.requests, 3)
+
+	// Beyond the staleness bound, the error is returned
+	now = now.Add(2 * time.Hour)
+	result, err = cache.GetPrices(ctx, []*types.Token{eth, dai}, "usd")
+	assert.ErrorIs(t, err, errProvider)
+	assert.Empty(t, result)
+
+	// Recovered
+	provider.err = nil
+	result, err = cache.GetPrices(ctx, []*types.Token{eth, dai}, "usd")
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	_, err = NewCachedProvider(provider, CacheConfig{TTL: time.Hour, MaxStaleness: time.Minute})
+	assert.ErrorIs(t, err, ErrInvalidCacheConfig)
+}
@@ -0,0 +1,122 @@
+package prices
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+type ChainlinkFeed struct {
+	// AggregatorV3 (or proxy) address
+	Address common.Address
+	// Quote currency of the feed, e.g. "usd"
+	Currency string
+}
+
+// ChainlinkProvider reads prices from Chainlink aggregators of a single chain.
+type ChainlinkProvider struct {
+	caller    multicall.Caller
+	feeds     map[string]ChainlinkFeed
+	maxAge    time.Duration
+	batchSize int
+	now       func() time.Time
+}
+
+// Creates a provider for the given feeds, keyed by Token.CrossChainID (or Token.Key()
+// for tokens without one). Answers older than maxAge are ignored, zero disables the check.
+func NewChainlinkProvider(caller multicall.Caller, feeds map[string]ChainlinkFeed, maxAge time.Duration) *ChainlinkProvider {
+	return &ChainlinkProvider{
+		caller:    caller,
+		feeds:     feeds,
+		maxAge:    maxAge,
+		batchSize: 100,
+		now:       time.Now,
+	}
+}
+
+func (p *ChainlinkProvider) GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]Price, error) {
+	// One job per feed, tokens sharing a feed are read once
+	feedTokens := make(map[common.Address][]*types.Token)
+	feedAddresses := make([]common.Address, 0)
+	for _, token := range tokens {
+		feed, ok := p.feeds[priceKey(token)]
+		if !ok || !strings.EqualFold(feed.Currency, currency) {
+			continue
+		}
+		if _, ok := feedTokens[feed.Address]; !ok {
+			feedAddresses = append(feedAddresses, feed.Address)
+		}
+		feedTokens[feed.Address] = append(feedTokens[feed.Address], token)
+	}
+
+	jobs := make([]multicall.Job, 0, len(feedAddresses))
+	for _, feedAddress := range feedAddresses {
+		jobs = append(jobs, multicall.Job{
+			Calls: []multicall3.IMulticall3Call{
+				multicall.BuildChainlinkDecimalsCall(feedAddress),
+				multicall.BuildChainlinkLatestRoundDataCall(feedAddress),
+			},
+			CallResultFn: func(result multicall3.IMulticall3Result) (any, error) {
+				return result, nil
+			},
+		})
+	}
+	jobResults := multicall.RunSync(ctx, jobs, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), p.caller, p.batchSize)
+
+	ret := make(map[string]Price)
+	for i, feedAddress := range feedAddresses {
+		jobResult := jobResults[i]
+		if jobResult.Err != nil {
+			// Context errors affect every feed
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			continue
+		}
+		price, ok := p.processFeed(jobResult, currency)
+		if !ok {
+			continue
+		}
+		for _, token := range feedTokens[feedAddress] {
+			ret[token.Key()] = price
+		}
+	}
+	return ret, nil
+}
+
+func (p *ChainlinkProvider) processFeed(jobResult multicall.JobResult, currency string) (Price, bool) {
+	if len(jobResult.Results) != 2 {
+		return Price{}, false
+	}
+	decimalsResult, _ := jobResult.Results[0].Value.(multicall3.IMulticall3Result)
+	roundResult, _ := jobResult.Results[1].Value.(multicall3.IMulticall3Result)
+
+	decimals, err := multicall.ProcessUint8Result(decimalsResult)
+	if err != nil {
+		return Price{}, false
+	}
+	round, err := multicall.ProcessChainlinkLatestRoundDataResult(roundResult)
+	if err != nil || round.Answer.Sign() <= 0 || round.AnsweredInRound.Cmp(round.RoundID) < 0 {
+		return Price{}, false
+	}
+	updatedAt := time.Unix(round.UpdatedAt.Int64(), 0)
+	if p.maxAge > 0 && p.now().Sub(updatedAt) > p.maxAge {
+		return Price{}, false
+	}
+
+	value := newFloat().SetInt(round.Answer)
+	value.Quo(value, newFloat().SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return Price{
+		Value:     value,
+		Currency:  strings.ToLower(currency),
+		UpdatedAt: updatedAt,
+	}, true
+}
@@ -0,0 +1,103 @@
+package prices_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/chainlink"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/prices"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	eth       = &types.Token{CrossChainID: "ethereum", ChainID: 1, Symbol: "ETH", Decimals: 18}
+	ethOnBase = &types.Token{CrossChainID: "ethereum", ChainID: 8453, Symbol: "ETH", Decimals: 18}
+	usdc      = &types.Token{CrossChainID: "usd-coin", ChainID: 1, Address: common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), Symbol: "USDC", Decimals: 6}
+	link      = &types.Token{CrossChainID: "chainlink", ChainID: 1, Address: common.HexToAddress("0x514910771AF9Ca656af840dff83E8264EcF986CA"), Symbol: "LINK", Decimals: 18}
+	unlisted  = &types.Token{ChainID: 1, Address: common.HexToAddress("0xd000000000000000000000000000000000000001"), Symbol: "NEW", Decimals: 18}
+
+	ethUSDFeed  = common.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")
+	usdcUSDFeed = common.HexToAddress("0x8fFfFfd4AfB6115b954Bd326cbe7B4BA576818f6")
+	linkUSDFeed = common.HexToAddress("0x2c1d072e956AFFC0D435Cb7AC38EF18d24d9127c")
+)
+
+type fakeRound struct {
+	decimals  uint8
+	answer    int64
+	updatedAt time.Time
+}
+
+// Fake chain answering Chainlink calls from the configured rounds
+type fakeFeeds struct {
+	t      *testing.T
+	rounds map[common.Address]fakeRound
+}
+
+func (f *fakeFeeds) ViewTryBlockAndAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+	results, err := f.ViewTryAggregate(opts, requireSuccess, calls)
+	return big.NewInt(100), [32]byte{1}, results, err
+}
+
+func (f *fakeFeeds) ViewTryAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) ([]multicall3.IMulticall3Result, error) {
+	contractABI, err := chainlink.AggregatorV3MetaData.GetAbi()
+	require.NoError(f.t, err)
+
+	results := make([]multicall3.IMulticall3Result, 0, len(calls))
+	for _, call := range calls {
+		round, ok := f.rounds[call.Target]
+		if !ok {
+			results = append(results, multicall3.IMulticall3Result{Success: false})
+			continue
+		}
+		method, err := contractABI.MethodById(call.CallData[:4])
+		require.NoError(f.t, err)
+
+		var data []byte
+		switch method.Name {
+		case "decimals":
+			data, err = method.Outputs.Pack(round.decimals)
+		case "latestRoundData":
+			data, err = method.Outputs.Pack(big.NewInt(10), big.NewInt(round.answer), big.NewInt(round.updatedAt.Unix()), big.NewInt(round.updatedAt.Unix()), big.NewInt(10))
+		}
+		require.NoError(f.t, err)
+		results = append(results, multicall3.IMulticall3Result{Success: true, ReturnData: data})
+	}
+	return results, nil
+}
+
+func TestChainlinkProvider(t *testing.T) {
+	now := time.Now()
+	caller := &fakeFeeds{t: t, rounds: map[common.Address]fakeRound{
+		ethUSDFeed:  {decimals: 8, answer: 3_012_34000000, updatedAt: now.Add(-time.Minute)},
+		usdcUSDFeed: {decimals: 8, answer: 99_990000, updatedAt: now.Add(-2 * time.Hour)},
+		linkUSDFeed: {decimals: 8, answer: 0, updatedAt: now},
+	}}
+	provider := prices.NewChainlinkProvider(caller, map[string]prices.ChainlinkFeed{
+		"ethereum":  {Address: ethUSDFeed, Currency: "usd"},
+		"usd-coin":  {Address: usdcUSDFeed, Currency: "usd"},
+		"chainlink": {Address: linkUSDFeed, Currency: "usd"},
+	}, time.Hour)
+
+	result, err := provider.GetPrices(context.Background(), []*types.Token{eth, ethOnBase, usdc, link, unlisted}, "USD")
+	require.NoError(t, err)
+
+	// Stale USDC answer and non-positive LINK answer are ignored
+	require.Len(t, result, 2)
+	assert.Equal(t, "3012.34", result[eth.Key()].Value.Text('f', 2))
+	assert.Equal(t, "usd", result[eth.Key()].Currency)
+	assert.Equal(t, now.Add(-time.Minute).Unix(), result[eth.Key()].UpdatedAt.Unix())
+	assert.Equal(t, result[eth.Key()], result[ethOnBase.Key()])
+
+	// No feed in that currency
+	result, err = provider.GetPrices(context.Background(), []*types.Token{eth}, "eur")
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,7 @@
+// Package prices converts token balances into fiat values.
+//
+// Prices come from pluggable PriceProvider implementations: Chainlink
+// aggregators read through Multicall3, and an HTTP API keyed by the token
+// CrossChainID (CoinGecko "simple/price" format). Providers can be chained with
+// a fallback and wrapped in a cache with staleness bounds.
+package prices
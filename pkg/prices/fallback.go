@@ -0,0 +1,46 @@
+package prices
+
+import (
+	"context"
+	"errors"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+// FallbackProvider asks each provider in turn for the tokens the previous ones didn't price.
+type FallbackProvider struct {
+	providers []PriceProvider
+}
+
+func NewFallbackProvider(providers ...PriceProvider) *FallbackProvider {
+	return &FallbackProvider{providers: providers}
+}
+
+// Errors of a provider are only returned if no later provider prices the remaining tokens.
+func (p *FallbackProvider) GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]Price, error) {
+	ret := make(map[string]Price, len(tokens))
+	remaining := tokens
+	var errs []error
+	for _, provider := range p.providers {
+		if len(remaining) == 0 {
+			break
+		}
+		prices, err := provider.GetPrices(ctx, remaining, currency)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		next := make([]*types.Token, 0, len(remaining))
+		for _, token := range remaining {
+			if price, ok := prices[token.Key()]; ok {
+				ret[token.Key()] = price
+				continue
+			}
+			next = append(next, token)
+		}
+		remaining = next
+	}
+	if len(remaining) > 0 && len(errs) > 0 {
+		return ret, errors.Join(errs...)
+	}
+	return ret, nil
+}
@@ -0,0 +1,141 @@
+package prices
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+const (
+	defaultHTTPBatchSize = 100
+	defaultHTTPTimeout   = 10 * time.Second
+)
+
+type HTTPConfig struct {
+	// Endpoint in the CoinGecko "simple/price" format,
+	// e.g. https://api.coingecko.com/api/v3/simple/price
+	URL string
+	// Optional API key, sent in the APIKeyHeader header
+	APIKey       string
+	APIKeyHeader string
+	// Defaults to a client with a 10s timeout
+	HTTPClient *http.Client
+	// IDs per request, 100 when zero
+	BatchSize int
+}
+
+// HTTPProvider fetches prices from an HTTP API keyed by Token.CrossChainID.
+// Tokens without a CrossChainID are not priced.
+type HTTPProvider struct {
+	config HTTPConfig
+	client *http.Client
+}
+
+func NewHTTPProvider(config HTTPConfig) *HTTPProvider {
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = defaultHTTPBatchSize
+	}
+	return &HTTPProvider{
+		config: config,
+		client: client,
+	}
+}
+
+func (p *HTTPProvider) GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]Price, error) {
+	currency = strings.ToLower(currency)
+
+	idTokens := make(map[string][]*types.Token)
+	for _, token := range tokens {
+		if token.CrossChainID == "" {
+			continue
+		}
+		idTokens[token.CrossChainID] = append(idTokens[token.CrossChainID], token)
+	}
+	ids := make([]string, 0, len(idTokens))
+	for id := range idTokens {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	ret := make(map[string]Price)
+	for start := 0; start < len(ids); start += p.config.BatchSize {
+		end := min(start+p.config.BatchSize, len(ids))
+		prices, err := p.fetch(ctx, ids[start:end], currency)
+		if err != nil {
+			return nil, err
+		}
+		for id, price := range prices {
+			for _, token := range idTokens[id] {
+				ret[token.Key()] = price
+			}
+		}
+	}
+	return ret, nil
+}
+
+// Response body, e.g. {"ethereum":{"usd":3000.12,"last_updated_at":1700000000}}
+type simplePriceResponse map[string]map[string]json.Number
+
+func (p *HTTPProvider) fetch(ctx context.Context, ids []string, currency string) (map[string]Price, error) {
+	query := url.Values{}
+	query.Set("ids", strings.Join(ids, ","))
+	query.Set("vs_currencies", currency)
+	query.Set("include_last_updated_at", "true")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.config.APIKey != "" && p.config.APIKeyHeader != "" {
+		req.Header.Set(p.config.APIKeyHeader, p.config.APIKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
+	}
+
+	var body simplePriceResponse
+	decoder := json.NewDecoder(resp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&body); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[string]Price, len(body))
+	for id, fields := range body {
+		number, ok := fields[currency]
+		if !ok {
+			continue
+		}
+		value, ok := newFloat().SetString(number.String())
+		if !ok || value.Sign() <= 0 {
+			continue
+		}
+		price := Price{
+			Value:    value,
+			Currency: currency,
+		}
+		if updatedAt, err := fields["last_updated_at"].Int64(); err == nil {
+			price.UpdatedAt = time.Unix(updatedAt, 0)
+		}
+		ret[id] = price
+	}
+	return ret, nil
+}
@@ -0,0 +1,71 @@
+package prices_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/prices"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+func TestHTTPProvider(t *testing.T) {
+	requestedIDs := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("x-cg-demo-api-key"))
+		assert.Equal(t, "usd", r.URL.Query().Get("vs_currencies"))
+		requestedIDs = append(requestedIDs, r.URL.Query().Get("ids"))
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{
+			"ethereum": {"usd": 3012.3456789, "last_updated_at": 1700000000},
+			"usd-coin": {"usd": 0.9999},
+			"chainlink": {"eur": 12.5}
+		}`))
+	}))
+	defer server.Close()
+
+	provider := prices.NewHTTPProvider(prices.HTTPConfig{
+		URL:          server.URL,
+		APIKey:       "secret",
+		APIKeyHeader: "x-cg-demo-api-key",
+		BatchSize:    2,
+	})
+
+	result, err := provider.GetPrices(context.Background(), []*types.Token{eth, ethOnBase, usdc, link, unlisted}, "usd")
+	require.NoError(t, err)
+
+	// Three distinct IDs in batches of two, tokens without CrossChainID are skipped
+	assert.Equal(t, []string{"chainlink,ethereum", "usd-coin"}, requestedIDs)
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "3012.3456789", result[eth.Key()].Value.Text('f', 7))
+	assert.Equal(t, int64(1700000000), result[eth.Key()].UpdatedAt.Unix())
+	assert.Equal(t, result[eth.Key()], result[ethOnBase.Key()])
+	assert.Equal(t, "0.9999", result[usdc.Key()].Value.Text('f', 4))
+	assert.True(t, result[usdc.Key()].UpdatedAt.IsZero())
+}
+
+func TestHTTPProvider_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Query().Get("ids"), "ethereum") {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`not json`))
+	}))
+	defer server.Close()
+
+	provider := prices.NewHTTPProvider(prices.HTTPConfig{URL: server.URL})
+
+	_, err := provider.GetPrices(context.Background(), []*types.Token{eth}, "usd")
+	assert.ErrorIs(t, err, prices.ErrUnexpectedStatusCode)
+
+	_, err = provider.GetPrices(context.Background(), []*types.Token{usdc}, "usd")
+	assert.Error(t, err)
+}
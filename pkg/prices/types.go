@@ -0,0 +1,45 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	ErrUnexpectedStatusCode = errors.New("unexpected status code")
+)
+
+// Precision of the big.Float values, in bits
+const precision = 256
+
+type Price struct {
+	// Price of one whole token (10^decimals base units)
+	Value    *big.Float
+	Currency string
+	// Time the price was last updated by its source, zero if unknown
+	UpdatedAt time.Time
+}
+
+// PriceProvider returns the prices of tokens in a currency (e.g. "usd").
+type PriceProvider interface {
+	// GetPrices returns the prices keyed by Token.Key().
+	// Tokens the provider has no price for are omitted.
+	GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]Price, error)
+}
+
+// Key identifying the priced asset: the CrossChainID when set, so that the same
+// asset on different chains shares a price, otherwise the token key.
+func priceKey(token *types.Token) string {
+	if token.CrossChainID != "" {
+		return token.CrossChainID
+	}
+	return token.Key()
+}
+
+func newFloat() *big.Float {
+	return new(big.Float).SetPrec(precision)
+}
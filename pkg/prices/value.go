@@ -0,0 +1,91 @@
+package prices
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+// TokenSource looks up the token of a balance, implemented by tokens/manager.Manager.
+type TokenSource interface {
+	GetTokenByChainAddress(chainID uint64, addr common.Address) (*types.Token, bool)
+}
+
+type Valuation struct {
+	Account common.Address
+	// Sum of the token values
+	Total *big.Float
+	// Value of each token, keyed by Token.Key()
+	Tokens map[string]*big.Float
+	// Balances that couldn't be valued, for lack of a token or a price.
+	// The zero address stands for the native balance.
+	Unpriced []common.Address
+}
+
+// Returns the value of a balance in base units, given the price of one whole token.
+func Value(balance *big.Int, decimals uint, price Price) *big.Float {
+	ret := newFloat().SetInt(balance)
+	ret.Quo(ret, newFloat().SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)))
+	return ret.Mul(ret, price.Value)
+}
+
+// Values the native and ERC20 balances of multistandardfetcher results, merged per account.
+// Collectibles (ERC721, ERC1155) and failed results are skipped. Native balances
+// are matched with the chain's native token (zero address) of the token source.
+func ValueFetchResults(chainID uint64, results []multistandardfetcher.FetchResult, tokens TokenSource, prices map[string]Price) map[common.Address]*Valuation {
+	ret := make(map[common.Address]*Valuation)
+	valuation := func(account common.Address) *Valuation {
+		if v, ok := ret[account]; ok {
+			return v
+		}
+		v := &Valuation{
+			Account: account,
+			Total:   newFloat(),
+			Tokens:  make(map[string]*big.Float),
+		}
+		ret[account] = v
+		return v
+	}
+	add := func(v *Valuation, address common.Address, balance *big.Int) {
+		token, ok := tokens.GetTokenByChainAddress(chainID, address)
+		if !ok {
+			v.Unpriced = append(v.Unpriced, address)
+			return
+		}
+		price, ok := prices[token.Key()]
+		if !ok {
+			v.Unpriced = append(v.Unpriced, address)
+			return
+		}
+		value := Value(balance, token.Decimals, price)
+		v.Tokens[token.Key()] = value
+		v.Total.Add(v.Total, value)
+	}
+
+	for _, fetchResult := range results {
+		switch fetchResult.ResultType {
+		case multistandardfetcher.ResultTypeNative:
+			result, ok := fetchResult.Result.(multistandardfetcher.NativeResult)
+			if !ok || result.Err != nil || result.Result == nil {
+				continue
+			}
+			add(valuation(result.Account), common.Address{}, result.Result)
+		case multistandardfetcher.ResultTypeERC20:
+			result, ok := fetchResult.Result.(multistandardfetcher.ERC20Result)
+			if !ok || result.Err != nil {
+				continue
+			}
+			v := valuation(result.Account)
+			for contractAddress, balance := range result.Results {
+				if balance == nil || balance.Sign() == 0 {
+					continue
+				}
+				add(v, contractAddress, balance)
+			}
+		}
+	}
+	return ret
+}
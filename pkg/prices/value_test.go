@@ -0,0 +1,98 @@
+package prices_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/balance/multistandardfetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/prices"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+type fakeTokenSource []*types.Token
+
+func (s fakeTokenSource) GetTokenByChainAddress(chainID uint64, addr common.Address) (*types.Token, bool) {
+	for _, token := range s {
+		if token.ChainID == chainID && token.Address == addr {
+			return token, true
+		}
+	}
+	return nil, false
+}
+
+type staticProvider struct {
+	prices map[string]prices.Price
+	err    error
+}
+
+func (p staticProvider) GetPrices(ctx context.Context, tokens []*types.Token, currency string) (map[string]prices.Price, error) {
+	ret := make(map[string]prices.Price)
+	for _, token := range tokens {
+		if price, ok := p.prices[token.Key()]; ok {
+			ret[token.Key()] = price
+		}
+	}
+	return ret, p.err
+}
+
+func usdPrice(value string) prices.Price {
+	price, _ := new(big.Float).SetPrec(256).SetString(value)
+	return prices.Price{Value: price, Currency: "usd"}
+}
+
+func TestValue(t *testing.T) {
+	balance, _ := new(big.Int).SetString("1500000000000000000", 10)
+	assert.Equal(t, "4518.51", prices.Value(balance, 18, usdPrice("3012.34")).Text('f', 2))
+	assert.Equal(t, "0.999900", prices.Value(big.NewInt(1_000_000), 6, usdPrice("0.9999")).Text('f', 6))
+}
+
+func TestValueFetchResults(t *testing.T) {
+	account := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	ethBalance, _ := new(big.Int).SetString("2000000000000000000", 10)
+	priceMap := map[string]prices.Price{
+		eth.Key():  usdPrice("3000"),
+		usdc.Key(): usdPrice("1"),
+	}
+
+	results := []multistandardfetcher.FetchResult{
+		{ResultType: multistandardfetcher.ResultTypeNative, Result: multistandardfetcher.NativeResult{Account: account, Result: ethBalance}},
+		{ResultType: multistandardfetcher.ResultTypeERC20, Result: multistandardfetcher.ERC20Result{Account: account, Results: map[common.Address]*big.Int{
+			usdc.Address:     big.NewInt(250_500000),
+			link.Address:     big.NewInt(1),
+			unlisted.Address: big.NewInt(1),
+		}}},
+		{ResultType: multistandardfetcher.ResultTypeERC20, Result: multistandardfetcher.ERC20Result{Account: account, Err: errors.New("failed")}},
+		{ResultType: multistandardfetcher.ResultTypeERC721, Result: multistandardfetcher.ERC721Result{Account: account}},
+	}
+
+	valuations := prices.ValueFetchResults(1, results, fakeTokenSource{eth, usdc, link}, priceMap)
+	require.Len(t, valuations, 1)
+	valuation := valuations[account]
+	assert.Equal(t, "6250.50", valuation.Total.Text('f', 2))
+	assert.Equal(t, "6000", valuation.Tokens[eth.Key()].Text('f', 0))
+	assert.Equal(t, "250.5", valuation.Tokens[usdc.Key()].Text('f', 1))
+	assert.ElementsMatch(t, []common.Address{link.Address, unlisted.Address}, valuation.Unpriced)
+}
+
+func TestFallbackProvider(t *testing.T) {
+	errFirst := errors.New("first failed")
+	first := staticProvider{prices: map[string]prices.Price{eth.Key(): usdPrice("3000")}, err: errFirst}
+	second := staticProvider{prices: map[string]prices.Price{eth.Key(): usdPrice("1"), usdc.Key(): usdPrice("1")}}
+
+	provider := prices.NewFallbackProvider(first, second)
+	result, err := provider.GetPrices(context.Background(), []*types.Token{eth, usdc}, "usd")
+	require.NoError(t, err)
+	assert.Equal(t, "3000", result[eth.Key()].Value.Text('f', 0))
+	assert.Equal(t, "1", result[usdc.Key()].Value.Text('f', 0))
+
+	// Nobody prices LINK, errors are reported
+	result, err = provider.GetPrices(context.Background(), []*types.Token{eth, link}, "usd")
+	assert.ErrorIs(t, err, errFirst)
+	assert.Len(t, result, 1)
+}
@@ -0,0 +1,64 @@
+package discovery
+
+import (
+	"errors"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+var (
+	ErrClientNotProvided      = errors.New("client is required")
+	ErrCallerNotProvided      = errors.New("multicall caller is required")
+	ErrTokenSourceNotProvided = errors.New("token source is required")
+	ErrCursorStoreNotProvided = errors.New("cursor store is required")
+	// Returned by Discover when the metadata of some contracts could not be
+	// read at all, the cursor is not advanced
+	ErrMetadataNotFetched = errors.New("token metadata could not be fetched")
+)
+
+const defaultBatchSize = 100
+
+type Config struct {
+	ChainID     uint64
+	Client      Client
+	Caller      multicall.Caller
+	TokenSource TokenSource
+	CursorStore CursorStore
+	// First block scanned for accounts without a cursor
+	StartBlock uint64
+	// Standards scanned, ERC20 only when empty
+	TransferTypes []eventfilter.TransferType
+	// Multicall3 batch size of metadata reads, 100 when zero
+	BatchSize int
+}
+
+func (c *Config) Validate() error {
+	if c.Client == nil {
+		return ErrClientNotProvided
+	}
+	if c.Caller == nil {
+		return ErrCallerNotProvided
+	}
+	if c.TokenSource == nil {
+		return ErrTokenSourceNotProvided
+	}
+	if c.CursorStore == nil {
+		return ErrCursorStoreNotProvided
+	}
+	return nil
+}
+
+func (c *Config) transferTypes() []eventfilter.TransferType {
+	if len(c.TransferTypes) == 0 {
+		return []eventfilter.TransferType{eventfilter.TransferTypeERC20}
+	}
+	return c.TransferTypes
+}
+
+func (c *Config) batchSize() int {
+	if c.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return c.BatchSize
+}
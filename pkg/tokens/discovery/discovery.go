@@ -0,0 +1,187 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/metadatafetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+// Discoverer finds the tokens received by accounts on a single chain.
+type Discoverer struct {
+	config Config
+}
+
+func New(config Config) (*Discoverer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Discoverer{config: config}, nil
+}
+
+// Scans the transfers received by the account since the last scan, up to the
+// latest block, and returns every token received in that range, including
+// tokens already returned by earlier scans.
+// The cursor is only advanced once the whole range has been scanned, so a failed
+// scan is retried from the same block. This includes metadata reads that failed
+// as a whole (RPC or multicall errors): the result is returned along with an
+// ErrMetadataNotFetched error, and the range is scanned again by the next call.
+func (d *Discoverer) Discover(ctx context.Context, account common.Address) (Result, error) {
+	ret := Result{
+		Account: account,
+		Failed:  make(map[common.Address]error),
+	}
+
+	fromBlock := d.config.StartBlock
+	lastScanned, ok, err := d.config.CursorStore.GetLastScannedBlock(d.config.ChainID, account)
+	if err != nil {
+		return ret, err
+	}
+	if ok {
+		fromBlock = lastScanned + 1
+	}
+	toBlock, err := d.config.Client.BlockNumber(ctx)
+	if err != nil {
+		return ret, err
+	}
+	ret.FromBlock = fromBlock
+	ret.ToBlock = toBlock
+	if fromBlock > toBlock {
+		return ret, nil
+	}
+
+	events, err := eventfilter.FilterTransfers(ctx, d.config.Client, eventfilter.TransferQueryConfig{
+		FromBlock:     new(big.Int).SetUint64(fromBlock),
+		ToBlock:       new(big.Int).SetUint64(toBlock),
+		Accounts:      []common.Address{account},
+		TransferTypes: d.config.transferTypes(),
+		Direction:     eventfilter.Receive,
+	})
+	if err != nil {
+		return ret, err
+	}
+
+	seen := receivedContracts(account, events)
+	var fetchErr error
+	ret.Tokens, ret.Failed, fetchErr = d.buildTokens(ctx, seen)
+	if fetchErr != nil {
+		return ret, errors.Join(ErrMetadataNotFetched, fetchErr)
+	}
+
+	if err := d.config.CursorStore.SetLastScannedBlock(d.config.ChainID, account, toBlock); err != nil {
+		return ret, err
+	}
+	return ret, nil
+}
+
+type seenContract struct {
+	standard       Standard
+	firstSeenBlock uint64
+}
+
+// Returns the contracts of the transfers to the account, with the block of their first transfer.
+func receivedContracts(account common.Address, events []eventlog.Event) map[common.Address]seenContract {
+	ret := make(map[common.Address]seenContract)
+	add := func(standard Standard, to common.Address, log gethtypes.Log) {
+		if to != account {
+			return
+		}
+		if contract, ok := ret[log.Address]; ok && contract.firstSeenBlock <= log.BlockNumber {
+			return
+		}
+		ret[log.Address] = seenContract{standard: standard, firstSeenBlock: log.BlockNumber}
+	}
+
+	for _, event := range events {
+		switch transfer := event.Unpacked.(type) {
+		case erc20.Erc20Transfer:
+			add(StandardERC20, transfer.To, transfer.Raw)
+		case erc721.Erc721Transfer:
+			add(StandardERC721, transfer.To, transfer.Raw)
+		case erc1155.Erc1155TransferSingle:
+			add(StandardERC1155, transfer.To, transfer.Raw)
+		case erc1155.Erc1155TransferBatch:
+			add(StandardERC1155, transfer.To, transfer.Raw)
+		}
+	}
+	return ret
+}
+
+// Looks the contracts up in the token source, fetching the metadata of unknown ones.
+// Also returns the job-level error of the metadata reads, if any.
+func (d *Discoverer) buildTokens(ctx context.Context, seen map[common.Address]seenContract) ([]DiscoveredToken, map[common.Address]error, error) {
+	tokens := make([]DiscoveredToken, 0, len(seen))
+	failed := make(map[common.Address]error)
+
+	fetchConfig := metadatafetcher.FetchConfig{
+		ERC721:  make(map[metadatafetcher.ContractAddress][]*big.Int),
+		ERC1155: make(map[metadatafetcher.ContractAddress][]*big.Int),
+	}
+	for contractAddress, contract := range seen {
+		if token, ok := d.config.TokenSource.GetTokenByChainAddress(d.config.ChainID, contractAddress); ok {
+			tokens = append(tokens, DiscoveredToken{
+				Token:          token,
+				Standard:       contract.standard,
+				Verified:       true,
+				FirstSeenBlock: contract.firstSeenBlock,
+			})
+			continue
+		}
+		switch contract.standard {
+		case StandardERC20:
+			fetchConfig.ERC20 = append(fetchConfig.ERC20, contractAddress)
+		case StandardERC721:
+			fetchConfig.ERC721[contractAddress] = nil
+		case StandardERC1155:
+			fetchConfig.ERC1155[contractAddress] = nil
+		}
+	}
+
+	metadata := metadatafetcher.FetchMetadata(ctx, d.config.Caller, fetchConfig, d.config.batchSize())
+	var jobErr error
+	addUnverified := func(contractAddress common.Address, token *types.Token, err error, metadataErr error) {
+		if metadataErr != nil {
+			jobErr = metadataErr
+		}
+		if err != nil {
+			failed[contractAddress] = err
+			return
+		}
+		tokens = append(tokens, DiscoveredToken{
+			Token:          token,
+			Standard:       seen[contractAddress].standard,
+			FirstSeenBlock: seen[contractAddress].firstSeenBlock,
+		})
+	}
+	for contractAddress, m := range metadata.ERC20 {
+		token, err := m.ToToken(d.config.ChainID)
+		addUnverified(contractAddress, token, err, m.Err)
+	}
+	for contractAddress, m := range metadata.ERC721 {
+		token, err := m.ToToken(d.config.ChainID)
+		addUnverified(contractAddress, token, err, m.Err)
+	}
+	for contractAddress, m := range metadata.ERC1155 {
+		token, err := m.ToToken(d.config.ChainID)
+		addUnverified(contractAddress, token, err, m.Err)
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].FirstSeenBlock != tokens[j].FirstSeenBlock {
+			return tokens[i].FirstSeenBlock < tokens[j].FirstSeenBlock
+		}
+		return tokens[i].Token.Address.Cmp(tokens[j].Token.Address) < 0
+	})
+	return tokens, failed, jobErr
+}
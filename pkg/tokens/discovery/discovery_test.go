@@ -0,0 +1,251 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/discovery"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+const chainID = 1
+
+var (
+	account = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	sender  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+
+	usdc     = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	unlisted = common.HexToAddress("0xc000000000000000000000000000000000000001")
+	later    = common.HexToAddress("0xc000000000000000000000000000000000000002")
+	noSymbol = common.HexToAddress("0xc000000000000000000000000000000000000003")
+)
+
+type fakeERC20 struct {
+	name     string
+	symbol   string
+	decimals uint8
+}
+
+// Fake chain serving transfer logs and answering ERC20 metadata calls
+type fakeChain struct {
+	t       *testing.T
+	head    uint64
+	logs    []gethtypes.Log
+	tokens  map[common.Address]fakeERC20
+	queries []ethereum.FilterQuery
+	// Returned by the multicalls when set
+	callErr error
+}
+
+func (c *fakeChain) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *fakeChain) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]gethtypes.Log, error) {
+	c.queries = append(c.queries, query)
+	ret := make([]gethtypes.Log, 0)
+	for _, log := range c.logs {
+		if log.BlockNumber >= query.FromBlock.Uint64() && log.BlockNumber <= query.ToBlock.Uint64() {
+			ret = append(ret, log)
+		}
+	}
+	return ret, nil
+}
+
+func (c *fakeChain) ViewTryBlockAndAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+	results, err := c.ViewTryAggregate(opts, requireSuccess, calls)
+	return new(big.Int).SetUint64(c.head), [32]byte{1}, results, err
+}
+
+func (c *fakeChain) ViewTryAggregate(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) ([]multicall3.IMulticall3Result, error) {
+	if c.callErr != nil {
+		return nil, c.callErr
+	}
+	contractABI, err := erc20.Erc20MetadataMetaData.GetAbi()
+	require.NoError(c.t, err)
+
+	results := make([]multicall3.IMulticall3Result, 0, len(calls))
+	for _, call := range calls {
+		token, ok := c.tokens[call.Target]
+		method, err := contractABI.MethodById(call.CallData[:4])
+		require.NoError(c.t, err)
+		if !ok {
+			results = append(results, multicall3.IMulticall3Result{Success: false})
+			continue
+		}
+
+		var data []byte
+		switch method.Name {
+		case "name":
+			data, err = method.Outputs.Pack(token.name)
+		case "symbol":
+			data, err = method.Outputs.Pack(token.symbol)
+		case "decimals":
+			data, err = method.Outputs.Pack(token.decimals)
+		case "totalSupply":
+			data, err = method.Outputs.Pack(big.NewInt(1_000_000))
+		}
+		require.NoError(c.t, err)
+		results = append(results, multicall3.IMulticall3Result{Success: true, ReturnData: data})
+	}
+	return results, nil
+}
+
+func transferLog(contract common.Address, to common.Address, blockNumber uint64) gethtypes.Log {
+	return gethtypes.Log{
+		Address:     contract,
+		BlockNumber: blockNumber,
+		Topics: []common.Hash{
+			eventlog.ERC20TransferID,
+			common.BytesToHash(sender.Bytes()),
+			common.BytesToHash(to.Bytes()),
+		},
+		Data: common.LeftPadBytes(big.NewInt(1).Bytes(), 32),
+	}
+}
+
+type fakeTokenSource map[common.Address]*types.Token
+
+func (s fakeTokenSource) GetTokenByChainAddress(chainID uint64, addr common.Address) (*types.Token, bool) {
+	token, ok := s[addr]
+	return token, ok
+}
+
+func TestDiscover_Incremental(t *testing.T) {
+	chain := &fakeChain{
+		t:    t,
+		head: 100,
+		logs: []gethtypes.Log{
+			transferLog(unlisted, account, 60),
+			transferLog(usdc, account, 50),
+			transferLog(usdc, account, 40),
+			transferLog(noSymbol, account, 70),
+			transferLog(later, account, 120),
+		},
+		tokens: map[common.Address]fakeERC20{
+			unlisted: {name: "Unlisted Token", symbol: "UNL", decimals: 9},
+			later:    {name: "", symbol: "LTR", decimals: 18},
+		},
+	}
+	listedUSDC := &types.Token{CrossChainID: "usd-coin", ChainID: chainID, Address: usdc, Decimals: 6, Name: "USD Coin", Symbol: "USDC"}
+	cursors := discovery.NewMemoryCursorStore()
+
+	discoverer, err := discovery.New(discovery.Config{
+		ChainID:     chainID,
+		Client:      chain,
+		Caller:      chain,
+		TokenSource: fakeTokenSource{usdc: listedUSDC},
+		CursorStore: cursors,
+		StartBlock:  10,
+	})
+	require.NoError(t, err)
+
+	result, err := discoverer.Discover(context.Background(), account)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), result.FromBlock)
+	assert.Equal(t, uint64(100), result.ToBlock)
+
+	require.Len(t, result.Tokens, 2)
+	assert.Equal(t, listedUSDC, result.Tokens[0].Token)
+	assert.True(t, result.Tokens[0].Verified)
+	assert.Equal(t, uint64(40), result.Tokens[0].FirstSeenBlock)
+
+	assert.False(t, result.Tokens[1].Verified)
+	assert.Equal(t, discovery.StandardERC20, result.Tokens[1].Standard)
+	assert.Equal(t, "UNL", result.Tokens[1].Token.Symbol)
+	assert.Equal(t, uint(9), result.Tokens[1].Token.Decimals)
+	assert.Equal(t, uint64(chainID), result.Tokens[1].Token.ChainID)
+
+	require.Len(t, result.Failed, 1)
+	assert.Error(t, result.Failed[noSymbol])
+
+	lastScanned, ok, err := cursors.GetLastScannedBlock(chainID, account)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(100), lastScanned)
+
+	// Resumes after the last scanned block
+	chain.head = 150
+	chain.queries = nil
+	result, err = discoverer.Discover(context.Background(), account)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(101), result.FromBlock)
+	require.Len(t, result.Tokens, 1)
+	assert.Equal(t, "LTR", result.Tokens[0].Token.Symbol)
+	assert.Equal(t, "LTR", result.Tokens[0].Token.Name)
+	require.NotEmpty(t, chain.queries)
+	assert.Equal(t, big.NewInt(101), chain.queries[0].FromBlock)
+	assert.Equal(t, big.NewInt(150), chain.queries[0].ToBlock)
+
+	// Nothing new to scan
+	chain.queries = nil
+	result, err = discoverer.Discover(context.Background(), account)
+	require.NoError(t, err)
+	assert.Empty(t, result.Tokens)
+	assert.Empty(t, chain.queries)
+}
+
+func TestDiscover_MetadataNotFetched(t *testing.T) {
+	chain := &fakeChain{
+		t:    t,
+		head: 100,
+		logs: []gethtypes.Log{
+			transferLog(unlisted, account, 60),
+		},
+		tokens: map[common.Address]fakeERC20{
+			unlisted: {name: "Unlisted Token", symbol: "UNL", decimals: 9},
+		},
+		callErr: errors.New("rate limited"),
+	}
+	cursors := discovery.NewMemoryCursorStore()
+
+	discoverer, err := discovery.New(discovery.Config{
+		ChainID:     chainID,
+		Client:      chain,
+		Caller:      chain,
+		TokenSource: fakeTokenSource{},
+		CursorStore: cursors,
+	})
+	require.NoError(t, err)
+
+	result, err := discoverer.Discover(context.Background(), account)
+	assert.ErrorIs(t, err, discovery.ErrMetadataNotFetched)
+	assert.ErrorIs(t, err, chain.callErr)
+	assert.Empty(t, result.Tokens)
+	assert.Error(t, result.Failed[unlisted])
+
+	// The cursor is not advanced
+	_, ok, err := cursors.GetLastScannedBlock(chainID, account)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// The token is discovered once the calls succeed
+	chain.callErr = nil
+	result, err = discoverer.Discover(context.Background(), account)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), result.FromBlock)
+	require.Len(t, result.Tokens, 1)
+	assert.Equal(t, "UNL", result.Tokens[0].Token.Symbol)
+	assert.Empty(t, result.Failed)
+}
+
+func TestNew_Validate(t *testing.T) {
+	chain := &fakeChain{t: t}
+	_, err := discovery.New(discovery.Config{Caller: chain, TokenSource: fakeTokenSource{}, CursorStore: discovery.NewMemoryCursorStore()})
+	assert.ErrorIs(t, err, discovery.ErrClientNotProvided)
+
+	_, err = discovery.New(discovery.Config{Client: chain, Caller: chain, TokenSource: fakeTokenSource{}})
+	assert.ErrorIs(t, err, discovery.ErrCursorStoreNotProvided)
+}
@@ -0,0 +1,8 @@
+// Package discovery finds the tokens an account has received by scanning its
+// transfer history, so that tokens missing from the token lists are also tracked.
+//
+// Tokens found in the known token lists are reported as verified. Others are
+// built from their on-chain metadata and reported as unverified. Scans are
+// incremental: the last scanned block of every account is kept in a CursorStore
+// and the next scan resumes right after it.
+package discovery
@@ -0,0 +1,89 @@
+package discovery
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventfilter"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+type Standard string
+
+const (
+	StandardERC20   Standard = "erc20"
+	StandardERC721  Standard = "erc721"
+	StandardERC1155 Standard = "erc1155"
+)
+
+// Client needed to scan the transfer history.
+type Client interface {
+	eventfilter.FilterClient
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// TokenSource looks up tokens of the known token lists, implemented by tokens/manager.Manager.
+type TokenSource interface {
+	GetTokenByChainAddress(chainID uint64, addr common.Address) (*types.Token, bool)
+}
+
+// CursorStore keeps the last scanned block of each account.
+type CursorStore interface {
+	// GetLastScannedBlock returns false if the account was never scanned.
+	GetLastScannedBlock(chainID uint64, account common.Address) (uint64, bool, error)
+	SetLastScannedBlock(chainID uint64, account common.Address, blockNumber uint64) error
+}
+
+type DiscoveredToken struct {
+	Token    *types.Token
+	Standard Standard
+	// Whether the token is part of the known token lists. Unverified tokens
+	// are built from on-chain metadata, which anyone can set.
+	Verified bool
+	// Block of the first transfer to the account within the scanned range
+	FirstSeenBlock uint64
+}
+
+type Result struct {
+	Account common.Address
+	// Scanned block range, empty when FromBlock > ToBlock
+	FromBlock uint64
+	ToBlock   uint64
+	Tokens    []DiscoveredToken
+	// Contracts that received transfers but couldn't be converted to a token
+	// (e.g. no symbol or decimals)
+	Failed map[common.Address]error
+}
+
+// In-memory CursorStore, scans resume within the process lifetime only.
+type MemoryCursorStore struct {
+	mu      sync.RWMutex
+	cursors map[cursorKey]uint64
+}
+
+type cursorKey struct {
+	chainID uint64
+	account common.Address
+}
+
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{
+		cursors: make(map[cursorKey]uint64),
+	}
+}
+
+func (s *MemoryCursorStore) GetLastScannedBlock(chainID uint64, account common.Address) (uint64, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	blockNumber, ok := s.cursors[cursorKey{chainID, account}]
+	return blockNumber, ok, nil
+}
+
+func (s *MemoryCursorStore) SetLastScannedBlock(chainID uint64, account common.Address, blockNumber uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursors[cursorKey{chainID, account}] = blockNumber
+	return nil
+}
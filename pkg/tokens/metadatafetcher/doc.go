@@ -0,0 +1,8 @@
+// Package metadatafetcher fetches token metadata (name, symbol, decimals, total
+// supply, token URIs) for ERC20, ERC721 and ERC1155 contracts in bulk using
+// Multicall3 batched calls.
+//
+// It handles legacy tokens returning bytes32 instead of string, contracts that
+// don't implement the optional metadata methods, and ERC165 interface detection.
+// Results can be converted into tokens/types.Token.
+package metadatafetcher
@@ -0,0 +1,79 @@
+package metadatafetcher
+
+import (
+	"context"
+	"math/big"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+type FetchConfig struct {
+	ERC20 []ContractAddress
+	// Token IDs for which tokenURI(id) is fetched, may be empty
+	ERC721 map[ContractAddress][]*big.Int
+	// Token IDs for which uri(id) is fetched, may be empty
+	ERC1155 map[ContractAddress][]*big.Int
+}
+
+type FetchResult struct {
+	ERC20   map[ContractAddress]ERC20Metadata
+	ERC721  map[ContractAddress]ERC721Metadata
+	ERC1155 map[ContractAddress]ERC1155Metadata
+}
+
+// Fetches metadata for all contracts specified in the FetchConfig using Multicall3 batched calls.
+// Failures are reported per contract (Err) and per field (FieldErrs), so the
+// result always contains one entry for each requested contract.
+func FetchMetadata(ctx context.Context, caller multicall.Caller, config FetchConfig, batchSize int) FetchResult {
+	// One job per contract
+	jobCount := len(config.ERC20) + len(config.ERC721) + len(config.ERC1155)
+	jobs := make([]multicall.Job, 0, jobCount)
+
+	type jobResultProcessor func(multicall.JobResult)
+	jobResultProcessors := make([]jobResultProcessor, 0, jobCount)
+
+	ret := FetchResult{
+		ERC20:   make(map[ContractAddress]ERC20Metadata, len(config.ERC20)),
+		ERC721:  make(map[ContractAddress]ERC721Metadata, len(config.ERC721)),
+		ERC1155: make(map[ContractAddress]ERC1155Metadata, len(config.ERC1155)),
+	}
+
+	for _, contractAddress := range config.ERC20 {
+		jobs = append(jobs, buildERC20Job(contractAddress))
+		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) {
+			ret.ERC20[contractAddress] = processERC20JobResult(contractAddress, jobResult)
+		})
+	}
+
+	for contractAddress, tokenIDs := range config.ERC721 {
+		jobs = append(jobs, buildERC721Job(contractAddress, tokenIDs))
+		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) {
+			ret.ERC721[contractAddress] = processERC721JobResult(contractAddress, tokenIDs, jobResult)
+		})
+	}
+
+	for contractAddress, tokenIDs := range config.ERC1155 {
+		jobs = append(jobs, buildERC1155Job(contractAddress, tokenIDs))
+		jobResultProcessors = append(jobResultProcessors, func(jobResult multicall.JobResult) {
+			ret.ERC1155[contractAddress] = processERC1155JobResult(contractAddress, tokenIDs, jobResult)
+		})
+	}
+
+	jobResults := multicall.RunSync(ctx, jobs, gethrpc.BlockNumberOrHashWithNumber(gethrpc.LatestBlockNumber), caller, batchSize)
+	for i, jobResult := range jobResults {
+		jobResultProcessors[i](jobResult)
+	}
+
+	return ret
+}
+
+func processStringField(result multicall3.IMulticall3Result, field Field, fieldErrs map[Field]error) string {
+	value, err := multicall.ProcessStringResult(result)
+	if err != nil {
+		fieldErrs[field] = err
+	}
+	return value
+}
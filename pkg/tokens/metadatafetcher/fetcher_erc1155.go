@@ -0,0 +1,71 @@
+package metadatafetcher
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// supportsInterface(ERC1155), supportsInterface(ERC1155MetadataURI), supportsInterface(0xffffffff), name, symbol
+const erc1155FixedCallCount = 5
+
+func buildERC1155Job(contractAddress ContractAddress, tokenIDs []*big.Int) multicall.Job {
+	job := multicall.Job{
+		Calls:        make([]multicall3.IMulticall3Call, 0, erc1155FixedCallCount+len(tokenIDs)),
+		CallResultFn: multicall.RawCallResult,
+	}
+	job.Calls = append(job.Calls,
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.ERC1155InterfaceID),
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.ERC1155MetadataURIInterfaceID),
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.InvalidInterfaceID),
+		// Not part of the standard, but widely implemented
+		multicall.BuildERC721NameCall(contractAddress),
+		multicall.BuildERC721SymbolCall(contractAddress),
+	)
+	for _, tokenID := range tokenIDs {
+		job.Calls = append(job.Calls, multicall.BuildERC1155URICall(contractAddress, tokenID))
+	}
+	return job
+}
+
+func processERC1155JobResult(contractAddress ContractAddress, tokenIDs []*big.Int, jobResult multicall.JobResult) (result ERC1155Metadata) {
+	result = ERC1155Metadata{
+		ContractAddress: contractAddress,
+		URIs:            make(map[HashableTokenID]string),
+		FieldErrs:       make(map[Field]error),
+		URIErrs:         make(map[HashableTokenID]error),
+	}
+	if jobResult.Err != nil {
+		result.Err = jobResult.Err
+		return
+	}
+	result.AtBlockNumber = jobResult.BlockNumber
+	result.AtBlockHash = jobResult.BlockHash
+
+	expectedCallCount := erc1155FixedCallCount + len(tokenIDs)
+	if len(jobResult.Results) != expectedCallCount {
+		result.Err = errors.New("expected " + strconv.Itoa(expectedCallCount) + " call results, got " + strconv.Itoa(len(jobResult.Results)))
+		return
+	}
+
+	results := multicall.RawResults(jobResult)
+	result.SupportsERC1155, _ = multicall.ProcessSupportsInterfaceResults(results[0], results[2])
+	result.SupportsMetadataURI, _ = multicall.ProcessSupportsInterfaceResults(results[1], results[2])
+	result.Name = processStringField(results[3], FieldName, result.FieldErrs)
+	result.Symbol = processStringField(results[4], FieldSymbol, result.FieldErrs)
+
+	for i, tokenID := range tokenIDs {
+		id := ToHashableTokenID(tokenID)
+		uri, err := multicall.ProcessStringResult(results[erc1155FixedCallCount+i])
+		if err != nil {
+			result.URIErrs[id] = err
+			continue
+		}
+		result.URIs[id] = uri
+	}
+
+	return
+}
@@ -0,0 +1,59 @@
+package metadatafetcher
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+const erc20CallCount = 4
+
+func buildERC20Job(contractAddress ContractAddress) multicall.Job {
+	return multicall.Job{
+		Calls: []multicall3.IMulticall3Call{
+			multicall.BuildERC20NameCall(contractAddress),
+			multicall.BuildERC20SymbolCall(contractAddress),
+			multicall.BuildERC20DecimalsCall(contractAddress),
+			multicall.BuildERC20TotalSupplyCall(contractAddress),
+		},
+		CallResultFn: multicall.RawCallResult,
+	}
+}
+
+func processERC20JobResult(contractAddress ContractAddress, jobResult multicall.JobResult) (result ERC20Metadata) {
+	result = ERC20Metadata{
+		ContractAddress: contractAddress,
+		FieldErrs:       make(map[Field]error),
+	}
+	if jobResult.Err != nil {
+		result.Err = jobResult.Err
+		return
+	}
+	result.AtBlockNumber = jobResult.BlockNumber
+	result.AtBlockHash = jobResult.BlockHash
+
+	if len(jobResult.Results) != erc20CallCount {
+		result.Err = errors.New("expected " + strconv.Itoa(erc20CallCount) + " call results, got " + strconv.Itoa(len(jobResult.Results)))
+		return
+	}
+
+	results := multicall.RawResults(jobResult)
+	result.Name = processStringField(results[0], FieldName, result.FieldErrs)
+	result.Symbol = processStringField(results[1], FieldSymbol, result.FieldErrs)
+
+	decimals, err := multicall.ProcessUint8Result(results[2])
+	if err != nil {
+		result.FieldErrs[FieldDecimals] = err
+	}
+	result.Decimals = decimals
+
+	totalSupply, err := multicall.ProcessUint256Result(results[3])
+	if err != nil {
+		result.FieldErrs[FieldTotalSupply] = err
+	}
+	result.TotalSupply = totalSupply
+
+	return
+}
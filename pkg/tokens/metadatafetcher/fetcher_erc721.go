@@ -0,0 +1,73 @@
+package metadatafetcher
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+)
+
+// supportsInterface(ERC721), supportsInterface(ERC721Metadata), supportsInterface(ERC721Enumerable),
+// supportsInterface(0xffffffff), name, symbol
+const erc721FixedCallCount = 6
+
+func buildERC721Job(contractAddress ContractAddress, tokenIDs []*big.Int) multicall.Job {
+	job := multicall.Job{
+		Calls:        make([]multicall3.IMulticall3Call, 0, erc721FixedCallCount+len(tokenIDs)),
+		CallResultFn: multicall.RawCallResult,
+	}
+	job.Calls = append(job.Calls,
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.ERC721InterfaceID),
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.ERC721MetadataInterfaceID),
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.ERC721EnumerableInterfaceID),
+		multicall.BuildSupportsInterfaceCall(contractAddress, multicall.InvalidInterfaceID),
+		multicall.BuildERC721NameCall(contractAddress),
+		multicall.BuildERC721SymbolCall(contractAddress),
+	)
+	for _, tokenID := range tokenIDs {
+		job.Calls = append(job.Calls, multicall.BuildERC721TokenURICall(contractAddress, tokenID))
+	}
+	return job
+}
+
+func processERC721JobResult(contractAddress ContractAddress, tokenIDs []*big.Int, jobResult multicall.JobResult) (result ERC721Metadata) {
+	result = ERC721Metadata{
+		ContractAddress: contractAddress,
+		TokenURIs:       make(map[HashableTokenID]string),
+		FieldErrs:       make(map[Field]error),
+		TokenURIErrs:    make(map[HashableTokenID]error),
+	}
+	if jobResult.Err != nil {
+		result.Err = jobResult.Err
+		return
+	}
+	result.AtBlockNumber = jobResult.BlockNumber
+	result.AtBlockHash = jobResult.BlockHash
+
+	expectedCallCount := erc721FixedCallCount + len(tokenIDs)
+	if len(jobResult.Results) != expectedCallCount {
+		result.Err = errors.New("expected " + strconv.Itoa(expectedCallCount) + " call results, got " + strconv.Itoa(len(jobResult.Results)))
+		return
+	}
+
+	results := multicall.RawResults(jobResult)
+	result.SupportsERC721, _ = multicall.ProcessSupportsInterfaceResults(results[0], results[3])
+	result.SupportsMetadata, _ = multicall.ProcessSupportsInterfaceResults(results[1], results[3])
+	result.SupportsEnumerable, _ = multicall.ProcessSupportsInterfaceResults(results[2], results[3])
+	result.Name = processStringField(results[4], FieldName, result.FieldErrs)
+	result.Symbol = processStringField(results[5], FieldSymbol, result.FieldErrs)
+
+	for i, tokenID := range tokenIDs {
+		id := ToHashableTokenID(tokenID)
+		uri, err := multicall.ProcessStringResult(results[erc721FixedCallCount+i])
+		if err != nil {
+			result.TokenURIErrs[id] = err
+			continue
+		}
+		result.TokenURIs[id] = uri
+	}
+
+	return
+}
@@ -0,0 +1,290 @@
+package metadatafetcher_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/multicall3"
+	"github.com/status-im/go-wallet-sdk/pkg/multicall"
+	mock_multicall "github.com/status-im/go-wallet-sdk/pkg/multicall/mock"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/metadatafetcher"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	usdc    = common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	mkr     = common.HexToAddress("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2")
+	eoa     = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bayc    = common.HexToAddress("0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D")
+	opensea = common.HexToAddress("0x495f947276749Ce646f68AC8c248420045cb7b5e")
+
+	blockNumber = big.NewInt(12345)
+	blockHash   = [32]byte{1, 2, 3}
+)
+
+func stringResult(t *testing.T, value string) multicall3.IMulticall3Result {
+	stringType, err := abi.NewType("string", "", nil)
+	require.NoError(t, err)
+	data, err := abi.Arguments{{Type: stringType}}.Pack(value)
+	require.NoError(t, err)
+	return multicall3.IMulticall3Result{Success: true, ReturnData: data}
+}
+
+func uintResult(value int64) multicall3.IMulticall3Result {
+	return multicall3.IMulticall3Result{Success: true, ReturnData: common.LeftPadBytes(big.NewInt(value).Bytes(), 32)}
+}
+
+func bytes32Result(value string) multicall3.IMulticall3Result {
+	return multicall3.IMulticall3Result{Success: true, ReturnData: common.RightPadBytes([]byte(value), 32)}
+}
+
+var (
+	revertResult = multicall3.IMulticall3Result{Success: false}
+	emptyResult  = multicall3.IMulticall3Result{Success: true}
+)
+
+func expectCalls(t *testing.T, mockCaller *mock_multicall.MockCaller, expectedCalls []multicall3.IMulticall3Call, results []multicall3.IMulticall3Result) {
+	mockCaller.EXPECT().
+		ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).
+		DoAndReturn(func(opts *bind.CallOpts, requireSuccess bool, calls []multicall3.IMulticall3Call) (*big.Int, [32]byte, []multicall3.IMulticall3Result, error) {
+			require.Equal(t, expectedCalls, calls)
+			return blockNumber, blockHash, results, nil
+		})
+}
+
+func TestFetchMetadata_ERC20(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	var expectedCalls []multicall3.IMulticall3Call
+	for _, token := range []common.Address{usdc, mkr, eoa} {
+		expectedCalls = append(expectedCalls,
+			multicall.BuildERC20NameCall(token),
+			multicall.BuildERC20SymbolCall(token),
+			multicall.BuildERC20DecimalsCall(token),
+			multicall.BuildERC20TotalSupplyCall(token),
+		)
+	}
+	results := []multicall3.IMulticall3Result{
+		// USDC
+		stringResult(t, "USD Coin"), stringResult(t, "USDC"), uintResult(6), uintResult(1000),
+		// MKR, returns bytes32
+		bytes32Result("Maker"), bytes32Result("MKR"), uintResult(18), uintResult(2000),
+		// Not a contract, every call succeeds with no data
+		emptyResult, emptyResult, emptyResult, emptyResult,
+	}
+	expectCalls(t, mockCaller, expectedCalls, results)
+
+	config := metadatafetcher.FetchConfig{ERC20: []common.Address{usdc, mkr, eoa}}
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, config, 100)
+	require.Len(t, result.ERC20, 3)
+
+	usdcMetadata := result.ERC20[usdc]
+	assert.NoError(t, usdcMetadata.Err)
+	assert.Empty(t, usdcMetadata.FieldErrs)
+	assert.Equal(t, "USD Coin", usdcMetadata.Name)
+	assert.Equal(t, "USDC", usdcMetadata.Symbol)
+	assert.Equal(t, uint8(6), usdcMetadata.Decimals)
+	assert.Equal(t, big.NewInt(1000), usdcMetadata.TotalSupply)
+	assert.Equal(t, blockNumber, usdcMetadata.AtBlockNumber)
+	assert.Equal(t, common.Hash(blockHash), usdcMetadata.AtBlockHash)
+
+	token, err := usdcMetadata.ToToken(1)
+	require.NoError(t, err)
+	assert.Equal(t, &types.Token{ChainID: 1, Address: usdc, Decimals: 6, Name: "USD Coin", Symbol: "USDC"}, token)
+
+	mkrMetadata := result.ERC20[mkr]
+	assert.Empty(t, mkrMetadata.FieldErrs)
+	assert.Equal(t, "Maker", mkrMetadata.Name)
+	assert.Equal(t, "MKR", mkrMetadata.Symbol)
+	assert.Equal(t, uint8(18), mkrMetadata.Decimals)
+
+	eoaMetadata := result.ERC20[eoa]
+	assert.NoError(t, eoaMetadata.Err)
+	assert.Len(t, eoaMetadata.FieldErrs, 4)
+	assert.ErrorIs(t, eoaMetadata.FieldErrs[metadatafetcher.FieldDecimals], multicall.ErrNoReturnData)
+	_, err = eoaMetadata.ToToken(1)
+	assert.ErrorIs(t, err, metadatafetcher.ErrMissingDecimals)
+}
+
+func TestFetchMetadata_ERC20_MissingOptionalMethods(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	expectedCalls := []multicall3.IMulticall3Call{
+		multicall.BuildERC20NameCall(usdc),
+		multicall.BuildERC20SymbolCall(usdc),
+		multicall.BuildERC20DecimalsCall(usdc),
+		multicall.BuildERC20TotalSupplyCall(usdc),
+	}
+	results := []multicall3.IMulticall3Result{revertResult, stringResult(t, "TKN"), uintResult(18), uintResult(1)}
+	expectCalls(t, mockCaller, expectedCalls, results)
+
+	config := metadatafetcher.FetchConfig{ERC20: []common.Address{usdc}}
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, config, 100)
+
+	metadata := result.ERC20[usdc]
+	var revertErr *multicall.RevertError
+	assert.ErrorAs(t, metadata.FieldErrs[metadatafetcher.FieldName], &revertErr)
+
+	// Name falls back to symbol
+	token, err := metadata.ToToken(10)
+	require.NoError(t, err)
+	assert.Equal(t, "TKN", token.Name)
+	assert.Equal(t, "TKN", token.Symbol)
+	assert.Equal(t, uint(18), token.Decimals)
+}
+
+func TestFetchMetadata_ERC721(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	tokenIDs := []*big.Int{big.NewInt(1), big.NewInt(2)}
+	expectedCalls := []multicall3.IMulticall3Call{
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.ERC721InterfaceID),
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.ERC721MetadataInterfaceID),
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.ERC721EnumerableInterfaceID),
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.InvalidInterfaceID),
+		multicall.BuildERC721NameCall(bayc),
+		multicall.BuildERC721SymbolCall(bayc),
+		multicall.BuildERC721TokenURICall(bayc, tokenIDs[0]),
+		multicall.BuildERC721TokenURICall(bayc, tokenIDs[1]),
+	}
+	results := []multicall3.IMulticall3Result{
+		uintResult(1), uintResult(1), revertResult, uintResult(0),
+		stringResult(t, "BoredApeYachtClub"), stringResult(t, "BAYC"),
+		stringResult(t, "ipfs://bayc/1"), revertResult,
+	}
+	expectCalls(t, mockCaller, expectedCalls, results)
+
+	config := metadatafetcher.FetchConfig{ERC721: map[common.Address][]*big.Int{bayc: tokenIDs}}
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, config, 100)
+
+	metadata := result.ERC721[bayc]
+	assert.NoError(t, metadata.Err)
+	assert.True(t, metadata.SupportsERC721)
+	assert.True(t, metadata.SupportsMetadata)
+	assert.False(t, metadata.SupportsEnumerable)
+	assert.Equal(t, "BoredApeYachtClub", metadata.Name)
+	assert.Equal(t, "BAYC", metadata.Symbol)
+	assert.Equal(t, map[metadatafetcher.HashableTokenID]string{
+		metadatafetcher.ToHashableTokenID(tokenIDs[0]): "ipfs://bayc/1",
+	}, metadata.TokenURIs)
+	assert.Contains(t, metadata.TokenURIErrs, metadatafetcher.ToHashableTokenID(tokenIDs[1]))
+
+	token, err := metadata.ToToken(1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), token.Decimals)
+	assert.Equal(t, "BAYC", token.Symbol)
+}
+
+func TestFetchMetadata_ERC721WithoutERC165(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	expectedCalls := []multicall3.IMulticall3Call{
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.ERC721InterfaceID),
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.ERC721MetadataInterfaceID),
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.ERC721EnumerableInterfaceID),
+		multicall.BuildSupportsInterfaceCall(bayc, multicall.InvalidInterfaceID),
+		multicall.BuildERC721NameCall(bayc),
+		multicall.BuildERC721SymbolCall(bayc),
+	}
+	// e.g. an ERC20 token queried as ERC721
+	results := []multicall3.IMulticall3Result{
+		revertResult, revertResult, revertResult, revertResult,
+		stringResult(t, "USD Coin"), stringResult(t, "USDC"),
+	}
+	expectCalls(t, mockCaller, expectedCalls, results)
+
+	config := metadatafetcher.FetchConfig{ERC721: map[common.Address][]*big.Int{bayc: nil}}
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, config, 100)
+
+	metadata := result.ERC721[bayc]
+	assert.NoError(t, metadata.Err)
+	assert.False(t, metadata.SupportsERC721)
+	assert.Equal(t, "USDC", metadata.Symbol)
+	_, err := metadata.ToToken(1)
+	assert.ErrorIs(t, err, metadatafetcher.ErrNotERC721)
+}
+
+func TestFetchMetadata_ERC1155(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	tokenID := big.NewInt(0x4cce)
+	expectedCalls := []multicall3.IMulticall3Call{
+		multicall.BuildSupportsInterfaceCall(opensea, multicall.ERC1155InterfaceID),
+		multicall.BuildSupportsInterfaceCall(opensea, multicall.ERC1155MetadataURIInterfaceID),
+		multicall.BuildSupportsInterfaceCall(opensea, multicall.InvalidInterfaceID),
+		multicall.BuildERC721NameCall(opensea),
+		multicall.BuildERC721SymbolCall(opensea),
+		multicall.BuildERC1155URICall(opensea, tokenID),
+	}
+	results := []multicall3.IMulticall3Result{
+		uintResult(1), uintResult(1), uintResult(0), revertResult, revertResult,
+		stringResult(t, "https://example.com/api/{id}.json"),
+	}
+	expectCalls(t, mockCaller, expectedCalls, results)
+
+	config := metadatafetcher.FetchConfig{ERC1155: map[common.Address][]*big.Int{opensea: {tokenID}}}
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, config, 100)
+
+	metadata := result.ERC1155[opensea]
+	assert.NoError(t, metadata.Err)
+	assert.True(t, metadata.SupportsERC1155)
+	assert.True(t, metadata.SupportsMetadataURI)
+	assert.Contains(t, metadata.FieldErrs, metadatafetcher.FieldName)
+	assert.Contains(t, metadata.FieldErrs, metadatafetcher.FieldSymbol)
+
+	uri := metadata.URIs[metadatafetcher.ToHashableTokenID(tokenID)]
+	assert.Equal(t, "https://example.com/api/{id}.json", uri)
+	assert.Equal(t,
+		"https://example.com/api/0000000000000000000000000000000000000000000000000000000000004cce.json",
+		metadatafetcher.ResolveERC1155URI(uri, tokenID))
+
+	_, err := metadata.ToToken(1)
+	assert.ErrorIs(t, err, types.ErrNoSymbol)
+
+	metadata.Symbol = "OS"
+	metadata.SupportsERC1155 = false
+	_, err = metadata.ToToken(1)
+	assert.ErrorIs(t, err, metadatafetcher.ErrNotERC1155)
+}
+
+func TestFetchMetadata_JobError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	expectedErr := errors.New("network error")
+	mockCaller.EXPECT().
+		ViewTryBlockAndAggregate(gomock.Any(), false, gomock.Any()).
+		Return(nil, [32]byte{}, nil, expectedErr)
+
+	config := metadatafetcher.FetchConfig{ERC20: []common.Address{usdc}}
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, config, 100)
+
+	metadata := result.ERC20[usdc]
+	assert.Equal(t, expectedErr, metadata.Err)
+	_, err := metadata.ToToken(1)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestFetchMetadata_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockCaller := mock_multicall.NewMockCaller(ctrl)
+
+	result := metadatafetcher.FetchMetadata(context.Background(), mockCaller, metadatafetcher.FetchConfig{}, 100)
+	assert.Empty(t, result.ERC20)
+	assert.Empty(t, result.ERC721)
+	assert.Empty(t, result.ERC1155)
+}
@@ -0,0 +1,146 @@
+package metadatafetcher
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	ErrMissingDecimals = errors.New("token decimals not available")
+	ErrNotERC721       = errors.New("contract does not support the ERC721 interface")
+	ErrNotERC1155      = errors.New("contract does not support the ERC1155 interface")
+)
+
+type ContractAddress = common.Address
+type HashableTokenID = [32]byte // *big.Int not comparable, use u256 fixed length array
+
+func ToHashableTokenID(tokenID *big.Int) (ret HashableTokenID) {
+	tokenID.FillBytes(ret[:])
+	return
+}
+
+// Field identifies a metadata method whose call may fail independently of the others.
+type Field string
+
+const (
+	FieldName        Field = "name"
+	FieldSymbol      Field = "symbol"
+	FieldDecimals    Field = "decimals"
+	FieldTotalSupply Field = "totalSupply"
+)
+
+type ERC20Metadata struct {
+	ContractAddress ContractAddress
+	Name            string
+	Symbol          string
+	Decimals        uint8
+	TotalSupply     *big.Int
+	// Job-level error, no field could be fetched
+	Err error
+	// Errors for individual fields that could not be fetched or decoded
+	FieldErrs     map[Field]error
+	AtBlockNumber *big.Int
+	AtBlockHash   common.Hash
+}
+
+type ERC721Metadata struct {
+	ContractAddress    ContractAddress
+	SupportsERC721     bool
+	SupportsMetadata   bool
+	SupportsEnumerable bool
+	Name               string
+	Symbol             string
+	TokenURIs          map[HashableTokenID]string
+	// Job-level error, no field could be fetched
+	Err error
+	// Errors for individual fields that could not be fetched or decoded
+	FieldErrs     map[Field]error
+	TokenURIErrs  map[HashableTokenID]error
+	AtBlockNumber *big.Int
+	AtBlockHash   common.Hash
+}
+
+type ERC1155Metadata struct {
+	ContractAddress     ContractAddress
+	SupportsERC1155     bool
+	SupportsMetadataURI bool
+	// name() and symbol() are not part of the ERC1155 standard, but many contracts implement them
+	Name   string
+	Symbol string
+	// Raw URIs as returned by uri(id), see ResolveERC1155URI
+	URIs map[HashableTokenID]string
+	// Job-level error, no field could be fetched
+	Err error
+	// Errors for individual fields that could not be fetched or decoded
+	FieldErrs     map[Field]error
+	URIErrs       map[HashableTokenID]error
+	AtBlockNumber *big.Int
+	AtBlockHash   common.Hash
+}
+
+// Converts the fetched metadata into a Token for the given chain.
+// Fails if the contract doesn't expose decimals() or a symbol, which is the case
+// for most contracts that are not ERC20 tokens.
+func (m ERC20Metadata) ToToken(chainID uint64) (*types.Token, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if err := m.FieldErrs[FieldDecimals]; err != nil {
+		return nil, errors.Join(ErrMissingDecimals, err)
+	}
+	return newToken(chainID, m.ContractAddress, uint(m.Decimals), m.Name, m.Symbol)
+}
+
+// Converts the fetched metadata into a Token (with 0 decimals) for the given chain.
+// ERC721 requires ERC165, so contracts failing the supportsInterface probe are rejected.
+func (m ERC721Metadata) ToToken(chainID uint64) (*types.Token, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if !m.SupportsERC721 {
+		return nil, ErrNotERC721
+	}
+	return newToken(chainID, m.ContractAddress, 0, m.Name, m.Symbol)
+}
+
+// Converts the fetched metadata into a Token (with 0 decimals) for the given chain.
+// ERC1155 requires ERC165, so contracts failing the supportsInterface probe are rejected.
+func (m ERC1155Metadata) ToToken(chainID uint64) (*types.Token, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	if !m.SupportsERC1155 {
+		return nil, ErrNotERC1155
+	}
+	return newToken(chainID, m.ContractAddress, 0, m.Name, m.Symbol)
+}
+
+func newToken(chainID uint64, address common.Address, decimals uint, name string, symbol string) (*types.Token, error) {
+	name = strings.TrimSpace(name)
+	symbol = strings.TrimSpace(symbol)
+	if symbol == "" {
+		return nil, types.ErrNoSymbol
+	}
+	if name == "" {
+		name = symbol
+	}
+	return &types.Token{
+		ChainID:  chainID,
+		Address:  address,
+		Decimals: decimals,
+		Name:     name,
+		Symbol:   symbol,
+	}, nil
+}
+
+// Replaces the "{id}" placeholder in an ERC1155 URI with the lowercase,
+// 64 character hex representation of the token ID, as defined in EIP-1155.
+func ResolveERC1155URI(uri string, tokenID *big.Int) string {
+	return strings.ReplaceAll(uri, "{id}", fmt.Sprintf("%064x", tokenID))
+}
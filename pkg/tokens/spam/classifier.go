@@ -0,0 +1,159 @@
+package spam
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/discovery"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+// Score added by each reason, a token is spam once the total reaches the threshold.
+var reasonScores = map[Reason]int{
+	ReasonUnlisted:            10,
+	ReasonURL:                 50,
+	ReasonLookalikeCharacters: 40,
+	ReasonImpersonation:       50,
+	ReasonZeroValueTransfers:  40,
+	ReasonNonStandardBytecode: 30,
+}
+
+type Classifier struct {
+	config Config
+
+	mu sync.RWMutex
+	// Normalized symbol -> known tokens with a CrossChainID
+	wellKnownSymbols map[string][]*types.Token
+}
+
+func New(config Config) (*Classifier, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	c := &Classifier{config: config}
+	c.UpdateKnownTokens()
+	return c, nil
+}
+
+// Rebuilds the index of well-known symbols from the token source.
+// Call it whenever the token lists are refreshed.
+func (c *Classifier) UpdateKnownTokens() {
+	wellKnownSymbols := make(map[string][]*types.Token)
+	for _, token := range c.config.TokenSource.UniqueTokens() {
+		if token.CrossChainID == "" || token.CustomToken {
+			continue
+		}
+		symbol := normalizeSymbol(token.Symbol)
+		wellKnownSymbols[symbol] = append(wellKnownSymbols[symbol], token)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.wellKnownSymbols = wellKnownSymbols
+}
+
+// Classifies a single token. User overrides take precedence, then token list
+// membership; heuristics only apply to unlisted tokens.
+func (c *Classifier) Classify(ctx context.Context, input Input) (Verdict, error) {
+	token := input.Token
+	if token == nil {
+		return Verdict{}, ErrTokenNotProvided
+	}
+
+	override, ok, err := c.config.OverrideStore.GetOverride(token.ChainID, token.Address)
+	if err != nil {
+		return Verdict{}, err
+	}
+	if ok {
+		switch override {
+		case OverrideSpam:
+			return Verdict{Score: MaxScore, Spam: true, Reasons: []Reason{ReasonUserMarkedSpam}}, nil
+		case OverrideTrusted:
+			return Verdict{Reasons: []Reason{ReasonUserMarkedTrusted}}, nil
+		}
+	}
+
+	if token.IsNative() {
+		return Verdict{Reasons: []Reason{ReasonListed}}, nil
+	}
+	if listed, ok := c.config.TokenSource.GetTokenByChainAddress(token.ChainID, token.Address); ok && !listed.CustomToken {
+		return Verdict{Reasons: []Reason{ReasonListed}}, nil
+	}
+
+	reasons := []Reason{ReasonUnlisted}
+	if containsURL(token.Name) || containsURL(token.Symbol) {
+		reasons = append(reasons, ReasonURL)
+	}
+	if hasLookalikeCharacters(token.Name) || hasLookalikeCharacters(token.Symbol) {
+		reasons = append(reasons, ReasonLookalikeCharacters)
+	}
+	if c.impersonates(token) {
+		reasons = append(reasons, ReasonImpersonation)
+	}
+	if hasZeroValueTransfers(input.Events) {
+		reasons = append(reasons, ReasonZeroValueTransfers)
+	}
+	if c.config.CodeClient != nil {
+		code, err := c.config.CodeClient.CodeAt(ctx, token.Address, nil)
+		if err != nil {
+			return Verdict{}, err
+		}
+		if isNonStandardBytecode(code) {
+			reasons = append(reasons, ReasonNonStandardBytecode)
+		}
+	}
+
+	return c.verdict(reasons), nil
+}
+
+// Classifies the tokens found by the discovery package, keyed by token key.
+// Events are matched to the tokens by contract address.
+func (c *Classifier) ClassifyDiscovered(ctx context.Context, tokens []discovery.DiscoveredToken, events []eventlog.Event) (map[string]Verdict, error) {
+	contractEvents := make(map[common.Address][]eventlog.Event)
+	for _, event := range events {
+		if contractAddress, ok := eventContract(event); ok {
+			contractEvents[contractAddress] = append(contractEvents[contractAddress], event)
+		}
+	}
+
+	ret := make(map[string]Verdict, len(tokens))
+	for _, discovered := range tokens {
+		verdict, err := c.Classify(ctx, Input{
+			Token:  discovered.Token,
+			Events: contractEvents[discovered.Token.Address],
+		})
+		if err != nil {
+			return nil, err
+		}
+		ret[discovered.Token.Key()] = verdict
+	}
+	return ret, nil
+}
+
+// Reports unlisted tokens using the symbol of a well-known token.
+func (c *Classifier) impersonates(token *types.Token) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, known := range c.wellKnownSymbols[normalizeSymbol(token.Symbol)] {
+		if known.ChainID != token.ChainID || known.Address != token.Address {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Classifier) verdict(reasons []Reason) Verdict {
+	score := 0
+	for _, reason := range reasons {
+		score += reasonScores[reason]
+	}
+	score = min(score, MaxScore)
+	return Verdict{
+		Score:   score,
+		Spam:    score >= c.config.threshold(),
+		Reasons: reasons,
+	}
+}
@@ -0,0 +1,171 @@
+package spam_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/discovery"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/spam"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+var (
+	usdc        = &types.Token{CrossChainID: "usd-coin", ChainID: 1, Address: common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"), Symbol: "USDC", Name: "USD Coin", Decimals: 6}
+	customToken = &types.Token{ChainID: 1, Address: common.HexToAddress("0xd000000000000000000000000000000000000001"), Symbol: "MYT", Name: "My Token", CustomToken: true}
+	fakeUSDC    = &types.Token{ChainID: 1, Address: common.HexToAddress("0xd000000000000000000000000000000000000002"), Symbol: "UЅDС", Name: "USD Coin", Decimals: 6}
+	phishing    = &types.Token{ChainID: 1, Address: common.HexToAddress("0xd000000000000000000000000000000000000003"), Symbol: "REWARD", Name: "Claim at eth-rewards.xyz"}
+	unlisted    = &types.Token{ChainID: 1, Address: common.HexToAddress("0xd000000000000000000000000000000000000004"), Symbol: "NEW", Name: "New Token", Decimals: 18}
+	poisoning   = &types.Token{ChainID: 1, Address: common.HexToAddress("0xd000000000000000000000000000000000000005"), Symbol: "TKN", Name: "Token", Decimals: 18}
+	tokenCode   = common.FromHex("0x6080604052348015600f57600080fd5b506004361060285760003560e01c806370a0823114602d575b600080fd5b00")
+	errCodeAt   = errors.New("code at failed")
+	bridgedUSD  = &types.Token{CrossChainID: "usd-coin", ChainID: 10, Address: common.HexToAddress("0x0b2C639c533813f4Aa9D7837CAf62653d097Ff85"), Symbol: "USDC", Name: "USD Coin", Decimals: 6}
+)
+
+type fakeTokenSource []*types.Token
+
+func (s fakeTokenSource) UniqueTokens() []*types.Token {
+	return s
+}
+
+func (s fakeTokenSource) GetTokenByChainAddress(chainID uint64, addr common.Address) (*types.Token, bool) {
+	for _, token := range s {
+		if token.ChainID == chainID && token.Address == addr {
+			return token, true
+		}
+	}
+	return nil, false
+}
+
+type fakeCodeClient struct {
+	code map[common.Address][]byte
+	err  error
+}
+
+func (c *fakeCodeClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return c.code[account], c.err
+}
+
+func newClassifier(t *testing.T, codeClient spam.CodeClient) (*spam.Classifier, *spam.MemoryOverrideStore) {
+	overrides := spam.NewMemoryOverrideStore()
+	classifier, err := spam.New(spam.Config{
+		TokenSource:   fakeTokenSource{usdc, bridgedUSD, customToken},
+		OverrideStore: overrides,
+		CodeClient:    codeClient,
+	})
+	require.NoError(t, err)
+	return classifier, overrides
+}
+
+func transferEvent(token *types.Token, value int64) eventlog.Event {
+	return eventlog.Event{
+		EventKey: eventlog.ERC20Transfer,
+		Unpacked: erc20.Erc20Transfer{Value: big.NewInt(value), Raw: gethtypes.Log{Address: token.Address}},
+	}
+}
+
+func TestClassify(t *testing.T) {
+	codeClient := &fakeCodeClient{code: map[common.Address][]byte{
+		fakeUSDC.Address:    tokenCode,
+		phishing.Address:    tokenCode,
+		unlisted.Address:    tokenCode,
+		poisoning.Address:   tokenCode,
+		customToken.Address: common.FromHex("0x6080604052600080fd"),
+	}}
+	classifier, _ := newClassifier(t, codeClient)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		input   spam.Input
+		spam    bool
+		reasons []spam.Reason
+	}{
+		{"listed", spam.Input{Token: usdc}, false, []spam.Reason{spam.ReasonListed}},
+		{"native", spam.Input{Token: &types.Token{ChainID: 1, Symbol: "ETH"}}, false, []spam.Reason{spam.ReasonListed}},
+		{"lookalike impersonation", spam.Input{Token: fakeUSDC}, true, []spam.Reason{spam.ReasonUnlisted, spam.ReasonLookalikeCharacters, spam.ReasonImpersonation}},
+		{"url", spam.Input{Token: phishing}, true, []spam.Reason{spam.ReasonUnlisted, spam.ReasonURL}},
+		{"unlisted", spam.Input{Token: unlisted, Events: []eventlog.Event{transferEvent(unlisted, 10)}}, false, []spam.Reason{spam.ReasonUnlisted}},
+		{"zero value transfers", spam.Input{Token: poisoning, Events: []eventlog.Event{transferEvent(poisoning, 0)}}, true, []spam.Reason{spam.ReasonUnlisted, spam.ReasonZeroValueTransfers}},
+		{"custom token with non-standard bytecode", spam.Input{Token: customToken}, false, []spam.Reason{spam.ReasonUnlisted, spam.ReasonNonStandardBytecode}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := classifier.Classify(ctx, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.spam, verdict.Spam)
+			assert.Equal(t, tt.reasons, verdict.Reasons)
+			assert.LessOrEqual(t, verdict.Score, spam.MaxScore)
+			if !tt.spam {
+				assert.Less(t, verdict.Score, 50)
+			}
+		})
+	}
+
+	_, err := classifier.Classify(ctx, spam.Input{})
+	assert.ErrorIs(t, err, spam.ErrTokenNotProvided)
+}
+
+func TestClassify_Overrides(t *testing.T) {
+	classifier, overrides := newClassifier(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, overrides.SetOverride(phishing.ChainID, phishing.Address, spam.OverrideTrusted))
+	verdict, err := classifier.Classify(ctx, spam.Input{Token: phishing})
+	require.NoError(t, err)
+	assert.False(t, verdict.Spam)
+	assert.Zero(t, verdict.Score)
+	assert.Equal(t, []spam.Reason{spam.ReasonUserMarkedTrusted}, verdict.Reasons)
+
+	require.NoError(t, overrides.SetOverride(usdc.ChainID, usdc.Address, spam.OverrideSpam))
+	verdict, err = classifier.Classify(ctx, spam.Input{Token: usdc})
+	require.NoError(t, err)
+	assert.True(t, verdict.Spam)
+	assert.Equal(t, spam.MaxScore, verdict.Score)
+
+	require.NoError(t, overrides.DeleteOverride(usdc.ChainID, usdc.Address))
+	verdict, err = classifier.Classify(ctx, spam.Input{Token: usdc})
+	require.NoError(t, err)
+	assert.False(t, verdict.Spam)
+}
+
+func TestClassify_CodeAtError(t *testing.T) {
+	classifier, _ := newClassifier(t, &fakeCodeClient{err: errCodeAt})
+	_, err := classifier.Classify(context.Background(), spam.Input{Token: unlisted})
+	assert.ErrorIs(t, err, errCodeAt)
+}
+
+func TestClassifyDiscovered(t *testing.T) {
+	classifier, _ := newClassifier(t, nil)
+	verdicts, err := classifier.ClassifyDiscovered(context.Background(), []discovery.DiscoveredToken{
+		{Token: usdc, Verified: true},
+		{Token: poisoning},
+		{Token: unlisted},
+	}, []eventlog.Event{
+		transferEvent(poisoning, 0),
+		transferEvent(poisoning, 0),
+		transferEvent(unlisted, 1),
+		transferEvent(usdc, 0),
+	})
+	require.NoError(t, err)
+	require.Len(t, verdicts, 3)
+	assert.False(t, verdicts[usdc.Key()].Spam)
+	assert.True(t, verdicts[poisoning.Key()].Spam)
+	assert.False(t, verdicts[unlisted.Key()].Spam)
+}
+
+func TestNew_Validate(t *testing.T) {
+	_, err := spam.New(spam.Config{OverrideStore: spam.NewMemoryOverrideStore()})
+	assert.ErrorIs(t, err, spam.ErrTokenSourceNotProvided)
+
+	_, err = spam.New(spam.Config{TokenSource: fakeTokenSource{}, OverrideStore: spam.NewMemoryOverrideStore(), Threshold: 101})
+	assert.ErrorIs(t, err, spam.ErrInvalidThreshold)
+}
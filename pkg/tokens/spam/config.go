@@ -0,0 +1,43 @@
+package spam
+
+import (
+	"errors"
+)
+
+var (
+	ErrTokenSourceNotProvided   = errors.New("token source is required")
+	ErrOverrideStoreNotProvided = errors.New("override store is required")
+	ErrInvalidThreshold         = errors.New("threshold must be between 1 and 100")
+	ErrTokenNotProvided         = errors.New("token is required")
+)
+
+const defaultThreshold = 50
+
+type Config struct {
+	TokenSource   TokenSource
+	OverrideStore OverrideStore
+	// Bytecode checks are skipped when nil
+	CodeClient CodeClient
+	// Minimum score of spam tokens, 50 when zero
+	Threshold int
+}
+
+func (c *Config) Validate() error {
+	if c.TokenSource == nil {
+		return ErrTokenSourceNotProvided
+	}
+	if c.OverrideStore == nil {
+		return ErrOverrideStoreNotProvided
+	}
+	if c.Threshold < 0 || c.Threshold > MaxScore {
+		return ErrInvalidThreshold
+	}
+	return nil
+}
+
+func (c *Config) threshold() int {
+	if c.Threshold == 0 {
+		return defaultThreshold
+	}
+	return c.Threshold
+}
@@ -0,0 +1,6 @@
+// Package spam classifies tokens and collectible contracts as spam or scams.
+//
+// A Classifier combines token list membership, heuristics on the token name,
+// symbol, transfers and bytecode, and user overrides into a risk score with the
+// reasons that led to it.
+package spam
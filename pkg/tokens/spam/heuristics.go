@@ -0,0 +1,175 @@
+package spam
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+var urlPattern = regexp.MustCompile(`(?i)(https?://|www\.|t\.me/|\b[a-z0-9-]+\.(com|io|org|net|xyz|app|site|top|finance|gift|claims?|link|live|info|cc|co|pro|club|vip|fun|online|website|network)\b)`)
+
+func containsURL(s string) bool {
+	return urlPattern.MatchString(s)
+}
+
+// Latin lookalikes commonly used in fake token symbols
+var confusables = map[rune]rune{
+	// Cyrillic
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'У': 'Y', 'І': 'I', 'Ѕ': 'S',
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x', 'і': 'i', 'ѕ': 's',
+	// Greek
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'ο': 'o', 'ν': 'v',
+}
+
+// Reports invisible characters, lookalikes of Latin letters, and words mixing
+// Latin and non-Latin letters.
+func hasLookalikeCharacters(s string) bool {
+	hasLatin, hasOther := false, false
+	for _, r := range s {
+		switch {
+		case unicode.IsSpace(r):
+			if hasLatin && hasOther {
+				return true
+			}
+			hasLatin, hasOther = false, false
+		case unicode.Is(unicode.Cf, r), unicode.Is(unicode.Mn, r):
+			return true
+		case r >= 0xFF01 && r <= 0xFF5E:
+			// Fullwidth ASCII
+			return true
+		case confusables[r] != 0:
+			return true
+		case unicode.IsLetter(r) && unicode.Is(unicode.Latin, r):
+			hasLatin = true
+		case unicode.IsLetter(r):
+			hasOther = true
+		}
+	}
+	return hasLatin && hasOther
+}
+
+// Maps lookalikes to ASCII and drops invisible characters, so that "UЅDС" and "USDC" compare equal.
+func normalizeSymbol(symbol string) string {
+	var b strings.Builder
+	for _, r := range symbol {
+		switch {
+		case unicode.Is(unicode.Cf, r), unicode.Is(unicode.Mn, r), unicode.IsSpace(r):
+			continue
+		case r >= 0xFF01 && r <= 0xFF5E:
+			r -= 0xFEE0
+		case confusables[r] != 0:
+			r = confusables[r]
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
+
+// Reports whether at least half of the transfers, and at least one, move no value.
+func hasZeroValueTransfers(events []eventlog.Event) bool {
+	total, zero := 0, 0
+	for _, event := range events {
+		switch transfer := event.Unpacked.(type) {
+		case erc20.Erc20Transfer:
+			total++
+			if transfer.Value == nil || transfer.Value.Sign() == 0 {
+				zero++
+			}
+		case erc1155.Erc1155TransferSingle:
+			total++
+			if transfer.Value == nil || transfer.Value.Sign() == 0 {
+				zero++
+			}
+		case erc1155.Erc1155TransferBatch:
+			total++
+			empty := true
+			for _, value := range transfer.Values {
+				if value != nil && value.Sign() != 0 {
+					empty = false
+					break
+				}
+			}
+			if empty {
+				zero++
+			}
+		}
+	}
+	return zero > 0 && zero*2 >= total
+}
+
+// Returns the contract emitting a transfer event.
+func eventContract(event eventlog.Event) (common.Address, bool) {
+	switch transfer := event.Unpacked.(type) {
+	case erc20.Erc20Transfer:
+		return transfer.Raw.Address, true
+	case erc721.Erc721Transfer:
+		return transfer.Raw.Address, true
+	case erc1155.Erc1155TransferSingle:
+		return transfer.Raw.Address, true
+	case erc1155.Erc1155TransferBatch:
+		return transfer.Raw.Address, true
+	}
+	return common.Address{}, false
+}
+
+const (
+	opPush1        = 0x60
+	opPush4        = 0x63
+	opPush32       = 0x7f
+	opDelegateCall = 0xf4
+)
+
+var (
+	// balanceOf(address), shared by ERC20 and ERC721
+	balanceOfSelector = [4]byte{0x70, 0xa0, 0x82, 0x31}
+	// balanceOf(address,uint256) of ERC1155
+	erc1155BalanceOfSelector = [4]byte{0x00, 0xfd, 0xd5, 0x8e}
+	// EIP-1167 minimal proxy
+	minimalProxyPrefix = common.FromHex("0x363d3d373d3d3d363d73")
+)
+
+// Reports bytecode that neither dispatches a token balanceOf function nor
+// delegates to an implementation (proxies can't be checked without resolving
+// the implementation). Empty code is not a contract and is non-standard too.
+func isNonStandardBytecode(code []byte) bool {
+	if len(code) == 0 {
+		return true
+	}
+	if bytes.HasPrefix(code, minimalProxyPrefix) {
+		return false
+	}
+
+	for i := 0; i < len(code); i++ {
+		op := code[i]
+		switch {
+		case op == opDelegateCall:
+			return false
+		case op >= opPush1 && op <= opPush4:
+			// solc pushes constants with the fewest bytes, selectors with
+			// leading zero bytes (e.g. PUSH3 0xfdd58e) are right-aligned
+			size := int(op - opPush1 + 1)
+			if i+size >= len(code) {
+				break
+			}
+			var selector [4]byte
+			copy(selector[4-size:], code[i+1:i+1+size])
+			if selector == balanceOfSelector || selector == erc1155BalanceOfSelector {
+				return false
+			}
+		}
+		// Skip the PUSH data, it's not code
+		if op >= opPush1 && op <= opPush32 {
+			i += int(op - opPush1 + 1)
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package spam
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+func TestContainsURL(t *testing.T) {
+	for _, s := range []string{"Visit https://claim-rewards.io", "www.airdrop.gift", "$ETH2 at eth2-claim.com", "t.me/freetokens", "USDC-REWARD.XYZ"} {
+		assert.True(t, containsURL(s), s)
+	}
+	for _, s := range []string{"USD Coin", "USDC.e", "Wrapped Ether", "Curve.fi DAI/USDC/USDT", "1inch"} {
+		assert.False(t, containsURL(s), s)
+	}
+}
+
+func TestHasLookalikeCharacters(t *testing.T) {
+	for _, s := range []string{"UЅDС", "US​DT", "ＵＳＤＴ", "Тether", "USDT̶"} {
+		assert.True(t, hasLookalikeCharacters(s), s)
+	}
+	for _, s := range []string{"USDC", "Wrapped Ether", "日本円", "Ω Token"} {
+		assert.False(t, hasLookalikeCharacters(s), s)
+	}
+}
+
+func TestNormalizeSymbol(t *testing.T) {
+	assert.Equal(t, "USDC", normalizeSymbol("UЅDС"))
+	assert.Equal(t, "USDT", normalizeSymbol("ＵＳＤＴ"))
+	assert.Equal(t, "USDT", normalizeSymbol("us​dt"))
+	assert.Equal(t, "WETH", normalizeSymbol(" WETH "))
+}
+
+func TestHasZeroValueTransfers(t *testing.T) {
+	transfer := func(value int64) eventlog.Event {
+		return eventlog.Event{Unpacked: erc20.Erc20Transfer{Value: big.NewInt(value)}}
+	}
+
+	assert.False(t, hasZeroValueTransfers(nil))
+	assert.False(t, hasZeroValueTransfers([]eventlog.Event{transfer(1), transfer(0), transfer(5)}))
+	assert.True(t, hasZeroValueTransfers([]eventlog.Event{transfer(1), transfer(0)}))
+	assert.True(t, hasZeroValueTransfers([]eventlog.Event{
+		{Unpacked: erc1155.Erc1155TransferBatch{Values: []*big.Int{big.NewInt(0), big.NewInt(0)}}},
+	}))
+	assert.False(t, hasZeroValueTransfers([]eventlog.Event{
+		{Unpacked: erc1155.Erc1155TransferSingle{Value: big.NewInt(1)}},
+	}))
+}
+
+func TestIsNonStandardBytecode(t *testing.T) {
+	// Dispatcher comparing the selector with balanceOf: PUSH4 0x70a08231 EQ
+	token := common.FromHex("0x6080604052348015600f57600080fd5b506004361060285760003560e01c806370a0823114602d575b600080fd5b00")
+	assert.False(t, isNonStandardBytecode(token))
+
+	// Function dispatcher of OpenZeppelin's ERC1155 in the layout emitted by
+	// solc 0.8 (function bodies omitted): balanceOf(address,uint256) is pushed as PUSH3 0xfdd58e, the
+	// other selectors (balanceOfBatch, setApprovalForAll, isApprovedForAll,
+	// safeTransferFrom, supportsInterface, uri, safeBatchTransferFrom) as PUSH4
+	erc1155Token := common.FromHex("0x608060405234801561000f575f80fd5b5060043610610089575f3560e01c80634e1273f411610059578063" +
+		"4e1273f41461013c578063a22cb4651461016c578063e985e9c514610188578063f242432a146101b857610089565b8062fdd58e14610091578063" +
+		"01ffc9a7146100c15780630e89341c146100f15780632eb2c2d61461012157610089565b5f80fd")
+	assert.False(t, isNonStandardBytecode(erc1155Token))
+	// Same dispatcher without balanceOf(address,uint256)
+	withoutBalanceOf := bytes.Replace(erc1155Token, common.FromHex("0x8062fdd58e14"), common.FromHex("0x8062abcdef14"), 1)
+	assert.True(t, isNonStandardBytecode(withoutBalanceOf))
+	// Truncated PUSH data
+	assert.True(t, isNonStandardBytecode(common.FromHex("0x62fdd5")))
+
+	// EIP-1167 minimal proxy
+	proxy := common.FromHex("0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3")
+	assert.False(t, isNonStandardBytecode(proxy))
+
+	// Selector only present inside PUSH32 data
+	hidden := append([]byte{opPush32}, common.LeftPadBytes([]byte{opPush4, 0x70, 0xa0, 0x82, 0x31}, 32)...)
+	assert.True(t, isNonStandardBytecode(hidden))
+
+	assert.True(t, isNonStandardBytecode(common.FromHex("0x6080604052600080fd")))
+	assert.True(t, isNonStandardBytecode(nil))
+}
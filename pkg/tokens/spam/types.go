@@ -0,0 +1,106 @@
+package spam
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/tokens/types"
+)
+
+type Reason string
+
+const (
+	// Found in the token lists, heuristics are skipped
+	ReasonListed Reason = "listed"
+	// Not found in the token lists
+	ReasonUnlisted Reason = "unlisted"
+	// Name or symbol contains a URL, typical of phishing airdrops
+	ReasonURL Reason = "url"
+	// Name or symbol contains invisible or non-Latin lookalike characters
+	ReasonLookalikeCharacters Reason = "lookalike_characters"
+	// Symbol of a well-known token, on a different contract
+	ReasonImpersonation Reason = "impersonation"
+	// Most transfers move no value, typical of address poisoning
+	ReasonZeroValueTransfers Reason = "zero_value_transfers"
+	// Bytecode doesn't implement the token standards
+	ReasonNonStandardBytecode Reason = "non_standard_bytecode"
+	ReasonUserMarkedSpam      Reason = "user_marked_spam"
+	ReasonUserMarkedTrusted   Reason = "user_marked_trusted"
+)
+
+// Scores range from 0 (no risk found) to MaxScore.
+const MaxScore = 100
+
+type Verdict struct {
+	Score   int
+	Spam    bool
+	Reasons []Reason
+}
+
+type Override string
+
+const (
+	OverrideSpam    Override = "spam"
+	OverrideTrusted Override = "trusted"
+)
+
+// TokenSource provides the known tokens, implemented by tokens/manager.Manager.
+type TokenSource interface {
+	UniqueTokens() []*types.Token
+	GetTokenByChainAddress(chainID uint64, addr common.Address) (*types.Token, bool)
+}
+
+// CodeClient reads contract bytecode, implemented by ethclient.Client.
+type CodeClient interface {
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+}
+
+// OverrideStore keeps the user decisions, which take precedence over the classification.
+type OverrideStore interface {
+	GetOverride(chainID uint64, address common.Address) (Override, bool, error)
+	SetOverride(chainID uint64, address common.Address, override Override) error
+	DeleteOverride(chainID uint64, address common.Address) error
+}
+
+type Input struct {
+	Token *types.Token
+	// Transfer events of the contract (optional), used to detect zero-value transfers
+	Events []eventlog.Event
+}
+
+// In-memory OverrideStore
+type MemoryOverrideStore struct {
+	mu        sync.RWMutex
+	overrides map[string]Override
+}
+
+func NewMemoryOverrideStore() *MemoryOverrideStore {
+	return &MemoryOverrideStore{
+		overrides: make(map[string]Override),
+	}
+}
+
+func (s *MemoryOverrideStore) GetOverride(chainID uint64, address common.Address) (Override, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	override, ok := s.overrides[types.TokenKey(chainID, address)]
+	return override, ok, nil
+}
+
+func (s *MemoryOverrideStore) SetOverride(chainID uint64, address common.Address, override Override) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.overrides[types.TokenKey(chainID, address)] = override
+	return nil
+}
+
+func (s *MemoryOverrideStore) DeleteOverride(chainID uint64, address common.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.overrides, types.TokenKey(chainID, address))
+	return nil
+}
@@ -0,0 +1,158 @@
+package transfers
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	walletcommon "github.com/status-im/go-wallet-sdk/pkg/common"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/weth"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+// Kind labels what a transfer does, for display.
+type Kind string
+
+const (
+	KindTransfer  Kind = "transfer"
+	KindMint      Kind = "mint"       // Tokens created, from the zero address
+	KindBurn      Kind = "burn"       // Tokens destroyed, to the zero address
+	KindWrap      Kind = "wrap"       // Native coin wrapped into the wrapped native token
+	KindUnwrap    Kind = "unwrap"     // Wrapped native token unwrapped into the native coin
+	KindBridgeOut Kind = "bridge_out" // Sent to a bridge, to another chain
+	KindBridgeIn  Kind = "bridge_in"  // Received from a bridge, from another chain
+)
+
+// KnownBridges maps chain ID to the contracts of the canonical bridges
+// holding or releasing the bridged funds.
+var KnownBridges = map[walletcommon.ChainID][]common.Address{
+	walletcommon.EthereumMainnet: {
+		common.HexToAddress("0x99C9fc46f92E8a1c0deC1b1747d010903E884bE1"), // Optimism L1StandardBridge
+		common.HexToAddress("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed"), // Optimism OptimismPortal
+		common.HexToAddress("0x3154Cf16ccdb4C6d922629664174b904d80F2C35"), // Base L1StandardBridge
+		common.HexToAddress("0x49048044D57e1C92A77f79988d21Fa8fAF74E97e"), // Base OptimismPortal
+		common.HexToAddress("0x4Dbd4fc535Ac27206064B68FfCf827b0A60BAB3f"), // Arbitrum One Inbox
+		common.HexToAddress("0x8315177aB297bA92A06054cE80a67Ed4DBd7ed3a"), // Arbitrum One Bridge
+		common.HexToAddress("0xa3A7B6F88361F48403514059F1F16C8E78d60EeC"), // Arbitrum One L1ERC20Gateway
+	},
+	walletcommon.OptimismMainnet: {
+		common.HexToAddress("0x4200000000000000000000000000000000000010"), // L2StandardBridge
+		common.HexToAddress("0x4200000000000000000000000000000000000016"), // L2ToL1MessagePasser
+	},
+	walletcommon.BaseMainnet: {
+		common.HexToAddress("0x4200000000000000000000000000000000000010"), // L2StandardBridge
+		common.HexToAddress("0x4200000000000000000000000000000000000016"), // L2ToL1MessagePasser
+	},
+	walletcommon.ArbitrumMainnet: {
+		common.HexToAddress("0x5288c571Fd7aD117beA99bF60FE0846C4E84F933"), // L2GatewayRouter
+		common.HexToAddress("0x09e9222E96E7B4AE2a407B98d48e330053351EEe"), // L2ERC20Gateway
+		common.HexToAddress("0x0000000000000000000000000000000000000064"), // ArbSys
+	},
+}
+
+type ClassifierConfig struct {
+	ChainID walletcommon.ChainID
+	// Wrapped native token contracts, in addition to
+	// weth.WrappedNativeAddresses[ChainID]
+	WrappedNative []common.Address
+	// Bridge contracts, in addition to KnownBridges[ChainID]
+	Bridges []common.Address
+}
+
+// Classifier labels the transfers of one chain.
+type Classifier struct {
+	wrappedNative map[common.Address]bool
+	bridges       map[common.Address]bool
+}
+
+func NewClassifier(config ClassifierConfig) *Classifier {
+	c := &Classifier{
+		wrappedNative: make(map[common.Address]bool),
+		bridges:       make(map[common.Address]bool),
+	}
+	if address, ok := weth.WrappedNativeAddresses[config.ChainID]; ok {
+		c.wrappedNative[address] = true
+	}
+	for _, address := range config.WrappedNative {
+		c.wrappedNative[address] = true
+	}
+	for _, address := range KnownBridges[config.ChainID] {
+		c.bridges[address] = true
+	}
+	for _, address := range config.Bridges {
+		c.bridges[address] = true
+	}
+	return c
+}
+
+// Classify returns the kind of a transfer, checked in this order:
+//   - Native coin sent to a wrapped native contract is a wrap, sent by it an
+//     unwrap. Wrapped native tokens minted or burned (contracts emitting a
+//     Transfer on deposit) are a wrap or an unwrap.
+//   - Tokens from the zero address are a mint, to the zero address a burn.
+//   - Transfers to a bridge are a bridge out, from a bridge a bridge in.
+//
+// Other transfers are KindTransfer.
+func (c *Classifier) Classify(transfer Transfer) Kind {
+	from, to := transfer.From, transfer.To
+	if transfer.Standard == StandardNative {
+		switch {
+		case c.wrappedNative[to]:
+			return KindWrap
+		case c.wrappedNative[from]:
+			return KindUnwrap
+		}
+	} else {
+		switch {
+		case c.wrappedNative[transfer.Contract] && from == (common.Address{}):
+			return KindWrap
+		case c.wrappedNative[transfer.Contract] && to == (common.Address{}):
+			return KindUnwrap
+		case from == (common.Address{}):
+			return KindMint
+		case to == (common.Address{}):
+			return KindBurn
+		}
+	}
+	switch {
+	case c.bridges[to]:
+		return KindBridgeOut
+	case c.bridges[from]:
+		return KindBridgeIn
+	}
+	return KindTransfer
+}
+
+// ClassifyEvent returns the kind of a parsed event, e.g. from the output of
+// eventfilter.FilterTransfers (with TransferQueryConfig.WrappedNative for the
+// wraps and unwraps): transfer events are classified as their transfers, WETH
+// Deposit and Withdrawal events of wrapped native contracts are a wrap and an
+// unwrap. Returns false for other events, including Deposit and Withdrawal
+// events of other contracts.
+func (c *Classifier) ClassifyEvent(event eventlog.Event) (Kind, bool) {
+	var transfer Transfer
+	switch unpacked := event.Unpacked.(type) {
+	case weth.WethDeposit:
+		if !c.wrappedNative[unpacked.Raw.Address] {
+			return "", false
+		}
+		return KindWrap, true
+	case weth.WethWithdrawal:
+		if !c.wrappedNative[unpacked.Raw.Address] {
+			return "", false
+		}
+		return KindUnwrap, true
+	case erc20.Erc20Transfer:
+		transfer = Transfer{Standard: StandardERC20, Contract: unpacked.Raw.Address, From: unpacked.From, To: unpacked.To}
+	case erc721.Erc721Transfer:
+		transfer = Transfer{Standard: StandardERC721, Contract: unpacked.Raw.Address, From: unpacked.From, To: unpacked.To}
+	case erc1155.Erc1155TransferSingle:
+		transfer = Transfer{Standard: StandardERC1155, Contract: unpacked.Raw.Address, From: unpacked.From, To: unpacked.To}
+	case erc1155.Erc1155TransferBatch:
+		transfer = Transfer{Standard: StandardERC1155, Contract: unpacked.Raw.Address, From: unpacked.From, To: unpacked.To}
+	default:
+		return "", false
+	}
+	return c.Classify(transfer), true
+}
@@ -0,0 +1,86 @@
+package transfers_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	walletcommon "github.com/status-im/go-wallet-sdk/pkg/common"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/weth"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/transfers"
+)
+
+func TestClassifier_Classify(t *testing.T) {
+	wrapped := weth.WrappedNativeAddresses[walletcommon.EthereumMainnet]
+	bridge := transfers.KnownBridges[walletcommon.EthereumMainnet][0]
+	customBridge := common.HexToAddress("0xb1d9e00000000000000000000000000000000004")
+	classifier := transfers.NewClassifier(transfers.ClassifierConfig{
+		ChainID: walletcommon.EthereumMainnet,
+		Bridges: []common.Address{customBridge},
+	})
+
+	tests := []struct {
+		name     string
+		transfer transfers.Transfer
+		want     transfers.Kind
+	}{
+		{"transfer", transfers.Transfer{Standard: transfers.StandardERC20, Contract: token, From: alice, To: bob}, transfers.KindTransfer},
+		{"mint", transfers.Transfer{Standard: transfers.StandardERC721, Contract: token, To: bob}, transfers.KindMint},
+		{"burn", transfers.Transfer{Standard: transfers.StandardERC1155, Contract: token, From: alice}, transfers.KindBurn},
+		{"native wrap", transfers.Transfer{Standard: transfers.StandardNative, From: alice, To: wrapped}, transfers.KindWrap},
+		{"native unwrap", transfers.Transfer{Standard: transfers.StandardNative, From: wrapped, To: alice, TraceAddress: []int{0}}, transfers.KindUnwrap},
+		{"wrapped token minted", transfers.Transfer{Standard: transfers.StandardERC20, Contract: wrapped, To: alice}, transfers.KindWrap},
+		{"wrapped token burned", transfers.Transfer{Standard: transfers.StandardERC20, Contract: wrapped, From: alice}, transfers.KindUnwrap},
+		{"native from zero", transfers.Transfer{Standard: transfers.StandardNative, To: alice}, transfers.KindTransfer},
+		{"bridge out", transfers.Transfer{Standard: transfers.StandardERC20, Contract: token, From: alice, To: bridge}, transfers.KindBridgeOut},
+		{"bridge in", transfers.Transfer{Standard: transfers.StandardNative, From: bridge, To: alice}, transfers.KindBridgeIn},
+		{"custom bridge", transfers.Transfer{Standard: transfers.StandardERC20, Contract: token, From: alice, To: customBridge}, transfers.KindBridgeOut},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifier.Classify(tt.transfer))
+		})
+	}
+
+	// Contracts of other chains aren't known
+	other := transfers.NewClassifier(transfers.ClassifierConfig{ChainID: walletcommon.BSCTestnet})
+	assert.Equal(t, transfers.KindTransfer, other.Classify(transfers.Transfer{Standard: transfers.StandardNative, From: alice, To: wrapped}))
+}
+
+func TestClassifier_ClassifyEvent(t *testing.T) {
+	wrapped := common.HexToAddress("0x3000000000000000000000000000000000000001")
+	classifier := transfers.NewClassifier(transfers.ClassifierConfig{
+		WrappedNative: []common.Address{wrapped},
+	})
+	amount := common.BigToHash(big.NewInt(5)).Bytes()
+
+	deposit := testLog(0, []common.Hash{eventlog.WETHDepositID, addressTopic(alice)}, amount)
+	deposit.Address = wrapped
+	kind, ok := classifier.ClassifyEvent(parse(t, deposit))
+	require.True(t, ok)
+	assert.Equal(t, transfers.KindWrap, kind)
+
+	withdrawal := testLog(1, []common.Hash{eventlog.WETHWithdrawalID, addressTopic(alice)}, amount)
+	withdrawal.Address = wrapped
+	kind, ok = classifier.ClassifyEvent(parse(t, withdrawal))
+	require.True(t, ok)
+	assert.Equal(t, transfers.KindUnwrap, kind)
+
+	// Same signature, not a wrapped native contract
+	kind, ok = classifier.ClassifyEvent(parse(t, testLog(2, []common.Hash{eventlog.WETHDepositID, addressTopic(alice)}, amount)))
+	assert.False(t, ok)
+	assert.Empty(t, kind)
+
+	mint := testLog(3, []common.Hash{eventlog.ERC20TransferID, addressTopic(common.Address{}), addressTopic(bob)}, amount)
+	kind, ok = classifier.ClassifyEvent(parse(t, mint))
+	require.True(t, ok)
+	assert.Equal(t, transfers.KindMint, kind)
+
+	approval := testLog(4, []common.Hash{eventlog.ERC20ApprovalID, addressTopic(alice), addressTopic(bob)}, amount)
+	_, ok = classifier.ClassifyEvent(parse(t, approval))
+	assert.False(t, ok)
+}
@@ -0,0 +1,13 @@
+// Package transfers provides a single record type for token transfers of all
+// standards.
+//
+// Transfer flattens the ERC20, ERC721 and ERC1155 transfer events parsed by
+// pkg/eventlog into one shape (standard, contract, from, to, token ID, amount,
+// operator and log position), so that consumers don't need to type-switch on
+// the unpacked event. ERC1155 TransferBatch events are exploded into one
+// Transfer per token ID. Native coin transfers, found by pkg/transfers/native,
+// use the same record.
+//
+// Classifier labels transfers as mints, burns, wraps and unwraps of the
+// wrapped native token, or bridge transfers.
+package transfers
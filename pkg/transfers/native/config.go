@@ -0,0 +1,83 @@
+package native
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+)
+
+const DefaultTraceFilterBlockRange = 1000
+
+var (
+	ErrClientNotProvided       = errors.New("client is required")
+	ErrSourceNotSupported      = errors.New("client doesn't support the source")
+	ErrInvalidSource           = errors.New("invalid source")
+	ErrNoTraceSourceAvailable  = errors.New("no trace source available")
+	ErrInvalidBlockRange       = errors.New("from block must not be greater than to block")
+	ErrUnexpectedTraceCount    = errors.New("number of traces doesn't match the number of transactions")
+	ErrUnexpectedReceiptsCount = errors.New("number of receipts doesn't match the number of transactions")
+)
+
+// Source is where transfers are read from.
+type Source string
+
+const (
+	// SourceAuto uses the first source supported by the node: SourceTraceFilter
+	// when accounts are set, then SourceDebugTrace, then SourceBlocks.
+	SourceAuto Source = ""
+	// SourceBlocks reads the transactions of every block and their receipts.
+	// Internal transfers are not found.
+	SourceBlocks Source = "blocks"
+	// SourceDebugTrace traces every block with the geth callTracer
+	// (debug_traceBlockByNumber).
+	SourceDebugTrace Source = "debug_trace"
+	// SourceTraceFilter queries the traces of the accounts over block ranges
+	// (trace_filter), without walking every block.
+	SourceTraceFilter Source = "trace_filter"
+)
+
+// Client reads blocks and receipts, used by SourceBlocks and SourceDebugTrace.
+type Client interface {
+	EthGetBlockByNumberWithFullTxs(ctx context.Context, number *big.Int) (*ethclient.BlockWithFullTxs, error)
+	EthGetBlockReceipts(ctx context.Context, number *big.Int) ([]*ethclient.Receipt, error)
+}
+
+// DebugTraceClient is needed by SourceDebugTrace.
+type DebugTraceClient interface {
+	DebugTraceBlockByNumberWithCallTracer(ctx context.Context, number *big.Int) ([]ethclient.TxTraceResult, error)
+}
+
+// TraceFilterClient is needed by SourceTraceFilter. TraceTransaction reads
+// the whole call tree of transactions whose internal transfers could be part
+// of a reverted call that trace_filter didn't return.
+type TraceFilterClient interface {
+	TraceFilter(ctx context.Context, args ethclient.TraceFilterArgs) ([]ethclient.Trace, error)
+	TraceTransaction(ctx context.Context, txHash common.Hash) ([]ethclient.Trace, error)
+}
+
+type Config struct {
+	// Transfers from or to any of the accounts, all transfers when empty
+	Accounts []common.Address
+	Source   Source
+	// Blocks per trace_filter query, defaults to DefaultTraceFilterBlockRange
+	TraceFilterBlockRange uint64
+}
+
+func (c *Config) Validate() error {
+	switch c.Source {
+	case SourceAuto, SourceBlocks, SourceDebugTrace, SourceTraceFilter:
+		return nil
+	}
+	return ErrInvalidSource
+}
+
+func (c Config) withDefaults() Config {
+	if c.TraceFilterBlockRange == 0 {
+		c.TraceFilterBlockRange = DefaultTraceFilterBlockRange
+	}
+	return c
+}
@@ -0,0 +1,9 @@
+// Package native finds the native coin (ETH) transfers of a block range, as
+// transfers.Transfer records like token transfers.
+//
+// Transfers made by the transactions themselves are read from the blocks and
+// their receipts. Transfers made by contracts during execution (internal
+// transfers) don't emit logs and are only visible in execution traces: they
+// are read from the geth callTracer (debug_traceBlockByNumber) or from Parity
+// style traces (trace_filter) when the node supports them.
+package native
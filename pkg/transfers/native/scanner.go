@@ -0,0 +1,306 @@
+package native
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"math/big"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+	"github.com/status-im/go-wallet-sdk/pkg/transfers"
+)
+
+// JSON-RPC error code of unknown methods
+const methodNotFoundCode = -32601
+
+// Error message of geth style nodes not exposing a method, when the error
+// code is lost (e.g. by a proxy)
+var methodNotFoundMessage = regexp.MustCompile(`^the method \S+ does not exist/is not available$`)
+
+// Error messages of providers that may reject a method, e.g. on a plan
+// without tracing, but also a temporary restriction
+var methodNotSupportedMessages = []string{
+	"method not found",
+	"method not supported",
+	"unsupported method",
+	"method not allowed",
+}
+
+// Scanner finds the native transfers of block ranges (thread-safe for
+// concurrent access).
+type Scanner struct {
+	client   Client
+	config   Config
+	accounts map[common.Address]bool
+
+	mu     sync.Mutex
+	source Source // Source in use, resolved at the first scan for SourceAuto
+}
+
+func NewScanner(client Client, config Config) (*Scanner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrClientNotProvided
+	}
+	switch config.Source {
+	case SourceDebugTrace:
+		if _, ok := client.(DebugTraceClient); !ok {
+			return nil, ErrSourceNotSupported
+		}
+	case SourceTraceFilter:
+		if _, ok := client.(TraceFilterClient); !ok {
+			return nil, ErrSourceNotSupported
+		}
+	}
+
+	accounts := make(map[common.Address]bool, len(config.Accounts))
+	for _, account := range config.Accounts {
+		accounts[account] = true
+	}
+	return &Scanner{
+		client:   client,
+		config:   config.withDefaults(),
+		accounts: accounts,
+		source:   config.Source,
+	}, nil
+}
+
+// Source returns the source in use, SourceAuto until the first successful
+// scan resolves it.
+func (s *Scanner) Source() Source {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.source
+}
+
+// Scan returns the native transfers of the blocks [fromBlock, toBlock], ordered
+// by block, transaction and position in the call tree. Transfers of reverted
+// transactions and calls are omitted.
+func (s *Scanner) Scan(ctx context.Context, fromBlock uint64, toBlock uint64) ([]transfers.Transfer, error) {
+	if fromBlock > toBlock {
+		return nil, ErrInvalidBlockRange
+	}
+
+	source := s.Source()
+	if source != SourceAuto {
+		return s.scan(ctx, source, fromBlock, toBlock)
+	}
+
+	// The first source supported by the node, only kept when the sources
+	// before it were rejected for certain
+	keep := true
+	for _, candidate := range s.autoSources() {
+		ret, err := s.scan(ctx, candidate, fromBlock, toBlock)
+		if err != nil && isMethodNotFoundError(err) {
+			continue
+		}
+		if err != nil && isMethodNotSupportedError(err) {
+			keep = false
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			s.mu.Lock()
+			s.source = candidate
+			s.mu.Unlock()
+		}
+		return ret, nil
+	}
+	return nil, ErrNoTraceSourceAvailable
+}
+
+// Sources tried by SourceAuto, in order.
+func (s *Scanner) autoSources() []Source {
+	var ret []Source
+	if _, ok := s.client.(TraceFilterClient); ok && len(s.accounts) > 0 {
+		ret = append(ret, SourceTraceFilter)
+	}
+	if _, ok := s.client.(DebugTraceClient); ok {
+		ret = append(ret, SourceDebugTrace)
+	}
+	return append(ret, SourceBlocks)
+}
+
+func (s *Scanner) scan(ctx context.Context, source Source, fromBlock uint64, toBlock uint64) ([]transfers.Transfer, error) {
+	var ret []transfers.Transfer
+	var err error
+	switch source {
+	case SourceBlocks:
+		ret, err = s.scanBlocks(ctx, fromBlock, toBlock)
+	case SourceDebugTrace:
+		ret, err = s.scanDebugTraces(ctx, fromBlock, toBlock)
+	case SourceTraceFilter:
+		ret, err = s.scanTraceFilter(ctx, fromBlock, toBlock)
+	default:
+		return nil, ErrInvalidSource
+	}
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(ret, compareTransfers)
+	return ret, nil
+}
+
+// Reads the value transfers of the transactions of each block, skipping the
+// failed ones.
+func (s *Scanner) scanBlocks(ctx context.Context, fromBlock uint64, toBlock uint64) ([]transfers.Transfer, error) {
+	ret := make([]transfers.Transfer, 0)
+	for number := fromBlock; number <= toBlock; number++ {
+		blockNumber := new(big.Int).SetUint64(number)
+		block, err := s.client.EthGetBlockByNumberWithFullTxs(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, ethereum.NotFound
+		}
+		if !hasValueTransfer(block) {
+			continue
+		}
+		receipts, err := s.client.EthGetBlockReceipts(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if len(receipts) != len(block.Transactions) {
+			return nil, ErrUnexpectedReceiptsCount
+		}
+
+		for i, tx := range block.Transactions {
+			if tx.Value == nil || tx.Value.Sign() == 0 || receipts[i].Status == 0 {
+				continue
+			}
+			to := receipts[i].ContractAddress
+			if tx.To != nil {
+				to = tx.To
+			}
+			if to == nil {
+				continue
+			}
+			s.add(&ret, transfers.Transfer{
+				Standard:    transfers.StandardNative,
+				From:        tx.From,
+				To:          *to,
+				Amount:      tx.Value,
+				TxHash:      tx.Hash,
+				TxIndex:     uint(i),
+				BlockNumber: number,
+				BlockHash:   blockHash(block.Hash),
+			})
+		}
+	}
+	return ret, nil
+}
+
+// Walks the callTracer call tree of every transaction of each block.
+func (s *Scanner) scanDebugTraces(ctx context.Context, fromBlock uint64, toBlock uint64) ([]transfers.Transfer, error) {
+	tracer := s.client.(DebugTraceClient)
+	ret := make([]transfers.Transfer, 0)
+	for number := fromBlock; number <= toBlock; number++ {
+		blockNumber := new(big.Int).SetUint64(number)
+		block, err := s.client.EthGetBlockByNumberWithFullTxs(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if block == nil {
+			return nil, ethereum.NotFound
+		}
+		if len(block.Transactions) == 0 {
+			continue
+		}
+		traces, err := tracer.DebugTraceBlockByNumberWithCallTracer(ctx, blockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if len(traces) != len(block.Transactions) {
+			return nil, ErrUnexpectedTraceCount
+		}
+
+		for i, trace := range traces {
+			if trace.Error != "" {
+				return nil, errors.New(trace.Error)
+			}
+			if trace.Result == nil {
+				continue
+			}
+			base := transfers.Transfer{
+				Standard:    transfers.StandardNative,
+				TxHash:      block.Transactions[i].Hash,
+				TxIndex:     uint(i),
+				BlockNumber: number,
+				BlockHash:   blockHash(block.Hash),
+			}
+			for _, transfer := range callFrameTransfers(*trace.Result, nil, base) {
+				s.add(&ret, transfer)
+			}
+		}
+	}
+	return ret, nil
+}
+
+// Adds a transfer involving the accounts.
+func (s *Scanner) add(ret *[]transfers.Transfer, transfer transfers.Transfer) {
+	if len(s.accounts) > 0 && !s.accounts[transfer.From] && !s.accounts[transfer.To] {
+		return
+	}
+	*ret = append(*ret, transfer)
+}
+
+func hasValueTransfer(block *ethclient.BlockWithFullTxs) bool {
+	for _, tx := range block.Transactions {
+		if tx.Value != nil && tx.Value.Sign() > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func blockHash(hash *common.Hash) common.Hash {
+	if hash == nil {
+		return common.Hash{}
+	}
+	return *hash
+}
+
+// Orders transfers by block, transaction and position in the call tree, the
+// transaction itself first.
+func compareTransfers(a, b transfers.Transfer) int {
+	if c := cmp.Compare(a.BlockNumber, b.BlockNumber); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.TxIndex, b.TxIndex); c != 0 {
+		return c
+	}
+	return slices.Compare(a.TraceAddress, b.TraceAddress)
+}
+
+// Reports whether err is a node rejecting a method it doesn't expose.
+func isMethodNotFoundError(err error) bool {
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+		return true
+	}
+	return methodNotFoundMessage.MatchString(err.Error())
+}
+
+// Reports whether err may be a provider rejecting a method.
+func isMethodNotSupportedError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range methodNotSupportedMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,400 @@
+package native_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+	"github.com/status-im/go-wallet-sdk/pkg/transfers"
+	"github.com/status-im/go-wallet-sdk/pkg/transfers/native"
+)
+
+var (
+	alice    = common.HexToAddress("0xa11ce00000000000000000000000000000000001")
+	bob      = common.HexToAddress("0xb0b0000000000000000000000000000000000002")
+	wallet   = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	exchange = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	created  = common.HexToAddress("0x1000000000000000000000000000000000000003")
+)
+
+// Serves blocks and receipts.
+type fakeClient struct {
+	blocks   map[uint64]*ethclient.BlockWithFullTxs
+	receipts map[uint64][]*ethclient.Receipt
+}
+
+func (c *fakeClient) EthGetBlockByNumberWithFullTxs(ctx context.Context, number *big.Int) (*ethclient.BlockWithFullTxs, error) {
+	return c.blocks[number.Uint64()], nil
+}
+
+func (c *fakeClient) EthGetBlockReceipts(ctx context.Context, number *big.Int) ([]*ethclient.Receipt, error) {
+	return c.receipts[number.Uint64()], nil
+}
+
+// Also serves callTracer traces.
+type fakeDebugClient struct {
+	*fakeClient
+	traces map[uint64][]ethclient.TxTraceResult
+	err    error
+}
+
+func (c *fakeDebugClient) DebugTraceBlockByNumberWithCallTracer(ctx context.Context, number *big.Int) ([]ethclient.TxTraceResult, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.traces[number.Uint64()], nil
+}
+
+// Also serves Parity style traces.
+type fakeTraceClient struct {
+	*fakeDebugClient
+	traces   []ethclient.Trace
+	txTraces map[common.Hash][]ethclient.Trace
+	err      error
+
+	filterArgs []ethclient.TraceFilterArgs
+	traced     []common.Hash
+}
+
+func (c *fakeTraceClient) TraceFilter(ctx context.Context, args ethclient.TraceFilterArgs) ([]ethclient.Trace, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.filterArgs = append(c.filterArgs, args)
+	var ret []ethclient.Trace
+	for _, trace := range c.traces {
+		if trace.BlockNumber < args.FromBlock.Uint64() || trace.BlockNumber > args.ToBlock.Uint64() {
+			continue
+		}
+		from, to := trace.Action.From, trace.Action.To
+		if trace.Action.Address != nil {
+			from, to = *trace.Action.Address, trace.Action.RefundAddress
+		}
+		if len(args.FromAddress) > 0 && !slices.Contains(args.FromAddress, from) {
+			continue
+		}
+		if len(args.ToAddress) > 0 && (to == nil || !slices.Contains(args.ToAddress, *to)) {
+			continue
+		}
+		ret = append(ret, trace)
+	}
+	return ret, nil
+}
+
+func (c *fakeTraceClient) TraceTransaction(ctx context.Context, txHash common.Hash) ([]ethclient.Trace, error) {
+	c.traced = append(c.traced, txHash)
+	return c.txTraces[txHash], nil
+}
+
+// JSON-RPC error of an unknown method.
+type methodNotFoundError struct{}
+
+func (methodNotFoundError) Error() string  { return "the method is not available" }
+func (methodNotFoundError) ErrorCode() int { return -32601 }
+
+func hash(n int64) common.Hash {
+	return common.BigToHash(big.NewInt(n))
+}
+
+func block(number uint64, txs ...ethclient.Transaction) *ethclient.BlockWithFullTxs {
+	h := hash(int64(number) + 1000)
+	return &ethclient.BlockWithFullTxs{
+		Number:       new(big.Int).SetUint64(number),
+		Hash:         &h,
+		Transactions: txs,
+	}
+}
+
+func tx(n int64, from common.Address, to *common.Address, value int64) ethclient.Transaction {
+	return ethclient.Transaction{
+		Hash:  hash(n),
+		From:  from,
+		To:    to,
+		Value: big.NewInt(value),
+	}
+}
+
+func receipt(status uint64, contract *common.Address) *ethclient.Receipt {
+	return &ethclient.Receipt{Status: status, ContractAddress: contract}
+}
+
+func transfer(block uint64, txIndex uint, n int64, from, to common.Address, amount int64, traceAddress ...int) transfers.Transfer {
+	return transfers.Transfer{
+		Standard:     transfers.StandardNative,
+		From:         from,
+		To:           to,
+		Amount:       big.NewInt(amount),
+		TxHash:       hash(n),
+		TxIndex:      txIndex,
+		BlockNumber:  block,
+		BlockHash:    hash(int64(block) + 1000),
+		TraceAddress: traceAddress,
+	}
+}
+
+func TestScan_Blocks(t *testing.T) {
+	client := &fakeClient{
+		blocks: map[uint64]*ethclient.BlockWithFullTxs{
+			1: block(1,
+				tx(1, alice, &bob, 10),
+				tx(2, alice, &bob, 20), // Failed
+				tx(3, alice, &bob, 0),
+				tx(4, alice, nil, 30), // Contract creation
+				tx(5, wallet, &exchange, 40),
+			),
+			2: block(2, tx(6, bob, &alice, 0)),
+			3: block(3, tx(7, bob, &alice, 50)),
+		},
+		receipts: map[uint64][]*ethclient.Receipt{
+			1: {receipt(1, nil), receipt(0, nil), receipt(1, nil), receipt(1, &created), receipt(1, nil)},
+			3: {receipt(1, nil)},
+		},
+	}
+
+	scanner, err := native.NewScanner(client, native.Config{
+		Accounts: []common.Address{alice},
+		Source:   native.SourceBlocks,
+	})
+	require.NoError(t, err)
+
+	result, err := scanner.Scan(context.Background(), 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []transfers.Transfer{
+		transfer(1, 0, 1, alice, bob, 10),
+		transfer(1, 3, 4, alice, created, 30),
+		transfer(3, 0, 7, bob, alice, 50),
+	}, result)
+	assert.False(t, result[0].IsInternal())
+}
+
+func TestScan_DebugTrace(t *testing.T) {
+	client := &fakeDebugClient{
+		fakeClient: &fakeClient{
+			blocks: map[uint64]*ethclient.BlockWithFullTxs{
+				5: block(5, tx(1, alice, &wallet, 100), tx(2, bob, &exchange, 0)),
+			},
+		},
+		traces: map[uint64][]ethclient.TxTraceResult{
+			5: {
+				{TxHash: hash(1), Result: &ethclient.CallFrame{
+					Type: "CALL", From: alice, To: &wallet, Value: big.NewInt(100),
+					Calls: []ethclient.CallFrame{
+						{Type: "STATICCALL", From: wallet, To: &exchange},
+						{Type: "CALL", From: wallet, To: &exchange, Value: big.NewInt(60)},
+						// Reverted with its subcalls
+						{Type: "CALL", From: wallet, To: &exchange, Value: big.NewInt(1), Error: "execution reverted",
+							Calls: []ethclient.CallFrame{
+								{Type: "CALL", From: exchange, To: &bob, Value: big.NewInt(1)},
+							},
+						},
+						// The value stays in the calling contract
+						{Type: "DELEGATECALL", From: wallet, To: &created, Value: big.NewInt(100)},
+					},
+				}},
+				{TxHash: hash(2), Result: &ethclient.CallFrame{
+					Type: "CALL", From: bob, To: &exchange, Value: big.NewInt(0),
+					Calls: []ethclient.CallFrame{
+						{Type: "CALL", From: exchange, To: &alice, Value: big.NewInt(5),
+							Calls: []ethclient.CallFrame{
+								{Type: "SELFDESTRUCT", From: alice, To: &bob, Value: big.NewInt(0)},
+							},
+						},
+						{Type: "CREATE2", From: exchange, To: &created, Value: big.NewInt(7)},
+					},
+				}},
+			},
+		},
+	}
+
+	scanner, err := native.NewScanner(client, native.Config{Source: native.SourceDebugTrace})
+	require.NoError(t, err)
+
+	result, err := scanner.Scan(context.Background(), 5, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []transfers.Transfer{
+		transfer(5, 0, 1, alice, wallet, 100),
+		transfer(5, 0, 1, wallet, exchange, 60, 1),
+		transfer(5, 1, 2, exchange, alice, 5, 0),
+		transfer(5, 1, 2, exchange, created, 7, 1),
+	}, result)
+	assert.True(t, result[1].IsInternal())
+}
+
+func callTrace(block uint64, txIndex uint64, n int64, from, to common.Address, value int64, traceAddress ...int) ethclient.Trace {
+	txHash := hash(n)
+	if traceAddress == nil {
+		traceAddress = []int{}
+	}
+	return ethclient.Trace{
+		Type:                "call",
+		Action:              ethclient.TraceAction{CallType: "call", From: from, To: &to, Value: big.NewInt(value)},
+		Result:              &ethclient.TraceResult{},
+		TraceAddress:        traceAddress,
+		BlockHash:           hash(int64(block) + 1000),
+		BlockNumber:         block,
+		TransactionHash:     &txHash,
+		TransactionPosition: &txIndex,
+	}
+}
+
+func failed(trace ethclient.Trace) ethclient.Trace {
+	trace.Error = "Reverted"
+	trace.Result = nil
+	return trace
+}
+
+func TestScan_TraceFilter(t *testing.T) {
+	suicide := callTrace(30, 2, 3, created, wallet, 0, 0)
+	suicide.Type = "suicide"
+	suicide.Action = ethclient.TraceAction{Address: &created, RefundAddress: &wallet, Balance: big.NewInt(9)}
+	suicide.Result = nil
+
+	client := &fakeTraceClient{
+		fakeDebugClient: &fakeDebugClient{fakeClient: &fakeClient{}},
+		traces: []ethclient.Trace{
+			callTrace(10, 0, 1, alice, wallet, 100),
+			// Returned by both the send and receive queries
+			callTrace(10, 0, 1, wallet, wallet, 1, 0),
+			// The parent call is known to have succeeded
+			callTrace(10, 0, 1, wallet, exchange, 60, 1),
+			// Within a reverted call that isn't returned
+			callTrace(20, 1, 2, exchange, wallet, 2, 0, 0),
+			failed(callTrace(25, 0, 4, alice, wallet, 3)),
+			suicide,
+		},
+		txTraces: map[common.Hash][]ethclient.Trace{
+			hash(2): {
+				callTrace(20, 1, 2, bob, exchange, 0),
+				failed(callTrace(20, 1, 2, exchange, exchange, 0, 0)),
+				callTrace(20, 1, 2, exchange, wallet, 2, 0, 0),
+			},
+			hash(3): {
+				callTrace(30, 2, 3, bob, created, 0),
+				suicide,
+			},
+		},
+	}
+
+	scanner, err := native.NewScanner(client, native.Config{
+		Accounts:              []common.Address{wallet},
+		Source:                native.SourceTraceFilter,
+		TraceFilterBlockRange: 20,
+	})
+	require.NoError(t, err)
+
+	result, err := scanner.Scan(context.Background(), 0, 30)
+	require.NoError(t, err)
+
+	assert.Equal(t, []transfers.Transfer{
+		transfer(10, 0, 1, alice, wallet, 100),
+		transfer(10, 0, 1, wallet, wallet, 1, 0),
+		transfer(10, 0, 1, wallet, exchange, 60, 1),
+		transfer(30, 2, 3, created, wallet, 9, 0),
+	}, result)
+
+	// Two sub-ranges, each queried by sender and by recipient
+	require.Len(t, client.filterArgs, 4)
+	assert.Equal(t, []common.Address{wallet}, client.filterArgs[0].FromAddress)
+	assert.Equal(t, []common.Address{wallet}, client.filterArgs[1].ToAddress)
+	assert.Equal(t, uint64(19), client.filterArgs[1].ToBlock.Uint64())
+	assert.Equal(t, uint64(20), client.filterArgs[2].FromBlock.Uint64())
+	// Only the transactions with internal transfers of unknown parents
+	assert.ElementsMatch(t, []common.Hash{hash(2), hash(3)}, client.traced)
+}
+
+func TestScan_AutoFallback(t *testing.T) {
+	debugClient := &fakeDebugClient{
+		fakeClient: &fakeClient{
+			blocks: map[uint64]*ethclient.BlockWithFullTxs{
+				1: block(1, tx(1, alice, &bob, 10)),
+			},
+		},
+		traces: map[uint64][]ethclient.TxTraceResult{
+			1: {{TxHash: hash(1), Result: &ethclient.CallFrame{Type: "CALL", From: alice, To: &bob, Value: big.NewInt(10)}}},
+		},
+	}
+	client := &fakeTraceClient{fakeDebugClient: debugClient, err: methodNotFoundError{}}
+
+	scanner, err := native.NewScanner(client, native.Config{Accounts: []common.Address{alice}})
+	require.NoError(t, err)
+	assert.Equal(t, native.SourceAuto, scanner.Source())
+
+	result, err := scanner.Scan(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []transfers.Transfer{transfer(1, 0, 1, alice, bob, 10)}, result)
+	assert.Equal(t, native.SourceDebugTrace, scanner.Source())
+
+	// Falls back to the blocks when tracing isn't available either
+	debugClient.err = methodNotFoundError{}
+	debugClient.receipts = map[uint64][]*ethclient.Receipt{1: {receipt(1, nil)}}
+	scanner, err = native.NewScanner(client, native.Config{Accounts: []common.Address{alice}})
+	require.NoError(t, err)
+	result, err = scanner.Scan(context.Background(), 1, 1)
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Equal(t, native.SourceBlocks, scanner.Source())
+}
+
+func TestScan_AutoFallbackErrors(t *testing.T) {
+	newClient := func(err error) *fakeTraceClient {
+		debugClient := &fakeDebugClient{
+			fakeClient: &fakeClient{
+				blocks: map[uint64]*ethclient.BlockWithFullTxs{
+					1: block(1, tx(1, alice, &bob, 10)),
+				},
+			},
+			traces: map[uint64][]ethclient.TxTraceResult{
+				1: {{TxHash: hash(1), Result: &ethclient.CallFrame{Type: "CALL", From: alice, To: &bob, Value: big.NewInt(10)}}},
+			},
+		}
+		return &fakeTraceClient{fakeDebugClient: debugClient, err: err}
+	}
+
+	tests := []struct {
+		name   string
+		err    error
+		source native.Source
+		fails  bool
+	}{
+		{"geth message", errors.New("the method trace_filter does not exist/is not available"), native.SourceDebugTrace, false},
+		// Skipped for this scan only
+		{"provider message", errors.New("Method not supported on your plan"), native.SourceAuto, false},
+		{"method not allowed", errors.New("method not allowed"), native.SourceAuto, false},
+		{"other error", errors.New("header for hash does not exist"), native.SourceAuto, true},
+		{"state not available", errors.New("historical state is not available"), native.SourceAuto, true},
+		{"transaction not supported", errors.New("transaction type not supported"), native.SourceAuto, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner, err := native.NewScanner(newClient(tt.err), native.Config{Accounts: []common.Address{alice}})
+			require.NoError(t, err)
+
+			result, err := scanner.Scan(context.Background(), 1, 1)
+			if tt.fails {
+				require.ErrorIs(t, err, tt.err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, []transfers.Transfer{transfer(1, 0, 1, alice, bob, 10)}, result)
+			}
+			assert.Equal(t, tt.source, scanner.Source())
+		})
+	}
+}
+
+func TestNewScanner_SourceNotSupported(t *testing.T) {
+	_, err := native.NewScanner(&fakeClient{}, native.Config{Source: native.SourceTraceFilter})
+	assert.ErrorIs(t, err, native.ErrSourceNotSupported)
+
+	_, err = native.NewScanner(&fakeClient{}, native.Config{Source: "unknown"})
+	assert.ErrorIs(t, err, native.ErrInvalidSource)
+
+	_, err = native.NewScanner(nil, native.Config{})
+	assert.ErrorIs(t, err, native.ErrClientNotProvided)
+}
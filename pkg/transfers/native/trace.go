@@ -0,0 +1,211 @@
+package native
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/status-im/go-wallet-sdk/pkg/ethclient"
+	"github.com/status-im/go-wallet-sdk/pkg/transfers"
+)
+
+// Returns the value transfers of a callTracer call tree, skipping the reverted
+// calls and their subcalls. base holds the transaction fields.
+func callFrameTransfers(frame ethclient.CallFrame, traceAddress []int, base transfers.Transfer) []transfers.Transfer {
+	if frame.Error != "" {
+		return nil
+	}
+	var ret []transfers.Transfer
+	if frame.To != nil && frame.Value != nil && frame.Value.Sign() > 0 && movesValue(frame.Type) {
+		transfer := base
+		transfer.From = frame.From
+		transfer.To = *frame.To
+		transfer.Amount = frame.Value
+		transfer.TraceAddress = traceAddress
+		ret = append(ret, transfer)
+	}
+	for i, call := range frame.Calls {
+		ret = append(ret, callFrameTransfers(call, append(slices.Clone(traceAddress), i), base)...)
+	}
+	return ret
+}
+
+// Reports whether a callTracer frame type moves its value to the callee.
+// DELEGATECALL and CALLCODE keep the value in the calling contract.
+func movesValue(frameType string) bool {
+	switch frameType {
+	case "CALL", "CREATE", "CREATE2", "SELFDESTRUCT":
+		return true
+	}
+	return false
+}
+
+// Identifies a trace within the chain.
+type traceID struct {
+	txHash       common.Hash
+	traceAddress string
+}
+
+// Queries the traces of the accounts over sub-ranges, or all traces if no
+// account is set.
+func (s *Scanner) scanTraceFilter(ctx context.Context, fromBlock uint64, toBlock uint64) ([]transfers.Transfer, error) {
+	tracer := s.client.(TraceFilterClient)
+
+	var argsList []ethclient.TraceFilterArgs
+	for start := fromBlock; start <= toBlock; start += s.config.TraceFilterBlockRange {
+		end := min(start+s.config.TraceFilterBlockRange-1, toBlock)
+		args := ethclient.TraceFilterArgs{
+			FromBlock: new(big.Int).SetUint64(start),
+			ToBlock:   new(big.Int).SetUint64(end),
+		}
+		if len(s.config.Accounts) == 0 {
+			argsList = append(argsList, args)
+		} else {
+			// Addresses are matched as "from AND to" when both are set
+			sent, received := args, args
+			sent.FromAddress = s.config.Accounts
+			received.ToAddress = s.config.Accounts
+			argsList = append(argsList, sent, received)
+		}
+		if end == toBlock {
+			break
+		}
+	}
+
+	seen := make(map[traceID]bool)
+	var traces []ethclient.Trace
+	for _, args := range argsList {
+		result, err := tracer.TraceFilter(ctx, args)
+		if err != nil {
+			return nil, err
+		}
+		for _, trace := range result {
+			if trace.TransactionHash == nil {
+				// Block and uncle rewards
+				continue
+			}
+			id := traceID{*trace.TransactionHash, traceAddressKey(trace.TraceAddress)}
+			if !seen[id] {
+				seen[id] = true
+				traces = append(traces, trace)
+			}
+		}
+	}
+
+	// Calls known to have failed, or to have succeeded, per transaction
+	failed := make(map[common.Hash]map[string]bool)
+	succeeded := make(map[common.Hash]map[string]bool)
+	record := func(trace ethclient.Trace) {
+		txHash := *trace.TransactionHash
+		set := succeeded
+		if trace.Error != "" {
+			set = failed
+		}
+		if set[txHash] == nil {
+			set[txHash] = make(map[string]bool)
+		}
+		set[txHash][traceAddressKey(trace.TraceAddress)] = true
+	}
+	for _, trace := range traces {
+		record(trace)
+	}
+
+	// The whole call tree is needed to tell whether a call is part of a
+	// reverted call that wasn't returned
+	traced := make(map[common.Hash]bool)
+	for _, trace := range traces {
+		txHash := *trace.TransactionHash
+		if trace.Error != "" || traced[txHash] || !isValueTrace(trace) || knownAncestors(trace.TraceAddress, succeeded[txHash]) {
+			continue
+		}
+		traced[txHash] = true
+		all, err := tracer.TraceTransaction(ctx, txHash)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range all {
+			if t.TransactionHash != nil {
+				record(t)
+			}
+		}
+	}
+
+	ret := make([]transfers.Transfer, 0)
+	for _, trace := range traces {
+		txHash := *trace.TransactionHash
+		if trace.Error != "" || !isValueTrace(trace) || hasFailedAncestor(trace.TraceAddress, failed[txHash]) {
+			continue
+		}
+		transfer := transfers.Transfer{
+			Standard:    transfers.StandardNative,
+			TxHash:      txHash,
+			BlockNumber: trace.BlockNumber,
+			BlockHash:   trace.BlockHash,
+		}
+		if trace.TransactionPosition != nil {
+			transfer.TxIndex = uint(*trace.TransactionPosition)
+		}
+		if len(trace.TraceAddress) > 0 {
+			transfer.TraceAddress = trace.TraceAddress
+		}
+		switch trace.Type {
+		case "call":
+			transfer.From, transfer.To, transfer.Amount = trace.Action.From, *trace.Action.To, trace.Action.Value
+		case "create":
+			transfer.From, transfer.To, transfer.Amount = trace.Action.From, *trace.Result.Address, trace.Action.Value
+		case "suicide":
+			transfer.From, transfer.To, transfer.Amount = *trace.Action.Address, *trace.Action.RefundAddress, trace.Action.Balance
+		}
+		s.add(&ret, transfer)
+	}
+	return ret, nil
+}
+
+// Reports whether a successful trace moves value, with the fields needed to
+// build its transfer.
+func isValueTrace(trace ethclient.Trace) bool {
+	switch trace.Type {
+	case "call":
+		return (trace.Action.CallType == "" || trace.Action.CallType == "call") &&
+			trace.Action.To != nil && isPositive(trace.Action.Value)
+	case "create":
+		return trace.Result != nil && trace.Result.Address != nil && isPositive(trace.Action.Value)
+	case "suicide":
+		return trace.Action.Address != nil && trace.Action.RefundAddress != nil && isPositive(trace.Action.Balance)
+	}
+	return false
+}
+
+func isPositive(value *big.Int) bool {
+	return value != nil && value.Sign() > 0
+}
+
+// Reports whether all the calls containing a call are known to have succeeded.
+func knownAncestors(traceAddress []int, succeeded map[string]bool) bool {
+	for i := range traceAddress {
+		if !succeeded[traceAddressKey(traceAddress[:i])] {
+			return false
+		}
+	}
+	return true
+}
+
+// Reports whether one of the calls containing a call failed.
+func hasFailedAncestor(traceAddress []int, failed map[string]bool) bool {
+	for i := range traceAddress {
+		if failed[traceAddressKey(traceAddress[:i])] {
+			return true
+		}
+	}
+	return false
+}
+
+func traceAddressKey(traceAddress []int) string {
+	b := make([]byte, 0, len(traceAddress)*4)
+	for _, i := range traceAddress {
+		b = append(b, byte(i>>24), byte(i>>16), byte(i>>8), byte(i))
+	}
+	return string(b)
+}
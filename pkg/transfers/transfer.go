@@ -0,0 +1,112 @@
+package transfers
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc20"
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc721"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+)
+
+type Standard string
+
+const (
+	StandardNative  Standard = "native"
+	StandardERC20   Standard = "erc20"
+	StandardERC721  Standard = "erc721"
+	StandardERC1155 Standard = "erc1155"
+)
+
+// Transfer is a transfer of native coins or tokens of any standard.
+type Transfer struct {
+	Standard Standard
+	// Token contract, zero for native transfers
+	Contract common.Address
+	From     common.Address
+	To       common.Address
+	// Nil for native and ERC20 transfers
+	TokenID *big.Int
+	// Amount in base units (wei for native transfers), 1 for ERC721 transfers
+	Amount *big.Int
+	// Account that performed the transfer, only known for ERC1155 transfers
+	Operator common.Address
+
+	TxHash  common.Hash
+	TxIndex uint
+	// Index of the log in the block, 0 for native transfers
+	LogIndex    uint
+	BlockNumber uint64
+	BlockHash   common.Hash
+	// Position of the token ID within an ERC1155 TransferBatch, 0 otherwise
+	BatchIndex int
+	// Position of the call in the call tree of the transaction for internal
+	// native transfers (e.g. [0, 2] for the third call of the first call),
+	// nil for the transaction itself and for token transfers
+	TraceAddress []int
+}
+
+// IsInternal reports whether the transfer is a native transfer made by a
+// contract call rather than by the transaction itself.
+func (t Transfer) IsInternal() bool {
+	return len(t.TraceAddress) > 0
+}
+
+// FromEvent returns the transfers of a transfer event, one per token ID for
+// ERC1155 TransferBatch events. Returns nil for other events.
+func FromEvent(event eventlog.Event) []Transfer {
+	switch unpacked := event.Unpacked.(type) {
+	case erc20.Erc20Transfer:
+		return []Transfer{newTransfer(StandardERC20, unpacked.Raw, unpacked.From, unpacked.To, nil, unpacked.Value)}
+	case erc721.Erc721Transfer:
+		return []Transfer{newTransfer(StandardERC721, unpacked.Raw, unpacked.From, unpacked.To, unpacked.TokenId, big.NewInt(1))}
+	case erc1155.Erc1155TransferSingle:
+		transfer := newTransfer(StandardERC1155, unpacked.Raw, unpacked.From, unpacked.To, unpacked.Id, unpacked.Value)
+		transfer.Operator = unpacked.Operator
+		return []Transfer{transfer}
+	case erc1155.Erc1155TransferBatch:
+		ret := make([]Transfer, 0, len(unpacked.Ids))
+		for i, id := range unpacked.Ids {
+			// Values is as long as Ids in valid events
+			var value *big.Int
+			if i < len(unpacked.Values) {
+				value = unpacked.Values[i]
+			}
+			transfer := newTransfer(StandardERC1155, unpacked.Raw, unpacked.From, unpacked.To, id, value)
+			transfer.Operator = unpacked.Operator
+			transfer.BatchIndex = i
+			ret = append(ret, transfer)
+		}
+		return ret
+	}
+	return nil
+}
+
+// FromEvents returns the transfers of the transfer events, in order.
+// Other events are skipped.
+func FromEvents(events []eventlog.Event) []Transfer {
+	ret := make([]Transfer, 0, len(events))
+	for _, event := range events {
+		ret = append(ret, FromEvent(event)...)
+	}
+	return ret
+}
+
+func newTransfer(standard Standard, log types.Log, from, to common.Address, tokenID *big.Int, amount *big.Int) Transfer {
+	return Transfer{
+		Standard:    standard,
+		Contract:    log.Address,
+		From:        from,
+		To:          to,
+		TokenID:     tokenID,
+		Amount:      amount,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+		LogIndex:    log.Index,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+	}
+}
@@ -0,0 +1,115 @@
+package transfers_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/status-im/go-wallet-sdk/pkg/contracts/erc1155"
+	"github.com/status-im/go-wallet-sdk/pkg/eventlog"
+	"github.com/status-im/go-wallet-sdk/pkg/transfers"
+)
+
+var (
+	alice    = common.HexToAddress("0xa11ce00000000000000000000000000000000001")
+	bob      = common.HexToAddress("0xb0b0000000000000000000000000000000000002")
+	operator = common.HexToAddress("0x0be0000000000000000000000000000000000003")
+	token    = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	txHash   = common.HexToHash("0x0abc")
+)
+
+func addressTopic(address common.Address) common.Hash {
+	return common.BytesToHash(address.Bytes())
+}
+
+func testLog(index uint, topics []common.Hash, data []byte) types.Log {
+	return types.Log{
+		Address:     token,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: 100,
+		BlockHash:   common.HexToHash("0x0100"),
+		TxHash:      txHash,
+		Index:       index,
+	}
+}
+
+func parse(t *testing.T, log types.Log) eventlog.Event {
+	t.Helper()
+	events := eventlog.ParseLog(log)
+	require.Len(t, events, 1)
+	return events[0]
+}
+
+func TestFromEvent_ERC20(t *testing.T) {
+	log := testLog(3, []common.Hash{eventlog.ERC20TransferID, addressTopic(alice), addressTopic(bob)}, common.BigToHash(big.NewInt(500)).Bytes())
+
+	assert.Equal(t, []transfers.Transfer{{
+		Standard:    transfers.StandardERC20,
+		Contract:    token,
+		From:        alice,
+		To:          bob,
+		Amount:      big.NewInt(500),
+		TxHash:      txHash,
+		LogIndex:    3,
+		BlockNumber: 100,
+		BlockHash:   common.HexToHash("0x0100"),
+	}}, transfers.FromEvent(parse(t, log)))
+}
+
+func TestFromEvent_ERC721(t *testing.T) {
+	log := testLog(0, []common.Hash{eventlog.ERC721TransferID, addressTopic(alice), addressTopic(bob), common.BigToHash(big.NewInt(42))}, nil)
+
+	got := transfers.FromEvent(parse(t, log))
+	require.Len(t, got, 1)
+	assert.Equal(t, transfers.StandardERC721, got[0].Standard)
+	assert.Equal(t, big.NewInt(42), got[0].TokenID)
+	assert.Equal(t, big.NewInt(1), got[0].Amount)
+	assert.Equal(t, common.Address{}, got[0].Operator)
+}
+
+func TestFromEvent_ERC1155(t *testing.T) {
+	erc1155ABI, err := erc1155.Erc1155MetaData.GetAbi()
+	require.NoError(t, err)
+	topics := []common.Hash{addressTopic(operator), addressTopic(alice), addressTopic(bob)}
+
+	data, err := erc1155ABI.Events["TransferSingle"].Inputs.NonIndexed().Pack(big.NewInt(7), big.NewInt(3))
+	require.NoError(t, err)
+	single := transfers.FromEvent(parse(t, testLog(0, append([]common.Hash{eventlog.ERC1155TransferSingleID}, topics...), data)))
+	require.Len(t, single, 1)
+	assert.Equal(t, transfers.StandardERC1155, single[0].Standard)
+	assert.Equal(t, operator, single[0].Operator)
+	assert.Equal(t, big.NewInt(7), single[0].TokenID)
+	assert.Equal(t, big.NewInt(3), single[0].Amount)
+
+	data, err = erc1155ABI.Events["TransferBatch"].Inputs.NonIndexed().Pack(
+		[]*big.Int{big.NewInt(1), big.NewInt(2)},
+		[]*big.Int{big.NewInt(10), big.NewInt(20)},
+	)
+	require.NoError(t, err)
+	batch := transfers.FromEvent(parse(t, testLog(1, append([]common.Hash{eventlog.ERC1155TransferBatchID}, topics...), data)))
+	require.Len(t, batch, 2)
+	for i, transfer := range batch {
+		assert.Equal(t, operator, transfer.Operator)
+		assert.Equal(t, alice, transfer.From)
+		assert.Equal(t, bob, transfer.To)
+		assert.Equal(t, uint(1), transfer.LogIndex)
+		assert.Equal(t, i, transfer.BatchIndex)
+		assert.Equal(t, big.NewInt(int64(i+1)), transfer.TokenID)
+		assert.Equal(t, big.NewInt(int64(10*(i+1))), transfer.Amount)
+	}
+}
+
+func TestFromEvents_SkipsOtherEvents(t *testing.T) {
+	approval := testLog(0, []common.Hash{eventlog.ERC20ApprovalID, addressTopic(alice), addressTopic(bob)}, common.BigToHash(big.NewInt(1)).Bytes())
+	transfer := testLog(1, []common.Hash{eventlog.ERC20TransferID, addressTopic(alice), addressTopic(bob)}, common.BigToHash(big.NewInt(1)).Bytes())
+
+	got := transfers.FromEvents([]eventlog.Event{parse(t, approval), parse(t, transfer)})
+	require.Len(t, got, 1)
+	assert.Equal(t, uint(1), got[0].LogIndex)
+	assert.Nil(t, transfers.FromEvent(parse(t, approval)))
+}